@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"io"
 	"runtime/debug"
+	"strconv"
 	"strings"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -26,6 +28,9 @@ import (
 // and to avoid affecting the Merkle root.
 var dbHeaderKey = []byte("header")
 
+// Key to store the consensus params in the main store.
+var mainConsensusParamsKey = []byte("consensus_params")
+
 // Enum mode for app.runTx
 type runTxMode uint8
 
@@ -51,6 +56,7 @@ type BaseApp struct {
 	txDecoder   sdk.TxDecoder        // unmarshal []byte into sdk.Tx
 
 	anteHandler sdk.AnteHandler // ante handler for fee and auth
+	baseKey     sdk.StoreKey    // main KVStore in cms
 
 	// may be nil
 	initChainer      sdk.InitChainer  // initialize state with validators and state blob
@@ -59,6 +65,11 @@ type BaseApp struct {
 	addrPeerFilter   sdk.PeerFilter   // filter peers by address and port
 	pubkeyPeerFilter sdk.PeerFilter   // filter peers by public key
 
+	// priority functions by the type of the first Msg of a transaction
+	txPriorities map[string]sdk.TxPriorityFunc
+
+	metrics *Metrics
+
 	//--------------------
 	// Volatile
 	// checkState is set on initialization and reset on Commit.
@@ -68,6 +79,14 @@ type BaseApp struct {
 	deliverState     *state                  // for DeliverTx
 	signedValidators []abci.SigningValidator // absent validators from begin block
 
+	// consensus params, with the maximum gas of a block
+	consensusParams *abci.ConsensusParams
+
+	// hashes of the transactions which passed CheckTx since the last Commit,
+	// and of those which passed it before and are yet to be rechecked
+	checkedTxs map[string]struct{}
+	recheckTxs map[string]struct{}
+
 	// flag for sealing
 	sealed bool
 }
@@ -93,6 +112,9 @@ func NewBaseApp(name string, logger log.Logger, db dbm.DB, txDecoder sdk.TxDecod
 		queryRouter: NewQueryRouter(),
 		codespacer:  sdk.NewCodespacer(),
 		txDecoder:   txDecoder,
+		metrics:     NopMetrics(),
+		checkedTxs:  make(map[string]struct{}),
+		recheckTxs:  make(map[string]struct{}),
 	}
 
 	// Register the undefined & root codespaces, which should not be used by
@@ -180,6 +202,19 @@ func (app *BaseApp) initFromStore(mainKey sdk.StoreKey) error {
 	if main == nil {
 		return errors.New("baseapp expects MultiStore with 'main' KVStore")
 	}
+	app.baseKey = mainKey
+
+	// Load the consensus params from the main store. If they are not found,
+	// they are stored by InitChain.
+	consensusParamsBz := main.Get(mainConsensusParamsKey)
+	if consensusParamsBz != nil {
+		var consensusParams = &abci.ConsensusParams{}
+		err := proto.Unmarshal(consensusParamsBz, consensusParams)
+		if err != nil {
+			return errors.Wrap(err, "failed to decode the consensus params")
+		}
+		app.consensusParams = consensusParams
+	}
 	// Needed for `gaiad export`, which inits from store but never calls initchain
 	app.setCheckState(abci.Header{})
 
@@ -221,6 +256,27 @@ func (app *BaseApp) setDeliverState(header abci.Header) {
 	}
 }
 
+// stores the consensus params in the main store, to be loaded on restart
+func (app *BaseApp) storeConsensusParams(consensusParams *abci.ConsensusParams) {
+	consensusParamsBz, err := proto.Marshal(consensusParams)
+	if err != nil {
+		panic(err)
+	}
+	mainStore := app.cms.GetKVStore(app.baseKey)
+	mainStore.Set(mainConsensusParamsKey, consensusParamsBz)
+}
+
+// returns the maximum gas of a block, 0 if it is unlimited
+func (app *BaseApp) getMaximumBlockGas() int64 {
+	if app.consensusParams == nil || app.consensusParams.BlockSize == nil {
+		return 0
+	}
+	if maxGas := app.consensusParams.BlockSize.MaxGas; maxGas > 0 {
+		return maxGas
+	}
+	return 0
+}
+
 //______________________________________________________________________________
 
 // ABCI
@@ -249,6 +305,11 @@ func (app *BaseApp) InitChain(req abci.RequestInitChain) (res abci.ResponseInitC
 	app.setDeliverState(abci.Header{ChainID: req.ChainId})
 	app.setCheckState(abci.Header{ChainID: req.ChainId})
 
+	if req.ConsensusParams != nil {
+		app.consensusParams = req.ConsensusParams
+		app.storeConsensusParams(req.ConsensusParams)
+	}
+
 	if app.initChainer == nil {
 		return
 	}
@@ -422,6 +483,15 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 		app.deliverState.ctx = app.deliverState.ctx.WithBlockHeader(req.Header).WithBlockHeight(req.Header.Height)
 	}
 
+	// the txs of the block consume its gas
+	var blockGasMeter sdk.GasMeter
+	if maxGas := app.getMaximumBlockGas(); maxGas > 0 {
+		blockGasMeter = sdk.NewGasMeter(maxGas)
+	} else {
+		blockGasMeter = sdk.NewInfiniteGasMeter()
+	}
+	app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(blockGasMeter)
+
 	if app.beginBlocker != nil {
 		res = app.beginBlocker(app.deliverState.ctx, req)
 	}
@@ -438,15 +508,41 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 // then finally the route match to see whether a handler exists. CheckTx does not run the actual
 // Msg handler function(s).
 func (app *BaseApp) CheckTx(txBytes []byte) (res abci.ResponseCheckTx) {
+	// Tendermint checks the transactions left in the mempool again after a
+	// Commit, against the new state
+	hash := string(tmhash.Sum(txBytes))
+	_, recheck := app.recheckTxs[hash]
+	delete(app.recheckTxs, hash)
+
 	// Decode the Tx.
 	var result sdk.Result
 	var tx, err = app.txDecoder(txBytes)
 	if err != nil {
 		result = err.Result()
+	} else if recheck {
+		st := &state{
+			ms:  app.checkState.ms,
+			ctx: app.checkState.ctx.WithIsReCheckTx(true),
+		}
+		result = app.runTxOnState(st, runTxModeCheck, txBytes, tx)
 	} else {
 		result = app.runTx(runTxModeCheck, txBytes, tx)
 	}
 
+	if recheck {
+		app.metrics.RecheckedTxs.Add(1)
+		if !result.IsOK() {
+			app.metrics.EvictedTxs.Add(1)
+		}
+	}
+
+	if result.IsOK() {
+		app.checkedTxs[hash] = struct{}{}
+
+		priority := []byte(strconv.FormatInt(result.Priority, 10))
+		result.Tags = result.Tags.AppendTag(sdk.TagPriority, priority)
+	}
+
 	return abci.ResponseCheckTx{
 		Code:      uint32(result.Code),
 		Data:      result.Data,
@@ -459,6 +555,8 @@ func (app *BaseApp) CheckTx(txBytes []byte) (res abci.ResponseCheckTx) {
 
 // Implements ABCI
 func (app *BaseApp) DeliverTx(txBytes []byte) (res abci.ResponseDeliverTx) {
+	app.forgetCheckedTx(txBytes)
+
 	// Decode the Tx.
 	var result sdk.Result
 	var tx, err = app.txDecoder(txBytes)
@@ -503,9 +601,9 @@ func validateBasicTxMsgs(msgs []sdk.Msg) sdk.Error {
 
 // retrieve the context for the ante handler and store the tx bytes; store
 // the signing validators if the tx runs within the deliverTx() state.
-func (app *BaseApp) getContextForAnte(mode runTxMode, txBytes []byte) (ctx sdk.Context) {
+func (app *BaseApp) getContextForAnte(st *state, mode runTxMode, txBytes []byte) (ctx sdk.Context) {
 	// Get the context
-	ctx = getState(app, mode).ctx.WithTxBytes(txBytes)
+	ctx = st.ctx.WithTxBytes(txBytes)
 	if mode == runTxModeDeliver {
 		ctx = ctx.WithSigningValidators(app.signedValidators)
 	}
@@ -564,6 +662,14 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (re
 	return result
 }
 
+// Forgets that a transaction delivered in a block, which Tendermint removes
+// from the mempool, passed CheckTx.
+func (app *BaseApp) forgetCheckedTx(txBytes []byte) {
+	hash := string(tmhash.Sum(txBytes))
+	delete(app.checkedTxs, hash)
+	delete(app.recheckTxs, hash)
+}
+
 // Returns the applicantion's deliverState if app is in runTxModeDeliver,
 // otherwise it returns the application's checkstate.
 func getState(app *BaseApp, mode runTxMode) *state {
@@ -585,12 +691,18 @@ func (app *BaseApp) initializeContext(ctx sdk.Context, mode runTxMode) sdk.Conte
 // anteHandler. txBytes may be nil in some cases, eg. in tests. Also, in the
 // future we may support "internal" transactions.
 func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk.Result) {
+	return app.runTxOnState(getState(app, mode), mode, txBytes, tx)
+}
+
+// runTxOnState processes a transaction against the given state, which is the
+// state of the mode unless the transaction is rechecked.
+func (app *BaseApp) runTxOnState(st *state, mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk.Result) {
 	// NOTE: GasWanted should be returned by the AnteHandler. GasUsed is
 	// determined by the GasMeter. We need access to the context to get the gas
 	// meter so we initialize upfront.
-	var gasWanted int64
+	var gasWanted, priority int64
 	var msCache sdk.CacheMultiStore
-	ctx := app.getContextForAnte(mode, txBytes)
+	ctx := app.getContextForAnte(st, mode, txBytes)
 	ctx = app.initializeContext(ctx, mode)
 
 	defer func() {
@@ -609,6 +721,25 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 		result.GasUsed = ctx.GasMeter().GasConsumed()
 	}()
 
+	// Once a block ran out of gas, its txs are rejected. The gas consumed by
+	// a tx is added to the gas of its block whether it fails or not, failing
+	// out of gas if the block has not enough gas left.
+	var blockGasConsumed bool
+	if mode == runTxModeDeliver {
+		if ctx.BlockGasMeter().IsOutOfGas() {
+			return sdk.ErrOutOfGas("no block gas left to run tx").Result()
+		}
+		// the gas of the tx is metered apart, unless the AnteHandler sets a
+		// limit
+		ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+
+		defer func() {
+			if !blockGasConsumed {
+				ctx.BlockGasMeter().ConsumeGas(ctx.GasMeter().GasConsumed(), "block gas meter")
+			}
+		}()
+	}
+
 	var msgs = tx.GetMsgs()
 	if err := validateBasicTxMsgs(msgs); err != nil {
 		return err.Result()
@@ -625,6 +756,13 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 		}
 
 		gasWanted = result.GasWanted
+		priority = result.Priority
+	}
+
+	if mode == runTxModeCheck {
+		if priorityFn, ok := app.txPriorities[msgs[0].Type()]; ok {
+			priority = priorityFn(ctx, tx)
+		}
 	}
 
 	if mode == runTxModeSimulate {
@@ -635,7 +773,7 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 
 	// Keep the state in a transient CacheWrap in case processing the messages
 	// fails.
-	msCache = getState(app, mode).CacheMultiStore()
+	msCache = st.CacheMultiStore()
 	if msCache.TracingEnabled() {
 		msCache = msCache.WithTracingContext(sdk.TraceContext(
 			map[string]interface{}{"txHash": cmn.HexBytes(tmhash.Sum(txBytes)).String()},
@@ -645,9 +783,17 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 	ctx = ctx.WithMultiStore(msCache)
 	result = app.runMsgs(ctx, msgs, mode)
 	result.GasWanted = gasWanted
+	if mode == runTxModeCheck {
+		result.Priority = priority
+	}
 
-	// only update state if all messages pass
+	// only update state if all messages pass, and if the block has enough gas
+	// left
 	if result.IsOK() {
+		if mode == runTxModeDeliver {
+			blockGasConsumed = true
+			ctx.BlockGasMeter().ConsumeGas(ctx.GasMeter().GasConsumed(), "block gas meter")
+		}
 		msCache.Write()
 	}
 
@@ -692,6 +838,9 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	// Use the header from this latest block.
 	app.setCheckState(header)
 
+	// The transactions left in the mempool are to be rechecked
+	app.recheckTxs, app.checkedTxs = app.checkedTxs, make(map[string]struct{})
+
 	// Empty the Deliver state
 	app.deliverState = nil
 
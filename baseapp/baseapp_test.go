@@ -5,8 +5,11 @@ import (
 	"encoding/binary"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"testing"
 
+	"github.com/go-kit/kit/metrics"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -475,6 +478,153 @@ func TestCheckTx(t *testing.T) {
 	require.Nil(t, storedBytes)
 }
 
+// Test that CheckTx returns the priority returned by the AnteHandler, or by
+// the function set for the type of the first Msg, so that a mempool orders
+// the txs paying the most first
+func TestCheckTxPriority(t *testing.T) {
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
+			// the counter of the tx stands for its fee
+			res.Priority = tx.(txTest).Counter
+			return
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		noopHandler := func(ctx sdk.Context, msg sdk.Msg) sdk.Result { return sdk.Result{} }
+		bapp.Router().AddRoute(typeMsgCounter, noopHandler)
+		bapp.Router().AddRoute(typeMsgCounter2, noopHandler)
+	}
+	priorityOpt := SetTxPriority(typeMsgCounter2, func(ctx sdk.Context, tx sdk.Tx) int64 { return 100 })
+
+	app := setupBaseApp(t, anteOpt, routerOpt, priorityOpt)
+	app.InitChain(abci.RequestInitChain{})
+
+	// Create same codec used in txDecoder
+	codec := codec.New()
+	registerTestCodec(codec)
+
+	txs := []*txTest{
+		newTxCounter(1, 0),
+		newTxCounter(5, 0),
+		newTxCounter(3, 0),
+		{Msgs: []sdk.Msg{msgCounter2{0}}, Counter: 0},
+		newTxCounter(5, 0),
+	}
+
+	// a mock mempool ordering the txs by priority, then by arrival
+	type mempoolTx struct {
+		index    int
+		priority int64
+	}
+	var mempool []mempoolTx
+	for i, tx := range txs {
+		txBytes, err := codec.MarshalBinary(tx)
+		require.NoError(t, err)
+		r := app.CheckTx(txBytes)
+		require.True(t, r.IsOK(), fmt.Sprintf("%v", r))
+
+		var priority []byte
+		for _, tag := range r.Tags {
+			if string(tag.Key) == sdk.TagPriority {
+				priority = tag.Value
+			}
+		}
+		p, err := strconv.ParseInt(string(priority), 10, 64)
+		require.NoError(t, err)
+		mempool = append(mempool, mempoolTx{i, p})
+	}
+
+	sort.SliceStable(mempool, func(i, j int) bool {
+		return mempool[i].priority > mempool[j].priority
+	})
+	var order []int
+	for _, tx := range mempool {
+		order = append(order, tx.index)
+	}
+	require.Equal(t, []int{3, 1, 4, 2, 0}, order)
+}
+
+// counts in memory
+type testCounter struct {
+	value float64
+}
+
+func (c *testCounter) With(labelValues ...string) metrics.Counter { return c }
+func (c *testCounter) Add(delta float64)                          { c.value += delta }
+
+// Test that the txs which passed CheckTx and were not delivered in a block
+// are rechecked after Commit, and that the failing ones are counted
+func TestCheckTxRecheck(t *testing.T) {
+	var reChecks []bool
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
+			reChecks = append(reChecks, ctx.IsReCheckTx())
+			// the txs with an odd counter are no longer valid after a block
+			if ctx.IsReCheckTx() && tx.(txTest).Counter%2 == 1 {
+				return ctx, sdk.ErrInvalidSequence("").Result(), true
+			}
+			return
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(typeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result { return sdk.Result{} })
+	}
+	rechecked, evicted := &testCounter{}, &testCounter{}
+	metricsOpt := SetMetrics(&Metrics{RecheckedTxs: rechecked, EvictedTxs: evicted})
+
+	app := setupBaseApp(t, anteOpt, routerOpt, metricsOpt)
+	app.InitChain(abci.RequestInitChain{})
+
+	// Create same codec used in txDecoder
+	codec := codec.New()
+	registerTestCodec(codec)
+
+	var txs [][]byte
+	for i := int64(0); i < 4; i++ {
+		txBytes, err := codec.MarshalBinary(newTxCounter(i, 0))
+		require.NoError(t, err)
+		txs = append(txs, txBytes)
+	}
+	checkTxs := func(txs ...[]byte) (codes []uint32) {
+		for _, txBytes := range txs {
+			codes = append(codes, app.CheckTx(txBytes).Code)
+		}
+		return
+	}
+	ok, invalid := uint32(sdk.CodeOK), uint32(sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidSequence))
+
+	require.Equal(t, []uint32{ok, ok, ok, ok}, checkTxs(txs...))
+	require.Equal(t, []bool{false, false, false, false}, reChecks)
+
+	// the first tx is delivered
+	app.BeginBlock(abci.RequestBeginBlock{})
+	app.DeliverTx(txs[0])
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	// the others are rechecked once
+	reChecks = nil
+	require.Equal(t, []uint32{ok, invalid, ok, invalid}, checkTxs(txs...))
+	require.Equal(t, []bool{false, true, true, true}, reChecks)
+	require.Equal(t, float64(3), rechecked.value)
+	require.Equal(t, float64(2), evicted.value)
+
+	reChecks = nil
+	checkTxs(txs[2])
+	require.Equal(t, []bool{false}, reChecks)
+
+	// the tx which passed its recheck is rechecked after the next block
+	app.BeginBlock(abci.RequestBeginBlock{})
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	reChecks = nil
+	require.Equal(t, []uint32{ok}, checkTxs(txs[2]))
+	require.Equal(t, []bool{true}, reChecks)
+	require.Equal(t, float64(4), rechecked.value)
+	require.Equal(t, float64(2), evicted.value)
+}
+
 // Test that successive DeliverTx can see each others' effects
 // on the store, both within and across blocks.
 func TestDeliverTx(t *testing.T) {
@@ -820,6 +970,111 @@ func TestTxGasLimits(t *testing.T) {
 	}
 }
 
+func TestMaxBlockGasLimits(t *testing.T) {
+	gasGranted := int64(10)
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
+			newCtx = ctx.WithGasMeter(sdk.NewGasMeter(gasGranted))
+
+			defer func() {
+				if r := recover(); r != nil {
+					switch rType := r.(type) {
+					case sdk.ErrorOutOfGas:
+						log := fmt.Sprintf("out of gas in location: %v", rType.Descriptor)
+						res = sdk.ErrOutOfGas(log).Result()
+						res.GasWanted = gasGranted
+						res.GasUsed = newCtx.GasMeter().GasConsumed()
+					default:
+						panic(r)
+					}
+				}
+			}()
+
+			count := tx.(*txTest).Counter
+			newCtx.GasMeter().ConsumeGas(count, "counter-ante")
+			res = sdk.Result{
+				GasWanted: gasGranted,
+			}
+			return
+		})
+	}
+
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(typeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			count := msg.(msgCounter).Counter
+			ctx.GasMeter().ConsumeGas(count, "counter-handler")
+			return sdk.Result{}
+		})
+	}
+
+	db := dbm.NewMemDB()
+	codec := codec.New()
+	registerTestCodec(codec)
+	app := NewBaseApp(t.Name(), defaultLogger(), db, testTxDecoder(codec), anteOpt, routerOpt)
+	app.MountStoresIAVL(capKey1)
+	require.Nil(t, app.LoadLatestVersion(capKey1))
+
+	app.InitChain(abci.RequestInitChain{
+		ConsensusParams: &abci.ConsensusParams{
+			BlockSize: &abci.BlockSize{
+				MaxGas: 100,
+			},
+		},
+	})
+
+	testCases := []struct {
+		tx                *txTest
+		numDelivers       int
+		gasUsedPerDeliver int64
+		fail              bool
+		failAfterDeliver  int
+	}{
+		{newTxCounter(0, 0), 0, 0, false, 0},
+		{newTxCounter(9, 1), 2, 10, false, 0},
+		{newTxCounter(10, 0), 3, 10, false, 0},
+		{newTxCounter(2, 7), 11, 9, false, 0},
+		{newTxCounter(10, 0), 10, 10, false, 0}, // fill the block
+
+		{newTxCounter(10, 0), 11, 10, true, 10},
+		{newTxCounter(10, 0), 15, 10, true, 10},
+		{newTxCounter(9, 0), 12, 9, true, 11}, // go past the limit
+	}
+
+	for i, tc := range testCases {
+		tx := tc.tx
+
+		// reset the block gas
+		app.BeginBlock(abci.RequestBeginBlock{})
+
+		// execute the transaction multiple times
+		for j := 0; j < tc.numDelivers; j++ {
+			res := app.Deliver(tx)
+
+			ctx := getState(app, runTxModeDeliver).ctx
+			blockGasUsed := ctx.BlockGasMeter().GasConsumed()
+
+			// check for failed transactions
+			if tc.fail && (j+1) > tc.failAfterDeliver {
+				require.Equal(t, res.Code, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfGas), fmt.Sprintf("%d: %v, %v", i, tc, res))
+				require.True(t, ctx.BlockGasMeter().IsOutOfGas())
+				continue
+			}
+
+			// check the gas used by the tx and cumulated by the block
+			require.True(t, res.IsOK(), fmt.Sprintf("%d,%d: %v, %v", i, j, tc, res))
+			require.Equal(t, tc.gasUsedPerDeliver, res.GasUsed)
+			require.Equal(t, tc.gasUsedPerDeliver*int64(j+1), blockGasUsed)
+		}
+	}
+
+	// the consensus params are loaded on restart
+	app.Commit()
+	app = NewBaseApp(t.Name(), defaultLogger(), db, testTxDecoder(codec))
+	app.MountStoresIAVL(capKey1)
+	require.Nil(t, app.LoadLatestVersion(capKey1))
+	require.Equal(t, int64(100), app.getMaximumBlockGas())
+}
+
 //-------------------------------------------------------------------------------------------
 // Queries
 
@@ -0,0 +1,44 @@
+package baseapp
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics contains the metrics exposed by the BaseApp.
+type Metrics struct {
+	// Number of transactions rechecked after a Commit.
+	RecheckedTxs metrics.Counter
+	// Number of transactions failing their recheck, which Tendermint evicts
+	// from the mempool.
+	EvictedTxs metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics built using the Prometheus client
+// library, registered with its default registerer.
+func PrometheusMetrics(namespace string) *Metrics {
+	return &Metrics{
+		RecheckedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "baseapp",
+			Name:      "rechecked_txs",
+			Help:      "Number of transactions rechecked after a block.",
+		}, []string{}),
+		EvictedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "baseapp",
+			Name:      "evicted_txs",
+			Help:      "Number of transactions evicted from the mempool by their recheck.",
+		}, []string{}),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		RecheckedTxs: discard.NewCounter(),
+		EvictedTxs:   discard.NewCounter(),
+	}
+}
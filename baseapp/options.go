@@ -26,3 +26,22 @@ func SetPruning(pruning string) func(*BaseApp) {
 		bap.cms.SetPruning(pruningEnum)
 	}
 }
+
+// SetTxPriority sets the function computing the priority returned by CheckTx
+// for the transactions whose first Msg is of a type, instead of the priority
+// returned by the AnteHandler
+func SetTxPriority(msgType string, priority sdk.TxPriorityFunc) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		if bap.txPriorities == nil {
+			bap.txPriorities = make(map[string]sdk.TxPriorityFunc)
+		}
+		bap.txPriorities[msgType] = priority
+	}
+}
+
+// SetMetrics sets the metrics the app reports
+func SetMetrics(metrics *Metrics) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		bap.metrics = metrics
+	}
+}
@@ -0,0 +1,74 @@
+package context
+
+import (
+	"path/filepath"
+	"sync"
+
+	tmlite "github.com/tendermint/tendermint/lite"
+	tmliteErr "github.com/tendermint/tendermint/lite/errors"
+	tmliteFiles "github.com/tendermint/tendermint/lite/files"
+)
+
+// directory of the home under which the trusted commits are stored, by chain
+const trustDir = "trust"
+
+// certifier certifies the commits of the node from its trusted commits, and
+// stores the latest commit it certified so the trust advances between runs.
+// The first run of a chain trusts the latest commit of the node.
+type certifier struct {
+	mtx     sync.Mutex
+	cert    *tmlite.InquiringCertifier
+	trusted tmlite.Provider
+}
+
+var _ tmlite.Certifier = (*certifier)(nil)
+
+// newCertifier returns a certifier of the chain, which trusts the commits
+// stored under the home and fetches the others from the source
+func newCertifier(chainID, home string, source tmlite.Provider) (*certifier, error) {
+	trusted := tmlite.NewCacheProvider(
+		tmlite.NewMemStoreProvider(),
+		tmliteFiles.NewProvider(filepath.Join(home, trustDir, chainID)),
+	)
+
+	fc, err := trusted.LatestCommit()
+	if tmliteErr.IsCommitNotFoundErr(err) {
+		fc, err = source.LatestCommit()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tmlite.NewInquiringCertifier(chainID, fc, trusted, source)
+	if err != nil {
+		return nil, err
+	}
+	return &certifier{cert: cert, trusted: trusted}, nil
+}
+
+// ChainID implements tmlite.Certifier
+func (c *certifier) ChainID() string {
+	return c.cert.ChainID()
+}
+
+// Certify implements tmlite.Certifier, storing the commit when it is the
+// latest one certified
+func (c *certifier) Certify(commit tmlite.Commit) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	err := c.cert.Certify(commit)
+	if err != nil {
+		return err
+	}
+
+	latest, err := c.trusted.LatestCommit()
+	if err != nil {
+		return err
+	}
+	if commit.Height() <= latest.Height() {
+		return nil
+	}
+	// the validators of the certifier are the ones which signed the commit
+	return c.trusted.StoreCommit(tmlite.NewFullCommit(commit, c.cert.Validators()))
+}
@@ -0,0 +1,56 @@
+package context
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tmlite "github.com/tendermint/tendermint/lite"
+)
+
+func TestCertifierPersistence(t *testing.T) {
+	home, err := ioutil.TempDir("", "certifier")
+	require.NoError(t, err)
+	defer os.RemoveAll(home)
+
+	keys := tmlite.GenValKeys(4)
+	vals := keys.ToValidators(10, 0)
+	source := tmlite.NewMemStoreProvider()
+	require.NoError(t, source.StoreCommit(
+		keys.GenFullCommit(testChainID, 1, nil, vals, nil, nil, nil, 0, len(keys))))
+
+	// the first run trusts the latest commit of the source
+	cert, err := newCertifier(testChainID, home, source)
+	require.NoError(t, err)
+	require.NoError(t, cert.Certify(keys.GenCommit(testChainID, 5, nil, vals, nil, nil, nil, 0, len(keys))))
+
+	// forged commits aren't certified, nor stored
+	other := tmlite.GenValKeys(4)
+	require.Error(t, cert.Certify(other.GenCommit(testChainID, 8, nil, vals, nil, nil, nil, 0, len(other))))
+	require.Error(t, cert.Certify(keys.GenCommit("other-chain", 8, nil, vals, nil, nil, nil, 0, len(keys))))
+
+	// the next run trusts the latest commit certified, whatever the source
+	forged := tmlite.NewMemStoreProvider()
+	otherVals := other.ToValidators(10, 0)
+	require.NoError(t, forged.StoreCommit(
+		other.GenFullCommit(testChainID, 10, nil, otherVals, nil, nil, nil, 0, len(other))))
+	cert, err = newCertifier(testChainID, home, forged)
+	require.NoError(t, err)
+	latest, err := cert.trusted.LatestCommit()
+	require.NoError(t, err)
+	require.Equal(t, int64(5), latest.Height())
+	require.NoError(t, cert.Certify(keys.GenCommit(testChainID, 6, nil, vals, nil, nil, nil, 0, len(keys))))
+	require.Error(t, cert.Certify(other.GenCommit(testChainID, 10, nil, otherVals, nil, nil, nil, 0, len(other))))
+
+	// the trusted commits are stored by chain
+	otherSource := tmlite.NewMemStoreProvider()
+	require.NoError(t, otherSource.StoreCommit(
+		other.GenFullCommit("other-chain", 3, nil, otherVals, nil, nil, nil, 0, len(other))))
+	cert, err = newCertifier("other-chain", home, otherSource)
+	require.NoError(t, err)
+	latest, err = cert.trusted.LatestCommit()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), latest.Height())
+}
@@ -13,7 +13,7 @@ import (
 
 	"github.com/tendermint/tendermint/libs/cli"
 	tmlite "github.com/tendermint/tendermint/lite"
-	tmliteProxy "github.com/tendermint/tendermint/lite/proxy"
+	tmliteClient "github.com/tendermint/tendermint/lite/client"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 )
 
@@ -90,7 +90,7 @@ func createCertifier() tmlite.Certifier {
 	if errMsg.Len() != 0 {
 		panic(fmt.Errorf("can't create certifier for distrust mode, empty values from these options: %s", errMsg.String()))
 	}
-	certifier, err := tmliteProxy.GetCertifier(chainID, home, nodeURI)
+	certifier, err := newCertifier(chainID, home, tmliteClient.NewHTTPProvider(nodeURI))
 	if err != nil {
 		panic(err)
 	}
@@ -16,11 +16,14 @@ import (
 	"github.com/cosmos/cosmos-sdk/store"
 	abci "github.com/tendermint/tendermint/abci/types"
 	cmn "github.com/tendermint/tendermint/libs/common"
-	tmliteProxy "github.com/tendermint/tendermint/lite/proxy"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
+// DefaultPageLimit is the number of key-value pairs of each page queried by
+// QuerySubspaceAll.
+const DefaultPageLimit = 100
+
 // GetNode returns an RPC client. If the context's client is not defined, an
 // error is returned.
 func (ctx CLIContext) GetNode() (rpcclient.Client, error) {
@@ -48,7 +51,8 @@ func (ctx CLIContext) QueryStore(key cmn.HexBytes, storeName string) (res []byte
 }
 
 // QuerySubspace performs a query from a Tendermint node with the provided
-// store name and subspace.
+// store name and subspace. The response carries no proof, so the node must be
+// trusted; QuerySubspaceAll queries a subspace with proofs.
 func (ctx CLIContext) QuerySubspace(subspace []byte, storeName string) (res []sdk.KVPair, err error) {
 	resRaw, err := ctx.queryStore(subspace, storeName, "subspace")
 	if err != nil {
@@ -59,6 +63,37 @@ func (ctx CLIContext) QuerySubspace(subspace []byte, storeName string) (res []sd
 	return
 }
 
+// QuerySubspacePaginated performs a query from a Tendermint node of at most
+// limit key-value pairs of a store under a subspace, starting from startKey or
+// from the beginning of the subspace if it is nil. The next key, nil after the
+// last page, starts the next page. A limit that is not positive, or too
+// high, is replaced by the maximum of the store.
+func (ctx CLIContext) QuerySubspacePaginated(subspace []byte, storeName string, startKey []byte, limit int) (
+	res []sdk.KVPair, nextKey []byte, err error) {
+
+	res, nextKey, _, err = ctx.querySubspacePage(subspace, storeName, startKey, limit)
+	return
+}
+
+// QuerySubspaceAll performs a query from a Tendermint node of all the
+// key-value pairs of a store under a subspace, one page at a time at the
+// height of the first page.
+func (ctx CLIContext) QuerySubspaceAll(subspace []byte, storeName string) (res []sdk.KVPair, err error) {
+	var startKey []byte
+	for {
+		kvs, nextKey, height, err := ctx.querySubspacePage(subspace, storeName, startKey, DefaultPageLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, kvs...)
+		if nextKey == nil {
+			return res, nil
+		}
+		ctx.Height, startKey = height, nextKey
+	}
+}
+
 // GetAccount queries for an account given an address and a block height. An
 // error is returned if the query or decoding fails.
 func (ctx CLIContext) GetAccount(address []byte) (auth.Account, error) {
@@ -290,11 +325,22 @@ func (ctx CLIContext) ensureBroadcastTx(txBytes []byte) error {
 // query performs a query from a Tendermint node with the provided store name
 // and path.
 func (ctx CLIContext) query(path string, key cmn.HexBytes) (res []byte, err error) {
-	node, err := ctx.GetNode()
+	resp, err := ctx.queryABCI(path, key)
 	if err != nil {
 		return res, err
 	}
 
+	return resp.Value, nil
+}
+
+// queryABCI performs a query from a Tendermint node with the provided store
+// name and path, returning the verified response.
+func (ctx CLIContext) queryABCI(path string, key cmn.HexBytes) (resp abci.ResponseQuery, err error) {
+	node, err := ctx.GetNode()
+	if err != nil {
+		return resp, err
+	}
+
 	opts := rpcclient.ABCIQueryOptions{
 		Height:  ctx.Height,
 		Trusted: ctx.TrustNode,
@@ -302,42 +348,39 @@ func (ctx CLIContext) query(path string, key cmn.HexBytes) (res []byte, err erro
 
 	result, err := node.ABCIQueryWithOptions(path, key, opts)
 	if err != nil {
-		return res, err
+		return resp, err
 	}
 
-	resp := result.Response
+	resp = result.Response
 	if !resp.IsOK() {
-		return res, errors.Errorf("query failed: (%d) %s", resp.Code, resp.Log)
+		return resp, errors.Errorf("query failed: (%d) %s", resp.Code, resp.Log)
+	}
+
+	// Data from trusted node doesn't need verification
+	if ctx.TrustNode {
+		return resp, nil
 	}
 
-	// Data from trusted node or subspace query doesn't need verification
-	if ctx.TrustNode || !isQueryStoreWithProof(path) {
-		return resp.Value, nil
+	// Custom queries and subspace queries come without a proof, so an
+	// untrusted node could answer them with anything
+	if !isQueryStoreWithProof(path) {
+		return resp, errors.Errorf("the response to query %s carries no proof and the node is not trusted", path)
 	}
 
 	err = ctx.verifyProof(path, resp)
 	if err != nil {
-		return nil, err
+		return resp, err
 	}
 
-	return resp.Value, nil
+	return resp, nil
 }
 
 // verifyProof perform response proof verification
 // nolint: unparam
 func (ctx CLIContext) verifyProof(path string, resp abci.ResponseQuery) error {
 
-	if ctx.Certifier == nil {
-		return fmt.Errorf("missing valid certifier to verify data from untrusted node")
-	}
-
-	node, err := ctx.GetNode()
-	if err != nil {
-		return err
-	}
-
 	// AppHash for height H is in header H+1
-	commit, err := tmliteProxy.GetCertifiedCommit(resp.Height+1, node, ctx.Certifier)
+	commit, err := ctx.certifiedCommit(resp.Height + 1)
 	if err != nil {
 		return err
 	}
@@ -355,7 +398,20 @@ func (ctx CLIContext) verifyProof(path string, resp abci.ResponseQuery) error {
 	if err != nil {
 		return errors.Wrap(err, "failed in verifying the proof against appHash")
 	}
-	err = store.VerifyRangeProof(resp.Key, resp.Value, substoreCommitHash, &multiStoreProof.RangeProof)
+	if strings.HasSuffix(path, "/range") {
+		var query store.RangeQuery
+		var result store.RangeResult
+		err = cdc.UnmarshalBinary(resp.Key, &query)
+		if err == nil {
+			err = cdc.UnmarshalBinary(resp.Value, &result)
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to unmarshalBinary range query")
+		}
+		err = store.VerifyRangeQueryProof(query, result, substoreCommitHash, &multiStoreProof.RangeProof)
+	} else {
+		err = store.VerifyRangeProof(resp.Key, resp.Value, substoreCommitHash, &multiStoreProof.RangeProof)
+	}
 	if err != nil {
 		return errors.Wrap(err, "failed in the range proof verification")
 	}
@@ -369,6 +425,38 @@ func (ctx CLIContext) queryStore(key cmn.HexBytes, storeName, endPath string) ([
 	return ctx.query(path, key)
 }
 
+// querySubspacePage performs a range query of a page of the key-value pairs
+// of a store under a subspace, returning the height of the response.
+func (ctx CLIContext) querySubspacePage(subspace []byte, storeName string, startKey []byte, limit int) (
+	res []sdk.KVPair, nextKey []byte, height int64, err error) {
+
+	if startKey == nil {
+		startKey = subspace
+	}
+	query := store.RangeQuery{
+		Start: startKey,
+		End:   sdk.PrefixEndBytes(subspace),
+		Limit: limit,
+	}
+	bz, err := ctx.Codec.MarshalBinary(query)
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf("/store/%s/range", storeName)
+	resp, err := ctx.queryABCI(path, bz)
+	if err != nil {
+		return
+	}
+
+	var result store.RangeResult
+	err = ctx.Codec.UnmarshalBinary(resp.Value, &result)
+	if err != nil {
+		return nil, nil, 0, errors.Wrap(err, "failed to unmarshalBinary range result")
+	}
+	return result.KVs, result.NextKey, resp.Height, nil
+}
+
 // isQueryStoreWithProof expects a format like /<queryType>/<storeName>/<subpath>
 // queryType can be app or store
 func isQueryStoreWithProof(path string) bool {
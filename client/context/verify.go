@@ -0,0 +1,125 @@
+package context
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	tmlite "github.com/tendermint/tendermint/lite"
+	tmliteProxy "github.com/tendermint/tendermint/lite/proxy"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// certifiedCommit returns the commit of the height, certified by the
+// certifier of the context
+func (ctx CLIContext) certifiedCommit(height int64) (tmlite.Commit, error) {
+	if ctx.Certifier == nil {
+		return tmlite.Commit{}, fmt.Errorf("missing valid certifier to verify data from untrusted node")
+	}
+
+	node, err := ctx.GetNode()
+	if err != nil {
+		return tmlite.Commit{}, err
+	}
+
+	return tmliteProxy.GetCertifiedCommit(height, node, ctx.Certifier)
+}
+
+// VerifyTx verifies the proof of inclusion of a tx, queried with its proof,
+// against the data hash of the certified header of its block, and its result
+// against the results hash of the certified header of the next block, so the
+// tx can only be verified once the next block is committed. The results hash
+// only commits to the code and data of the result: its log, tags and gas
+// aren't verified.
+func (ctx CLIContext) VerifyTx(res *ctypes.ResultTx) error {
+	if !bytes.Equal(res.Proof.Data, res.Tx) || !bytes.Equal(res.Hash, res.Tx.Hash()) {
+		return errors.New("the proof of the tx doesn't prove the tx")
+	}
+
+	commit, err := ctx.certifiedCommit(res.Height)
+	if err != nil {
+		return err
+	}
+
+	err = res.Proof.Validate(commit.Header.DataHash)
+	if err != nil {
+		return errors.Wrap(err, "failed in verifying the proof of the tx against the data hash")
+	}
+	return ctx.verifyTxResult(res)
+}
+
+// verifyTxResult verifies the result of a tx against the results of its
+// block, whose hash is in the header of the next block
+func (ctx CLIContext) verifyTxResult(res *ctypes.ResultTx) error {
+	node, err := ctx.GetNode()
+	if err != nil {
+		return err
+	}
+	blockRes, err := node.BlockResults(&res.Height)
+	if err != nil {
+		return err
+	}
+	if blockRes.Results == nil || int(res.Index) >= len(blockRes.Results.DeliverTx) {
+		return fmt.Errorf("missing result of tx %d of block %d", res.Index, res.Height)
+	}
+	for _, result := range blockRes.Results.DeliverTx {
+		if result == nil {
+			return fmt.Errorf("missing result in the results of block %d", res.Height)
+		}
+	}
+
+	commit, err := ctx.certifiedCommit(res.Height + 1)
+	if err != nil {
+		return errors.Wrap(err, "failed in certifying the header holding the results hash of the tx")
+	}
+	if !bytes.Equal(tmtypes.NewResults(blockRes.Results.DeliverTx).Hash(), commit.Header.LastResultsHash) {
+		return errors.New("the results of the block don't match the results hash of the next certified header")
+	}
+
+	result := blockRes.Results.DeliverTx[res.Index]
+	if res.TxResult.Code != result.Code || !bytes.Equal(res.TxResult.Data, result.Data) {
+		return errors.New("the result of the tx doesn't match the results of its block")
+	}
+	return nil
+}
+
+// VerifyBlock verifies the header of a block against its certified header,
+// and the data, evidence and last commit of the block against the header.
+func (ctx CLIContext) VerifyBlock(res *ctypes.ResultBlock) error {
+	if res.Block == nil || res.BlockMeta == nil {
+		return errors.New("missing block in the response")
+	}
+
+	commit, err := ctx.certifiedCommit(res.Block.Height)
+	if err != nil {
+		return err
+	}
+
+	hash := commit.Header.Hash()
+	if !bytes.Equal(res.Block.Hash(), hash) || !bytes.Equal(res.BlockMeta.Header.Hash(), hash) ||
+		!bytes.Equal(res.BlockMeta.BlockID.Hash, hash) {
+		return errors.New("the header of the block doesn't match its certified header")
+	}
+
+	err = res.Block.ValidateBasic()
+	if err != nil {
+		return errors.Wrap(err, "the block doesn't match its header")
+	}
+	return nil
+}
+
+// VerifyValidators verifies a validator set against the validators hash of
+// the certified header of its height.
+func (ctx CLIContext) VerifyValidators(height int64, validators []*tmtypes.Validator) error {
+	commit, err := ctx.certifiedCommit(height)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(tmtypes.NewValidatorSet(validators).Hash(), commit.Header.ValidatorsHash) {
+		return errors.New("the validator set doesn't match the validators hash of its certified header")
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package context
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmlite "github.com/tendermint/tendermint/lite"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	sm "github.com/tendermint/tendermint/state"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const testChainID = "test-chain"
+
+// mockNode serves the commits and block results of a chain
+type mockNode struct {
+	rpcclient.Client
+	commits map[int64]tmlite.Commit
+	results map[int64][]*abci.ResponseDeliverTx
+}
+
+func (n mockNode) Status() (*ctypes.ResultStatus, error) {
+	var latest int64
+	for height := range n.commits {
+		if height > latest {
+			latest = height
+		}
+	}
+	return &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: latest}}, nil
+}
+
+func (n mockNode) Commit(height *int64) (*ctypes.ResultCommit, error) {
+	commit, ok := n.commits[*height]
+	if !ok {
+		return nil, errors.New("commit not found")
+	}
+	return ctypes.NewResultCommit(commit.Header, commit.Commit, true), nil
+}
+
+func (n mockNode) BlockResults(height *int64) (*ctypes.ResultBlockResults, error) {
+	results, ok := n.results[*height]
+	if !ok {
+		return nil, errors.New("results not found")
+	}
+	return &ctypes.ResultBlockResults{
+		Height:  *height,
+		Results: &sm.ABCIResponses{DeliverTx: results},
+	}, nil
+}
+
+// setupVerify returns a context verifying the responses of a node with a
+// block of txs at height 1 and the results hash of its txs at height 2
+func setupVerify(txs tmtypes.Txs, results []*abci.ResponseDeliverTx) (CLIContext, tmlite.ValKeys) {
+	keys := tmlite.GenValKeys(4)
+	vals := keys.ToValidators(10, 0)
+	resHash := tmtypes.NewResults(results).Hash()
+	node := mockNode{
+		commits: map[int64]tmlite.Commit{
+			1: keys.GenCommit(testChainID, 1, txs, vals, nil, nil, nil, 0, len(keys)),
+			2: keys.GenCommit(testChainID, 2, nil, vals, nil, nil, resHash, 0, len(keys)),
+		},
+		results: map[int64][]*abci.ResponseDeliverTx{1: results},
+	}
+	cliCtx := CLIContext{
+		Client:    node,
+		Certifier: tmlite.NewBaseCertifier(testChainID, 1, vals),
+	}
+	return cliCtx, keys
+}
+
+func TestVerifyTx(t *testing.T) {
+	txs := tmtypes.Txs{tmtypes.Tx("tx0"), tmtypes.Tx("tx1")}
+	results := []*abci.ResponseDeliverTx{
+		{Code: 0, Data: []byte("data0")},
+		{Code: 5, Data: []byte("data1")},
+	}
+	cliCtx, keys := setupVerify(txs, results)
+	txRes := func() *ctypes.ResultTx {
+		return &ctypes.ResultTx{
+			Hash:     txs[1].Hash(),
+			Height:   1,
+			Index:    1,
+			TxResult: *results[1],
+			Tx:       txs[1],
+			Proof:    txs.Proof(1),
+		}
+	}
+	require.NoError(t, cliCtx.VerifyTx(txRes()))
+
+	// the certifier is required
+	res := txRes()
+	require.Error(t, cliCtx.WithCertifier(nil).VerifyTx(res))
+
+	// a tampered tx
+	res = txRes()
+	res.Tx = tmtypes.Tx("tx2")
+	res.Hash = res.Tx.Hash()
+	res.Proof.Data = res.Tx
+	require.Error(t, cliCtx.VerifyTx(res))
+
+	// a tx which isn't the one of the proof
+	res = txRes()
+	res.Tx = txs[0]
+	require.Error(t, cliCtx.VerifyTx(res))
+
+	// a tampered result
+	res = txRes()
+	res.TxResult.Code = 0
+	require.Error(t, cliCtx.VerifyTx(res))
+	res = txRes()
+	res.TxResult.Data = []byte("data2")
+	require.Error(t, cliCtx.VerifyTx(res))
+
+	// the result of another tx
+	res = txRes()
+	res.Index = 0
+	require.Error(t, cliCtx.VerifyTx(res))
+
+	// tampered results of the block
+	node := cliCtx.Client.(mockNode)
+	node.results[1] = []*abci.ResponseDeliverTx{results[1], results[1]}
+	res = txRes()
+	require.Error(t, cliCtx.VerifyTx(res))
+	node.results[1] = results
+
+	// a header which isn't signed by the validators
+	other := tmlite.GenValKeys(4)
+	node.commits[1] = other.GenCommit(testChainID, 1, txs, keys.ToValidators(10, 0), nil, nil, nil, 0, len(other))
+	require.Error(t, cliCtx.VerifyTx(txRes()))
+}
+
+func TestVerifyBlock(t *testing.T) {
+	txs := tmtypes.Txs{tmtypes.Tx("tx0"), tmtypes.Tx("tx1")}
+	cliCtx, keys := setupVerify(txs, nil)
+	node := cliCtx.Client.(mockNode)
+	blockRes := func() *ctypes.ResultBlock {
+		block := &tmtypes.Block{
+			Header:     *node.commits[1].Header,
+			Data:       tmtypes.Data{Txs: txs},
+			LastCommit: &tmtypes.Commit{},
+		}
+		return &ctypes.ResultBlock{
+			BlockMeta: &tmtypes.BlockMeta{
+				BlockID: tmtypes.BlockID{Hash: block.Hash()},
+				Header:  block.Header,
+			},
+			Block: block,
+		}
+	}
+	require.NoError(t, cliCtx.VerifyBlock(blockRes()))
+
+	// a missing block
+	res := blockRes()
+	res.Block = nil
+	require.Error(t, cliCtx.VerifyBlock(res))
+
+	// a tampered header
+	res = blockRes()
+	res.Block.AppHash = []byte("apphash")
+	require.Error(t, cliCtx.VerifyBlock(res))
+	res = blockRes()
+	res.BlockMeta.Header.AppHash = []byte("apphash")
+	require.Error(t, cliCtx.VerifyBlock(res))
+
+	// tampered txs
+	res = blockRes()
+	res.Block.Data = tmtypes.Data{Txs: tmtypes.Txs{txs[1], txs[0]}}
+	require.Error(t, cliCtx.VerifyBlock(res))
+
+	// a header which isn't signed by the validators
+	other := tmlite.GenValKeys(4)
+	node.commits[1] = other.GenCommit(testChainID, 1, txs, keys.ToValidators(10, 0), nil, nil, nil, 0, len(other))
+	require.Error(t, cliCtx.VerifyBlock(blockRes()))
+}
+
+func TestVerifyValidators(t *testing.T) {
+	cliCtx, keys := setupVerify(nil, nil)
+	vals := keys.ToValidators(10, 0)
+	require.NoError(t, cliCtx.VerifyValidators(1, vals.Validators))
+
+	// tampered validator sets
+	require.Error(t, cliCtx.VerifyValidators(1, keys.ToValidators(11, 0).Validators))
+	require.Error(t, cliCtx.VerifyValidators(1, vals.Validators[1:]))
+	require.Error(t, cliCtx.VerifyValidators(1, keys.Change(0).ToValidators(10, 0).Validators))
+
+	// a height without header
+	require.Error(t, cliCtx.VerifyValidators(100, vals.Validators))
+}
@@ -34,6 +34,9 @@ const (
 	FlagPrintResponse = "print-response"
 	FlagDryRun        = "dry-run"
 	FlagGenerateOnly  = "generate-only"
+
+	FlagKeyringBackend   = "keyring-backend"
+	FlagRemoteSignerAddr = "remote-signer-addr"
 )
 
 // LineBreak can be included in a command list to provide a blank line
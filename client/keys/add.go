@@ -14,17 +14,20 @@ import (
 
 	ccrypto "github.com/cosmos/cosmos-sdk/crypto"
 	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
 
 	"github.com/tendermint/tendermint/libs/cli"
 )
 
 const (
+	flagAlgo     = "algo"
 	flagType     = "type"
 	flagRecover  = "recover"
 	flagNoBackup = "no-backup"
 	flagDryRun   = "dry-run"
 	flagAccount  = "account"
 	flagIndex    = "index"
+	flagLanguage = "language"
 )
 
 func addKeyCommand() *cobra.Command {
@@ -32,17 +35,21 @@ func addKeyCommand() *cobra.Command {
 		Use:   "add <name>",
 		Short: "Create a new key, or import from seed",
 		Long: `Add a public/private key pair to the key store.
-If you select --seed/-s you can recover a key from the seed
-phrase, otherwise, a new key will be generated.`,
+If you select --recover you can recover a key from the seed
+phrase, whose language is detected, otherwise, a new key will
+be generated with a seed phrase in the --language.`,
 		RunE: runAddCmd,
 	}
-	cmd.Flags().StringP(flagType, "t", "secp256k1", "Type of private key (secp256k1|ed25519)")
+	cmd.Flags().String(flagAlgo, string(keys.Secp256k1), "Signing algorithm of the key (secp256k1|ed25519)")
+	cmd.Flags().StringP(flagType, "t", "", "Type of private key (secp256k1|ed25519)")
+	cmd.Flags().MarkDeprecated(flagType, "use --algo instead")
 	cmd.Flags().Bool(client.FlagUseLedger, false, "Store a local reference to a private key on a Ledger device")
 	cmd.Flags().Bool(flagRecover, false, "Provide seed phrase to recover existing key instead of creating")
 	cmd.Flags().Bool(flagNoBackup, false, "Don't print out seed phrase (if others are watching the terminal)")
 	cmd.Flags().Bool(flagDryRun, false, "Perform action, but don't add key to local keystore")
-	cmd.Flags().Uint32(flagAccount, 0, "Account number for HD derivation")
-	cmd.Flags().Uint32(flagIndex, 0, "Index number for HD derivation")
+	cmd.Flags().Uint32(flagAccount, 0, "Account number for HD derivation of a Ledger or recovered key")
+	cmd.Flags().Uint32(flagIndex, 0, "Index number for HD derivation of a Ledger or recovered key")
+	cmd.Flags().String(flagLanguage, keys.English.String(), "Language of the seed phrase of a new key (english|japanese|korean|spanish|chinese-simplified|chinese-traditional|french|italian)")
 	return cmd
 }
 
@@ -80,7 +87,7 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 		}
 
 		// ask for a password when generating a local key
-		if !viper.GetBool(client.FlagUseLedger) {
+		if !viper.GetBool(client.FlagUseLedger) && PassphraseRequired() {
 			pass, err = client.GetCheckPassword(
 				"Enter a passphrase for your key:",
 				"Repeat the passphrase:", buf)
@@ -90,11 +97,19 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	algo := keys.SigningAlgo(viper.GetString(flagAlgo))
+	if keyType := viper.GetString(flagType); keyType != "" {
+		algo = keys.SigningAlgo(keyType)
+	}
+	account := uint32(viper.GetInt(flagAccount))
+	index := uint32(viper.GetInt(flagIndex))
+	language, err := keys.ParseLanguage(viper.GetString(flagLanguage))
+	if err != nil {
+		return err
+	}
+
 	if viper.GetBool(client.FlagUseLedger) {
-		account := uint32(viper.GetInt(flagAccount))
-		index := uint32(viper.GetInt(flagIndex))
 		path := ccrypto.DerivationPath{44, 118, account, 0, index}
-		algo := keys.SigningAlgo(viper.GetString(flagType))
 		info, err := kb.CreateLedger(name, path, algo)
 		if err != nil {
 			return err
@@ -106,7 +121,8 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		info, err := kb.CreateKey(name, seed, pass)
+		params := *hd.NewFundraiserParams(account, index)
+		info, err := kb.Derive(name, seed, pass, params, algo)
 		if err != nil {
 			return err
 		}
@@ -114,8 +130,7 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 		viper.Set(flagNoBackup, true)
 		printCreate(info, "")
 	} else {
-		algo := keys.SigningAlgo(viper.GetString(flagType))
-		info, seed, err := kb.CreateMnemonic(name, keys.English, pass, algo)
+		info, seed, err := kb.CreateMnemonic(name, language, pass, algo)
 		if err != nil {
 			return err
 		}
@@ -163,6 +178,7 @@ type NewKeyBody struct {
 	Name     string `json:"name"`
 	Password string `json:"password"`
 	Seed     string `json:"seed"`
+	Algo     string `json:"algo"` // secp256k1 if empty
 }
 
 // add new key REST handler
@@ -207,11 +223,20 @@ func AddNewKeyRequestHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// create account
+	algo := keys.Secp256k1
+	if m.Algo != "" {
+		algo = keys.SigningAlgo(m.Algo)
+	}
 	seed := m.Seed
 	if seed == "" {
-		seed = getSeed(keys.Secp256k1)
+		seed = getSeed(algo)
+	}
+	var info keys.Info
+	if algo == keys.Secp256k1 {
+		info, err = kb.CreateKey(m.Name, seed, m.Password)
+	} else {
+		info, err = kb.Derive(m.Name, seed, m.Password, *hd.NewFundraiserParams(0, 0), algo)
 	}
-	info, err := kb.CreateKey(m.Name, seed, m.Password)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		w.Write([]byte(err.Error()))
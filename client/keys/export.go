@@ -0,0 +1,131 @@
+package keys
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	keys "github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/gorilla/mux"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	flagFormat = "format"
+
+	// formatArmor is the ASCII armored key info of the keybase
+	formatArmor = "armor"
+	// formatWeb3 is the JSON keystore of the Web3 secret storage
+	formatWeb3 = "web3"
+)
+
+func exportKeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export <name>",
+		Short: "Export a key",
+		Long: `Export a key to the standard output. The armor format holds the key
+as stored in the keybase, and the web3 format is a JSON keystore of the Web3
+secret storage, encrypted with a new passphrase, which other wallets can
+import.`,
+		RunE: runExportCmd,
+		Args: cobra.ExactArgs(1),
+	}
+	cmd.Flags().String(flagFormat, formatArmor, "Format of the exported key (armor|web3)")
+	return cmd
+}
+
+func runExportCmd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	kb, err := GetKeyBase()
+	if err != nil {
+		return err
+	}
+
+	switch format := viper.GetString(flagFormat); format {
+	case formatArmor:
+		armor, err := kb.Export(name)
+		if err != nil {
+			return err
+		}
+		fmt.Println(armor)
+
+	case formatWeb3:
+		buf := client.BufferStdin()
+		passphrase, err := client.GetPassword(
+			"Enter the passphrase of the key:", buf)
+		if err != nil {
+			return err
+		}
+		exportPassphrase, err := client.GetCheckPassword(
+			"Enter a passphrase to encrypt the exported key:",
+			"Repeat the passphrase:", buf)
+		if err != nil {
+			return err
+		}
+		keyJSON, err := kb.ExportWeb3(name, passphrase, exportPassphrase)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(keyJSON))
+
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+	return nil
+}
+
+///////////////////////
+// REST
+
+// export key request REST body
+type ExportKeyBody struct {
+	Password       string `json:"password"`
+	ExportPassword string `json:"export_password"`
+}
+
+// export key REST handler, returning a Web3 JSON keystore
+func ExportKeyRequestHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+	var kb keys.Keybase
+	var m ExportKeyBody
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&m)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	if m.ExportPassword == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("You have to specify a password for the exported key."))
+		return
+	}
+
+	kb, err = GetKeyBase()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	if _, err = kb.Get(name); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	keyJSON, err := kb.ExportWeb3(name, m.Password, m.ExportPassword)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(keyJSON)
+}
@@ -0,0 +1,145 @@
+package keys
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	keys "github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/gorilla/mux"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func importKeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <name> <keyfile>",
+		Short: "Import a key from a file",
+		Long: `Import a key exported with 'gaiacli keys export' from a file. A key
+in the web3 format is decrypted with the passphrase of the keystore, and stored
+encrypted with a new passphrase.`,
+		RunE: runImportCmd,
+		Args: cobra.ExactArgs(2),
+	}
+	cmd.Flags().String(flagFormat, formatArmor, "Format of the imported key (armor|web3)")
+	return cmd
+}
+
+func runImportCmd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	bz, err := ioutil.ReadFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	kb, err := GetKeyBase()
+	if err != nil {
+		return err
+	}
+
+	switch format := viper.GetString(flagFormat); format {
+	case formatArmor:
+		if err = kb.Import(name, string(bz)); err != nil {
+			return err
+		}
+
+	case formatWeb3:
+		buf := client.BufferStdin()
+		importPassphrase, err := client.GetPassword(
+			"Enter the passphrase of the keystore:", buf)
+		if err != nil {
+			return err
+		}
+		var passphrase string
+		if PassphraseRequired() {
+			passphrase, err = client.GetCheckPassword(
+				"Enter a passphrase for your key:",
+				"Repeat the passphrase:", buf)
+			if err != nil {
+				return err
+			}
+		}
+		if _, err = kb.ImportWeb3(name, bz, importPassphrase, passphrase); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unknown import format %q", format)
+	}
+
+	info, err := kb.Get(name)
+	if err != nil {
+		return err
+	}
+	printKeyInfo(info, Bech32KeyOutput)
+	return nil
+}
+
+///////////////////////
+// REST
+
+// import key request REST body
+type ImportKeyBody struct {
+	Keystore         json.RawMessage `json:"keystore"`
+	KeystorePassword string          `json:"keystore_password"`
+	Password         string          `json:"password"`
+}
+
+// import key REST handler, storing the key of a Web3 JSON keystore
+func ImportKeyRequestHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+	var kb keys.Keybase
+	var m ImportKeyBody
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&m)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	if m.Password == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("You have to specify a password for the locally stored account."))
+		return
+	}
+
+	kb, err = GetKeyBase()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	if _, err = kb.Get(name); err == nil {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(fmt.Sprintf("Account with name %s already exists.", name)))
+		return
+	}
+
+	info, err := kb.ImportWeb3(name, m.Keystore, m.KeystorePassword, m.Password)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	keyOutput, err := Bech32KeyOutput(info)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	output, err := json.MarshalIndent(keyOutput, "", "  ")
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Write(output)
+}
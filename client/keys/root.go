@@ -25,6 +25,14 @@ func Commands() *cobra.Command {
 		client.LineBreak,
 		deleteKeyCommand(),
 		updateKeyCommand(),
+		client.LineBreak,
+		exportKeyCommand(),
+		importKeyCommand(),
+		client.LineBreak,
+		signMessageCommand(),
+		verifyMessageCommand(),
+		client.LineBreak,
+		signerCommand(),
 	)
 	return cmd
 }
@@ -37,4 +45,8 @@ func RegisterRoutes(r *mux.Router) {
 	r.HandleFunc("/keys/{name}", GetKeyRequestHandler).Methods("GET")
 	r.HandleFunc("/keys/{name}", UpdateKeyRequestHandler).Methods("PUT")
 	r.HandleFunc("/keys/{name}", DeleteKeyRequestHandler).Methods("DELETE")
+	r.HandleFunc("/keys/{name}/export", ExportKeyRequestHandler).Methods("POST")
+	r.HandleFunc("/keys/{name}/import", ImportKeyRequestHandler).Methods("POST")
+	r.HandleFunc("/keys/{name}/sign-message", SignMessageRequestHandler).Methods("POST")
+	r.HandleFunc("/keys/verify-message", VerifyMessageRequestHandler).Methods("POST")
 }
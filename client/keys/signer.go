@@ -0,0 +1,140 @@
+package keys
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/cli"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+)
+
+const (
+	flagListenAddr     = "laddr"
+	flagAllowedClients = "allowed-clients"
+	flagShowIDs        = "show-ids"
+)
+
+func signerCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "signer",
+		Short: "Run a remote signer serving the local keys",
+		Long: `Run a signer daemon serving the keys stored in the local database to
+the clients using the remote keyring backend. The clients list the keys of
+the signer and have it sign with them, the private keys never leave the
+signer.
+
+The clients send the passphrases of the keys to the signer, and whoever can
+connect to the signer can sign with its keys. By default, the signer listens
+on the unix socket signer/signer.sock under the home directory, and the
+connections are not encrypted. A unix socket is only created in a directory
+which no one but its owner can access, so that no one else can connect to it.
+
+On TCP, the connections are encrypted and authenticated with the keys stored
+in signer_key.json for the signer and signer_client_key.json for the client,
+under their home directories. The signer only serves the clients whose ID is
+given with --allowed-clients, and the clients connect to the address
+tcp://<signer-id>@<host>:<port>. Use --show-ids to print the IDs of the
+signer and of the client under the home directory.`,
+		Args: cobra.NoArgs,
+		RunE: runSignerCmd,
+	}
+	cmd.Flags().String(flagListenAddr, DefaultRemoteSignerAddr, "The address for the signer to listen on, unix://<path> or tcp://<host>:<port> (default unix://<home>/signer/signer.sock)")
+	cmd.Flags().StringSlice(flagAllowedClients, nil, "The IDs of the clients served over TCP")
+	cmd.Flags().Bool(flagShowIDs, false, "Print the IDs of the signer and of the client under the home directory and exit")
+	return cmd
+}
+
+func runSignerCmd(cmd *cobra.Command, args []string) error {
+	home := viper.GetString(cli.HomeFlag)
+	if viper.GetBool(flagShowIDs) {
+		signerKey, err := p2p.LoadOrGenNodeKey(filepath.Join(home, signerKeyFile))
+		if err != nil {
+			return err
+		}
+		clientKey, err := p2p.LoadOrGenNodeKey(filepath.Join(home, signerClientKeyFile))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("signer ID: %s\n", keys.RemoteSignerID(signerKey.PubKey()))
+		fmt.Printf("client ID: %s\n", keys.RemoteSignerID(clientKey.PubKey()))
+		return nil
+	}
+
+	// the signer serves its local keys whatever the keyring backend
+	kb, err := getDBKeyBase(home)
+	if err != nil {
+		return err
+	}
+
+	protocol, address := cmn.ProtocolAndAddress(remoteSignerAddr(home, viper.GetString(flagListenAddr)))
+	var privKey tmcrypto.PrivKey
+	if protocol != "unix" {
+		nodeKey, err := p2p.LoadOrGenNodeKey(filepath.Join(home, signerKeyFile))
+		if err != nil {
+			return err
+		}
+		privKey = nodeKey.PrivKey
+	}
+
+	if protocol == "unix" {
+		// only the owner of the socket may connect to the signer, whatever the
+		// permissions the socket is created with
+		if err = ensurePrivateDir(filepath.Dir(address)); err != nil {
+			return err
+		}
+	}
+	listener, err := net.Listen(protocol, address)
+	if err != nil {
+		return err
+	}
+
+	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "signer")
+	signer, err := keys.NewRemoteSigner(kb, listener, privKey, viper.GetStringSlice(flagAllowedClients), logger)
+	if err != nil {
+		listener.Close()
+		return err
+	}
+	if err = signer.Start(); err != nil {
+		return err
+	}
+	if privKey != nil {
+		logger.Info("serving keys", "laddr", listener.Addr(), "id", keys.RemoteSignerID(privKey.PubKey()))
+	} else {
+		logger.Info("serving keys", "laddr", listener.Addr())
+	}
+
+	// wait forever and cleanup
+	cmn.TrapSignal(func() {
+		err := signer.Stop()
+		if err != nil {
+			logger.Error("error stopping signer", "err", err)
+		}
+	})
+
+	return nil
+}
+
+// create the directory if it doesn't exist, and check that no one but its
+// owner can access it
+func ensurePrivateDir(dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		return fmt.Errorf("%s must only be accessible to its owner, e.g. with chmod 700 %s", dir, dir)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package keys
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	keys "github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/gorilla/mux"
+	"github.com/spf13/cobra"
+)
+
+func signMessageCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign-message <name> <message>",
+		Short: "Sign an arbitrary message with a key",
+		Long: `Sign an arbitrary message with a key to prove the ownership of its address,
+and print the signed message. The signature is not the signature of a transaction,
+and cannot be replayed as a transaction. If you supply a dash (-) argument in place
+of the message, the command reads the message from standard input.`,
+		RunE: runSignMessageCmd,
+		Args: cobra.ExactArgs(2),
+	}
+	return cmd
+}
+
+func runSignMessageCmd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	data := []byte(args[1])
+	if args[1] == "-" {
+		var err error
+		if data, err = ioutil.ReadAll(os.Stdin); err != nil {
+			return err
+		}
+	}
+
+	kb, err := GetKeyBase()
+	if err != nil {
+		return err
+	}
+	passphrase, err := GetPassphrase(name)
+	if err != nil {
+		return err
+	}
+	msg, err := signMessage(kb, name, passphrase, data)
+	if err != nil {
+		return err
+	}
+
+	output, err := codec.MarshalJSONIndent(cdc, msg)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
+func verifyMessageCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-message <file>",
+		Short: "Verify a message signed with sign-message",
+		Long: `Verify that the message read from <file>, as printed by sign-message, is signed
+by the key of its signer. If you supply a dash (-) argument in place of an input
+filename, the command reads from standard input.`,
+		RunE: runVerifyMessageCmd,
+		Args: cobra.ExactArgs(1),
+	}
+	return cmd
+}
+
+func runVerifyMessageCmd(cmd *cobra.Command, args []string) (err error) {
+	var bz []byte
+	if args[0] == "-" {
+		bz, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		bz, err = ioutil.ReadFile(args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	var msg auth.StdSignedMessage
+	if err = cdc.UnmarshalJSON(bz, &msg); err != nil {
+		return err
+	}
+	if err = msg.Verify(); err != nil {
+		return err
+	}
+	fmt.Printf("Message signed by %s:\n%s\n", msg.Signer, msg.Data)
+	return nil
+}
+
+// signMessage signs the data with the key of the given name
+func signMessage(kb keys.Keybase, name, passphrase string, data []byte) (msg auth.StdSignedMessage, err error) {
+	info, err := kb.Get(name)
+	if err != nil {
+		return
+	}
+	signer := sdk.AccAddress(info.GetPubKey().Address())
+
+	sig, pubkey, err := kb.Sign(name, passphrase, auth.StdSignMessageBytes(signer, data))
+	if err != nil {
+		return
+	}
+	return auth.StdSignedMessage{
+		Signer:    signer,
+		Data:      data,
+		PubKey:    pubkey,
+		Signature: sig,
+	}, nil
+}
+
+///////////////////////
+// REST
+
+// sign message request REST body
+type SignMessageBody struct {
+	Password string `json:"password"`
+	Data     []byte `json:"data"`
+}
+
+// sign message REST handler, signing arbitrary data with a key
+func SignMessageRequestHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+	var kb keys.Keybase
+	var m SignMessageBody
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&m)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	kb, err = GetKeyBase()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	if _, err = kb.Get(name); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	msg, err := signMessage(kb, name, m.Password, m.Data)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	output, err := codec.MarshalJSONIndent(cdc, msg)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Write(output)
+}
+
+// verify message REST handler, verifying a message signed with a key
+func VerifyMessageRequestHandler(w http.ResponseWriter, r *http.Request) {
+	var msg auth.StdSignedMessage
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	if err = cdc.UnmarshalJSON(body, &msg); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	if err = msg.Verify(); err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Write([]byte(msg.Signer.String()))
+}
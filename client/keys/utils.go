@@ -7,8 +7,11 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/cli"
+	cmn "github.com/tendermint/tendermint/libs/common"
 	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/p2p"
 
 	"github.com/cosmos/cosmos-sdk/client"
 
@@ -18,6 +21,29 @@ import (
 // KeyDBName is the directory under root where we store the keys
 const KeyDBName = "keys"
 
+// Keybase backends selected with the --keyring-backend flag
+const (
+	// BackendDB stores the encrypted keys in a local database
+	BackendDB = "db"
+	// BackendFile stores each key in a file, encrypted with scrypt
+	BackendFile = "file"
+	// BackendTest stores the keys unencrypted, without passphrase
+	BackendTest = "test"
+	// BackendRemote forwards the signatures to a remote signer
+	BackendRemote = "remote"
+)
+
+// DefaultRemoteSignerAddr is the default address of the remote signer, the
+// unix socket signer/signer.sock under the home directory
+const DefaultRemoteSignerAddr = ""
+
+// Files under the home directory holding the keys which secure the TCP
+// connections between the remote signer and its clients
+const (
+	signerKeyFile       = "signer_key.json"
+	signerClientKeyFile = "signer_client_key.json"
+)
+
 // keybase is used to make GetKeyBase a singleton
 var keybase keys.Keybase
 
@@ -49,14 +75,19 @@ func GetKeyInfo(name string) (keys.Info, error) {
 func GetPassphrase(name string) (string, error) {
 	var passphrase string
 
+	if !PassphraseRequired() {
+		return passphrase, nil
+	}
+
 	keyInfo, err := GetKeyInfo(name)
 	if err != nil {
 		return passphrase, err
 	}
 
-	// we only need a passphrase for locally stored keys
+	// we only need a passphrase for locally stored keys, or keys stored by a
+	// remote signer
 	// TODO: (ref: #864) address security concerns
-	if keyInfo.GetType() == keys.TypeLocal {
+	if keyInfo.GetType() == keys.TypeLocal || keyInfo.GetType() == keys.TypeRemote {
 		passphrase, err = ReadPassphraseFromStdin(name)
 		if err != nil {
 			return passphrase, err
@@ -66,6 +97,12 @@ func GetPassphrase(name string) (string, error) {
 	return passphrase, nil
 }
 
+// PassphraseRequired returns whether the keys of the keyring backend are
+// protected by a passphrase.
+func PassphraseRequired() bool {
+	return viper.GetString(client.FlagKeyringBackend) != BackendTest
+}
+
 // ReadPassphraseFromStdin attempts to read a passphrase from STDIN return an
 // error upon failure.
 func ReadPassphraseFromStdin(name string) (string, error) {
@@ -80,18 +117,66 @@ func ReadPassphraseFromStdin(name string) (string, error) {
 	return passphrase, nil
 }
 
-// initialize a keybase based on the configuration
+// initialize a keybase based on the configuration, with the backend selected
+// by the --keyring-backend flag
 func GetKeyBaseFromDir(rootDir string) (keys.Keybase, error) {
 	if keybase == nil {
-		db, err := dbm.NewGoLevelDB(KeyDBName, filepath.Join(rootDir, "keys"))
-		if err != nil {
-			return nil, err
+		switch backend := viper.GetString(client.FlagKeyringBackend); backend {
+		case "", BackendDB:
+			kb, err := getDBKeyBase(rootDir)
+			if err != nil {
+				return nil, err
+			}
+			keybase = kb
+		case BackendFile:
+			keybase = keys.NewFile(filepath.Join(rootDir, "keys-file"))
+		case BackendTest:
+			db, err := dbm.NewGoLevelDB(KeyDBName, filepath.Join(rootDir, "keys-test"))
+			if err != nil {
+				return nil, err
+			}
+			keybase = keys.NewTest(db)
+		case BackendRemote:
+			addr := remoteSignerAddr(rootDir, viper.GetString(client.FlagRemoteSignerAddr))
+			var privKey tmcrypto.PrivKey
+			if protocol, _ := cmn.ProtocolAndAddress(addr); protocol != "unix" {
+				nodeKey, err := p2p.LoadOrGenNodeKey(filepath.Join(rootDir, signerClientKeyFile))
+				if err != nil {
+					return nil, err
+				}
+				privKey = nodeKey.PrivKey
+			}
+			kb, err := keys.NewRemote(addr, privKey)
+			if err != nil {
+				return nil, err
+			}
+			keybase = kb
+		default:
+			return nil, fmt.Errorf("unknown keyring backend %q", backend)
 		}
-		keybase = client.GetKeyBase(db)
 	}
 	return keybase, nil
 }
 
+// initialize a keybase storing the keys in a database under the root
+// directory
+func getDBKeyBase(rootDir string) (keys.Keybase, error) {
+	db, err := dbm.NewGoLevelDB(KeyDBName, filepath.Join(rootDir, "keys"))
+	if err != nil {
+		return nil, err
+	}
+	return client.GetKeyBase(db), nil
+}
+
+// the address of the remote signer, defaulting to the unix socket under the
+// root directory
+func remoteSignerAddr(rootDir, addr string) string {
+	if addr == "" {
+		return "unix://" + filepath.Join(rootDir, "signer", "signer.sock")
+	}
+	return addr
+}
+
 // used to set the keybase manually in test
 func SetKeyBase(kb keys.Keybase) {
 	keybase = kb
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/client/tx"
 
+	"github.com/gorilla/websocket"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -110,6 +112,39 @@ func TestKeys(t *testing.T) {
 	res, body = Request(t, port, "PUT", keyEndpoint, jsonStr)
 	require.Equal(t, http.StatusUnauthorized, res.StatusCode, body)
 
+	// export key as a Web3 keystore
+	jsonStr = []byte(`{"password":"12345678901", "export_password":"exported"}`)
+	res, body = Request(t, port, "POST", keyEndpoint+"/export", jsonStr)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	keystore := body
+
+	// import it under another name
+	jsonStr = []byte(fmt.Sprintf(`{"keystore":%s, "keystore_password":"exported", "password":"%s"}`, keystore, newPassword))
+	res, body = Request(t, port, "POST", "/keys/test_imported/import", jsonStr)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var m3 keys.KeyOutput
+	err = cdc.UnmarshalJSON([]byte(body), &m3)
+	require.Nil(t, err)
+	require.Equal(t, "test_imported", m3.Name)
+	require.Equal(t, addr2Bech32, m3.Address)
+
+	res, body = Request(t, port, "POST", "/keys/test_imported/import", jsonStr)
+	require.Equal(t, http.StatusConflict, res.StatusCode, body)
+
+	// sign a message and verify it
+	jsonStr = []byte(`{"password":"12345678901", "data":"aGVsbG8="}`)
+	res, body = Request(t, port, "POST", keyEndpoint+"/sign-message", jsonStr)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	signedMessage := body
+
+	res, body = Request(t, port, "POST", "/keys/verify-message", []byte(signedMessage))
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	require.Equal(t, addr2Bech32, body)
+
+	res, body = Request(t, port, "POST", "/keys/verify-message",
+		[]byte(strings.Replace(signedMessage, "aGVsbG8=", "aGVsbG8h", 1)))
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode, body)
+
 	// delete key
 	jsonStr = []byte(`{"password":"12345678901"}`)
 	res, body = Request(t, port, "DELETE", keyEndpoint, jsonStr)
@@ -439,6 +474,80 @@ func TestTxs(t *testing.T) {
 	require.Equal(t, resultTx.Height, indexedTxs[0].Height)
 }
 
+func TestEvents(t *testing.T) {
+	name, password := "test", "1234567890"
+	addr, seed := CreateAddr(t, "test", password, GetKeyBase(t))
+	cleanup, _, port := InitializeTestLCD(t, 1, []sdk.AccAddress{addr})
+	defer cleanup()
+
+	ws, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("ws://localhost:%s/events", port), nil)
+	require.NoError(t, err)
+	defer ws.Close()
+	ws.SetReadDeadline(time.Now().Add(30 * time.Second))
+
+	// unknown event types are refused
+	require.NoError(t, ws.WriteJSON(rpc.EventsRequest{Action: "subscribe", ID: "bad", Type: "unknown"}))
+	var res rpc.EventsResponse
+	require.NoError(t, ws.ReadJSON(&res))
+	require.Equal(t, "bad", res.ID)
+	require.NotEmpty(t, res.Error)
+
+	// the tags are only equality conditions on the tags of the txs
+	for _, tag := range []string{"sender='a' OR tm.event='NewBlock'", "sender=a", "tm.event='NewBlock'"} {
+		require.NoError(t, ws.WriteJSON(rpc.EventsRequest{Action: "subscribe", ID: "bad", Type: rpc.EventTx, Tags: []string{tag}}))
+		require.NoError(t, ws.ReadJSON(&res))
+		require.Equal(t, "bad", res.ID)
+		require.NotEmpty(t, res.Error, tag)
+	}
+
+	// the subscriptions of a connection are limited
+	for i := 0; i < 10; i++ {
+		require.NoError(t, ws.WriteJSON(rpc.EventsRequest{
+			Action: "subscribe",
+			ID:     fmt.Sprintf("sub%d", i),
+			Type:   rpc.EventTx,
+			Tags:   []string{fmt.Sprintf("sender='none%d'", i)},
+		}))
+	}
+	require.NoError(t, ws.WriteJSON(rpc.EventsRequest{Action: "subscribe", ID: "over", Type: rpc.EventNewBlock}))
+	require.NoError(t, ws.ReadJSON(&res))
+	require.Equal(t, "over", res.ID)
+	require.NotEmpty(t, res.Error)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, ws.WriteJSON(rpc.EventsRequest{Action: "unsubscribe", ID: fmt.Sprintf("sub%d", i)}))
+	}
+
+	// new blocks
+	require.NoError(t, ws.WriteJSON(rpc.EventsRequest{Action: "subscribe", ID: "blocks", Type: rpc.EventNewBlock}))
+	require.NoError(t, ws.ReadJSON(&res))
+	require.Equal(t, "blocks", res.ID, res.Error)
+	require.Equal(t, rpc.EventNewBlock, res.Type)
+	var resultBlock ctypes.ResultBlock
+	require.NoError(t, cdc.UnmarshalJSON(res.Data, &resultBlock))
+	require.NotNil(t, resultBlock.Block)
+	require.NoError(t, ws.WriteJSON(rpc.EventsRequest{Action: "unsubscribe", ID: "blocks"}))
+
+	// txs of the sender
+	require.NoError(t, ws.WriteJSON(rpc.EventsRequest{
+		Action: "subscribe",
+		ID:     "sent",
+		Type:   rpc.EventTx,
+		Tags:   []string{fmt.Sprintf("sender='%s'", addr)},
+	}))
+	_, resultTx := doSend(t, port, seed, name, password, addr)
+	for {
+		require.NoError(t, ws.ReadJSON(&res))
+		if res.ID == "sent" {
+			break
+		}
+	}
+	require.Equal(t, rpc.EventTx, res.Type, res.Error)
+	var info tx.Info
+	require.NoError(t, cdc.UnmarshalJSON(res.Data, &info))
+	require.Equal(t, resultTx.Hash, info.Hash)
+	require.Equal(t, resultTx.Height, info.Height)
+}
+
 func TestPoolParamsQuery(t *testing.T) {
 	_, password := "test", "1234567890"
 	addr, _ := CreateAddr(t, "test", password, GetKeyBase(t))
@@ -1,7 +1,6 @@
 package lcd
 
 import (
-	"net/http"
 	"os"
 
 	"github.com/cosmos/cosmos-sdk/client"
@@ -71,7 +70,7 @@ func ServeCommand(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
-func createHandler(cdc *codec.Codec) http.Handler {
+func createHandler(cdc *codec.Codec) *mux.Router {
 	r := mux.NewRouter()
 
 	kb, err := keys.GetKeyBase() //XXX
@@ -94,6 +93,7 @@ func createHandler(cdc *codec.Codec) http.Handler {
 	stake.RegisterRoutes(cliCtx, r, cdc, kb)
 	slashing.RegisterRoutes(cliCtx, r, cdc, kb)
 	gov.RegisterRoutes(cliCtx, r, cdc)
+	registerSwaggerRoutes(r)
 
 	return r
 }
@@ -0,0 +1,59 @@
+package lcd
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// swaggerUIVersion is the version of the swagger-ui-dist package the Swagger
+// UI is loaded from
+const swaggerUIVersion = "5.18.2"
+
+// swaggerIndex is the page of the Swagger UI browsing the specification, with
+// the scripts and styles of the UI loaded from a CDN
+const swaggerIndex = `<!DOCTYPE html>
+<html lang="en">
+  <head>
+    <meta charset="UTF-8">
+    <title>Gaia-lite REST API</title>
+    <link rel="stylesheet" type="text/css" href="https://unpkg.com/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui.css" />
+    <style>
+      body { margin: 0; background: #fafafa; }
+    </style>
+  </head>
+
+  <body>
+    <div id="swagger-ui"></div>
+    <script src="https://unpkg.com/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui-bundle.js" charset="UTF-8"></script>
+    <script src="https://unpkg.com/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui-standalone-preset.js" charset="UTF-8"></script>
+    <script>
+      window.onload = function() {
+        window.ui = SwaggerUIBundle({
+          url: "swagger.yaml",
+          dom_id: "#swagger-ui",
+          deepLinking: true,
+          presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+          layout: "StandaloneLayout"
+        });
+      };
+    </script>
+  </body>
+</html>
+`
+
+// registerSwaggerRoutes serves the OpenAPI specification of the REST routes,
+// and the Swagger UI browsing it, under /swagger/
+func registerSwaggerRoutes(r *mux.Router) {
+	r.Handle("/swagger", http.RedirectHandler("/swagger/", http.StatusMovedPermanently)).Methods("GET")
+	r.Handle("/swagger/", serveSwaggerFile("text/html; charset=utf-8", swaggerIndex)).Methods("GET")
+	r.Handle("/swagger/swagger.yaml", serveSwaggerFile("application/x-yaml", swaggerYAML)).Methods("GET")
+}
+
+// serveSwaggerFile serves a file of the Swagger UI
+func serveSwaggerFile(contentType, content string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Write([]byte(content))
+	}
+}
@@ -0,0 +1,2520 @@
+package lcd
+
+// swaggerYAML is the OpenAPI specification of the REST routes, served at
+// /swagger/swagger.yaml. Being a raw string, it writes the code spans of its
+// descriptions as <code> elements rather than between backquotes.
+const swaggerYAML = `swagger: '2.0'
+info:
+  version: '1.1.0'
+  title: Gaia-Lite (former LCD) to interface with Cosmos BaseServer via REST
+  description: |
+    Specification for Gaia-lite provided by <code>gaiacli rest-server</code>, served with
+    the Swagger UI at <code>/swagger/</code>. The JSON of the requests and responses is
+    the amino JSON of the SDK types: 64 bit integers are encoded as strings,
+    bytes as base64 strings, and the values of interfaces (accounts, txs, msgs,
+    public keys, proposals) are wrapped as <code>{"type": "&lt;name&gt;", "value": {...}}</code>.
+
+tags:
+- name: version
+  description: Information about the app version
+- name: node
+  description: Information of the connected node
+- name: query
+  description: Information about blocks and txs
+- name: validator set
+  description: Check the state of the validator set
+- name: keys
+  description: Key management to add or view local private keys
+- name: account
+  description: Query account balance
+- name: send
+  description: Create, sign and broadcast txs
+- name: ibc
+  description: Transfer coins to other chains
+- name: stake
+  description: Stake module API for staking and validation
+- name: slashing
+  description: Slashing module API for the signing info and slashes of validators
+- name: gov
+  description: Governance module API for proposals, deposits and votes
+
+securityDefinitions:
+  kms:
+    type: basic
+
+paths:
+  /version:
+    get:
+      summary: Version of Gaia-lite
+      tags:
+        - version
+      description: Get the version of gaia-lite running locally to compare against expected
+      produces:
+        - text/plain
+      responses:
+        200:
+          description: Plaintext version i.e. "v0.5.0"
+  /node_version:
+    get:
+      summary: Version of the connected node
+      tags:
+        - node
+      description: Get the version of the SDK running on the connected node to compare against expected
+      produces:
+        - text/plain
+      responses:
+        200:
+          description: Plaintext version i.e. "v0.5.0"
+        500:
+          description: The node could not be queried
+  /node_info:
+    get:
+      description: Information about the connected node
+      summary: The properties of the connected node
+      tags:
+        - node
+      produces:
+        - application/json
+      responses:
+        200:
+          description: Node status
+          schema:
+            $ref: "#/definitions/NodeInfo"
+        500:
+          description: The node could not be queried
+  /syncing:
+    get:
+      summary: Syncing state of node
+      tags:
+        - node
+      description: Get if the node is currently syncing with other nodes
+      produces:
+        - text/plain
+      responses:
+        200:
+          description: '"true" or "false"'
+        500:
+          description: The node could not be queried
+
+  /blocks/latest:
+    get:
+      summary: Get the latest block
+      tags:
+        - query
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The latest block
+          schema:
+            $ref: "#/definitions/ResultBlock"
+        500:
+          description: The node could not be queried, or the block could not be verified
+  /blocks/{height}:
+    parameters:
+      - $ref: "#/parameters/height"
+    get:
+      summary: Get a block at a certain height
+      tags:
+        - query
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The block at a specific height
+          schema:
+            $ref: "#/definitions/ResultBlock"
+        400:
+          description: The height is not a number
+        404:
+          description: Block at height is not available
+        500:
+          description: The node could not be queried, or the block could not be verified
+  /validatorsets/latest:
+    get:
+      summary: Get the latest validator set
+      tags:
+        - validator set
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The validator set at the latest block height
+          schema:
+            $ref: "#/definitions/ResultValidatorsOutput"
+        500:
+          description: The node could not be queried, or the validator set could not be verified
+  /validatorsets/{height}:
+    parameters:
+      - $ref: "#/parameters/height"
+    get:
+      summary: Get a validator set a certain height
+      tags:
+        - validator set
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The validator set at a specific block height
+          schema:
+            $ref: "#/definitions/ResultValidatorsOutput"
+        400:
+          description: The height is not a number
+        404:
+          description: Block at height not available
+        500:
+          description: The node could not be queried, or the validator set could not be verified
+  /events:
+    get:
+      summary: Subscribe to the events of the node over a websocket
+      description: |
+        Upgrades the connection to a websocket. The client sends <code>EventsRequest</code> messages
+        <code>{"action": "subscribe", "id": "&lt;id&gt;", "type": "new_block|tx|validator_set_updates", "tags": ["action='send'"]}</code>
+        and <code>{"action": "unsubscribe", "id": "&lt;id&gt;"}</code>, the txs being filtered by the tags they match,
+        given as <code>key='value'</code>, the value not holding quotes. A client has at most 10 subscriptions,
+        and the LCD serves at most 1000 subscriptions.
+        The server sends the events of each subscription as <code>EventsResponse</code> messages
+        <code>{"id": "&lt;id&gt;", "type": "&lt;type&gt;", "data": ...}</code>, where the data is the block of <code>/blocks/{height}</code>,
+        the tx of <code>/txs/{hash}</code> or the updated validators of <code>/validatorsets/{height}</code>, and the errors as
+        <code>{"id": "&lt;id&gt;", "error": "&lt;error&gt;"}</code>, such as when the node closes a subscription, which the
+        client has to subscribe to again. When the LCD doesn't trust its node, the blocks and txs
+        are verified before being sent, and the validator_set_updates events, which can't be verified,
+        are refused.
+      tags:
+        - query
+      responses:
+        101:
+          description: Switching to the websocket protocol
+        400:
+          description: The request is not a websocket handshake
+  /txs/{hash}:
+    parameters:
+      - in: path
+        name: hash
+        description: Tx hash
+        required: true
+        type: string
+    get:
+      summary: Get a Tx by hash
+      tags:
+        - query
+      produces:
+        - application/json
+      parameters:
+        - in: query
+          name: trust_node
+          description: |
+            Whether to trust the node instead of verifying the proof of the tx. The proof is always
+            verified when the LCD doesn't trust its node.
+          type: boolean
+      responses:
+        200:
+          description: Tx with the provided hash
+          schema:
+            $ref: "#/definitions/TxInfo"
+        500:
+          description: Tx not available for provided hash, or its proof could not be verified
+  /txs:
+    get:
+      summary: Search txs by tag
+      tags:
+        - query
+      produces:
+        - application/json
+      parameters:
+        - in: query
+          name: tag
+          description: |
+            The tag as a key=value pair, postfix the key with _bech32 to search bech32-encoded addresses
+          type: string
+          required: true
+          x-example: "sender_bech32=cosmos16xyempempp92x9hyzz9wrgf94r6j9h5f06pxxv"
+      responses:
+        200:
+          description: All txs matching the provided tag
+          schema:
+            type: array
+            items:
+              $ref: "#/definitions/TxInfo"
+        400:
+          description: The tag is missing or malformed
+        500:
+          description: The node could not be queried, or the proofs of the txs could not be verified
+
+  /keys:
+    get:
+      summary: List of keys stored locally
+      tags:
+        - keys
+      produces:
+        - application/json
+      responses:
+        200:
+          description: Array of keys
+          schema:
+            type: array
+            items:
+              $ref: "#/definitions/KeyOutput"
+        500:
+          description: The keybase could not be read
+    post:
+      summary: Create a new key locally
+      tags:
+        - keys
+      consumes:
+        - application/json
+      produces:
+        - application/json
+      parameters:
+        - in: body
+          name: key
+          description: The key to create, from the seed if given
+          required: true
+          schema:
+            $ref: "#/definitions/NewKeyBody"
+      responses:
+        200:
+          description: The key created, with its seed
+          schema:
+            $ref: "#/definitions/KeyOutput"
+        400:
+          description: The name or the password is missing
+        409:
+          description: A key with the name already exists
+  /keys/seed:
+    get:
+      summary: Create a new seed to create a new key with
+      tags:
+        - keys
+      produces:
+        - text/plain
+      responses:
+        200:
+          description: 24 word seed
+  /keys/{name}:
+    parameters:
+      - $ref: "#/parameters/name"
+    get:
+      summary: Get a certain locally stored key
+      tags:
+        - keys
+      produces:
+        - application/json
+      responses:
+        200:
+          description: Locally stored key
+          schema:
+            $ref: "#/definitions/KeyOutput"
+        404:
+          description: Key is not available
+    put:
+      summary: Update the password for this key in the KMS
+      tags:
+        - keys
+      consumes:
+        - application/json
+      parameters:
+        - in: body
+          name: passwords
+          description: The new and old password
+          required: true
+          schema:
+            $ref: "#/definitions/UpdateKeyBody"
+      responses:
+        200:
+          description: Updated password
+        401:
+          description: Password is wrong
+        404:
+          description: Key is not available
+    delete:
+      summary: Remove a key
+      tags:
+        - keys
+      consumes:
+        - application/json
+      parameters:
+        - in: body
+          name: password
+          description: The password of the key to remove from the KMS
+          required: true
+          schema:
+            $ref: "#/definitions/DeleteKeyBody"
+      responses:
+        200:
+          description: Removed key
+        401:
+          description: Password is wrong
+        404:
+          description: Key is not available
+  /keys/{name}/export:
+    parameters:
+      - $ref: "#/parameters/name"
+    post:
+      summary: Export a key as a Web3 secret-storage keystore
+      tags:
+        - keys
+      consumes:
+        - application/json
+      produces:
+        - application/json
+      parameters:
+        - in: body
+          name: passwords
+          description: The password of the key and the password encrypting the keystore
+          required: true
+          schema:
+            $ref: "#/definitions/ExportKeyBody"
+      responses:
+        200:
+          description: The Web3 keystore
+          schema:
+            $ref: "#/definitions/Web3Keystore"
+        400:
+          description: The export password is missing
+        401:
+          description: Password is wrong
+        404:
+          description: Key is not available
+  /keys/{name}/import:
+    parameters:
+      - $ref: "#/parameters/name"
+    post:
+      summary: Import a key from a Web3 secret-storage keystore
+      tags:
+        - keys
+      consumes:
+        - application/json
+      produces:
+        - application/json
+      parameters:
+        - in: body
+          name: keystore
+          description: The keystore, its password and the password of the imported key
+          required: true
+          schema:
+            $ref: "#/definitions/ImportKeyBody"
+      responses:
+        200:
+          description: The key imported
+          schema:
+            $ref: "#/definitions/KeyOutput"
+        400:
+          description: The password is missing
+        401:
+          description: The keystore password is wrong
+        409:
+          description: A key with the name already exists
+  /keys/{name}/sign-message:
+    parameters:
+      - $ref: "#/parameters/name"
+    post:
+      summary: Sign an arbitrary message with a key
+      description: The signature is not the signature of a tx, and cannot be replayed as a tx
+      tags:
+        - keys
+      consumes:
+        - application/json
+      produces:
+        - application/json
+      parameters:
+        - in: body
+          name: message
+          description: The password of the key and the message
+          required: true
+          schema:
+            $ref: "#/definitions/SignMessageBody"
+      responses:
+        200:
+          description: The signed message
+          schema:
+            $ref: "#/definitions/StdSignedMessage"
+        401:
+          description: Password is wrong
+        404:
+          description: Key is not available
+  /keys/verify-message:
+    post:
+      summary: Verify a message signed with a key
+      tags:
+        - keys
+      consumes:
+        - application/json
+      produces:
+        - text/plain
+      parameters:
+        - in: body
+          name: message
+          description: The signed message
+          required: true
+          schema:
+            $ref: "#/definitions/StdSignedMessage"
+      responses:
+        200:
+          description: The bech32 address of the signer
+        400:
+          description: The signed message is malformed
+        401:
+          description: The signature is invalid
+
+  /accounts/{address}:
+    parameters:
+      - $ref: "#/parameters/address"
+    get:
+      summary: Get the account balances
+      tags:
+        - account
+      produces:
+        - application/json
+      responses:
+        200:
+          description: Account balances
+          schema:
+            $ref: "#/definitions/Account"
+        204:
+          description: There is no data for the requested account. This is not a 404 as the account might exist, just does not hold data.
+        500:
+          description: The account could not be queried
+  /accounts/{address}/send:
+    parameters:
+      - $ref: "#/parameters/address"
+    post:
+      summary: Send coins (build -> sign -> send)
+      tags:
+        - send
+      security:
+        - kms: []
+      consumes:
+        - application/json
+      produces:
+        - application/json
+      parameters:
+        - in: body
+          name: send
+          description: The coins to send, the key signing the tx and its account number and sequence
+          required: true
+          schema:
+            $ref: "#/definitions/SendBody"
+        - $ref: "#/parameters/simulate"
+        - $ref: "#/parameters/generate_only"
+      responses:
+        200:
+          description: The result of the tx, the gas estimate if simulated, or the unsigned tx if generated only
+          schema:
+            $ref: "#/definitions/ResultBroadcastTxCommit"
+        400:
+          description: The request is malformed
+        401:
+          description: Key or password is wrong
+        500:
+          description: The tx could not be broadcast
+  /tx/sign:
+    post:
+      summary: Sign a tx
+      description: Sign a tx with a locally stored key, the signature is appended or replaces the signatures of the tx
+      tags:
+        - send
+      security:
+        - kms: []
+      consumes:
+        - application/json
+      produces:
+        - application/json
+      parameters:
+        - in: body
+          name: sign
+          description: The tx to sign, the key signing it and its account number and sequence
+          required: true
+          schema:
+            $ref: "#/definitions/SignBody"
+      responses:
+        200:
+          description: The signed tx
+          schema:
+            $ref: "#/definitions/StdTx"
+        400:
+          description: The request is malformed
+        401:
+          description: Key or password is wrong
+  /tx/broadcast:
+    post:
+      summary: Broadcast a signed tx
+      tags:
+        - send
+      consumes:
+        - application/json
+      produces:
+        - application/json
+      parameters:
+        - in: body
+          name: tx
+          description: The signed tx
+          required: true
+          schema:
+            $ref: "#/definitions/BroadcastBody"
+      responses:
+        200:
+          description: The result of the tx
+          schema:
+            $ref: "#/definitions/ResultBroadcastTxCommit"
+        400:
+          description: The tx is malformed
+        500:
+          description: The tx could not be broadcast
+
+  /ibc/{destchain}/{address}/send:
+    parameters:
+      - in: path
+        name: destchain
+        description: The chain ID of the destination chain
+        required: true
+        type: string
+      - $ref: "#/parameters/address"
+    post:
+      summary: Transfer coins to an address on another chain
+      tags:
+        - ibc
+      security:
+        - kms: []
+      consumes:
+        - application/json
+      produces:
+        - application/json
+      parameters:
+        - in: body
+          name: transfer
+          description: The coins to transfer, the key signing the tx and its account number and sequence
+          required: true
+          schema:
+            $ref: "#/definitions/TransferBody"
+        - $ref: "#/parameters/simulate"
+        - $ref: "#/parameters/generate_only"
+      responses:
+        200:
+          description: The result of the tx, the gas estimate if simulated, or the unsigned tx if generated only
+          schema:
+            $ref: "#/definitions/ResultBroadcastTxCommit"
+        400:
+          description: The request is malformed
+        401:
+          description: Key or password is wrong
+        500:
+          description: The tx could not be broadcast
+  /ibc/denom_traces/{denom}:
+    parameters:
+      - in: path
+        name: denom
+        description: The voucher denom, including the path of the chains it was transferred through
+        required: true
+        type: string
+    get:
+      summary: Trace a voucher denom back to its origin chain
+      tags:
+        - ibc
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The trace of the denom
+          schema:
+            $ref: "#/definitions/DenomTrace"
+        500:
+          description: The denom could not be traced
+
+  /stake/delegators/{delegatorAddr}:
+    parameters:
+      - $ref: "#/parameters/delegatorAddr"
+    get:
+      summary: Get all delegations (delegation, undelegation and redelegation) from a delegator
+      tags:
+        - stake
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The delegations of the delegator
+          schema:
+            $ref: "#/definitions/DelegationSummary"
+        400:
+          description: The address is malformed
+        500:
+          description: The delegations could not be queried
+  /stake/delegators/{delegatorAddr}/txs:
+    parameters:
+      - $ref: "#/parameters/delegatorAddr"
+    get:
+      summary: Get all staking txs (i.e msgs) from a delegator
+      tags:
+        - stake
+      produces:
+        - application/json
+      parameters:
+        - in: query
+          name: type
+          description: The space separated types of the txs, all if empty
+          type: string
+          enum:
+            - bond
+            - unbond
+            - redelegate
+      responses:
+        200:
+          description: The staking txs of the delegator
+          schema:
+            type: array
+            items:
+              $ref: "#/definitions/TxInfo"
+        400:
+          description: The type is unknown
+        500:
+          description: The txs could not be queried
+  /stake/delegators/{delegatorAddr}/validators:
+    parameters:
+      - $ref: "#/parameters/delegatorAddr"
+    get:
+      summary: Query all validators that a delegator is bonded to
+      tags:
+        - stake
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The validators the delegator is bonded to
+          schema:
+            type: array
+            items:
+              $ref: "#/definitions/Validator"
+        400:
+          description: The address is malformed
+        500:
+          description: The validators could not be queried
+  /stake/delegators/{delegatorAddr}/validators/{validatorAddr}:
+    parameters:
+      - $ref: "#/parameters/delegatorAddr"
+      - $ref: "#/parameters/validatorAddr"
+    get:
+      summary: Query a validator that a delegator is bonded to
+      tags:
+        - stake
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The validator
+          schema:
+            $ref: "#/definitions/Validator"
+        400:
+          description: The addresses are malformed
+        500:
+          description: The delegator is not bonded to the validator
+  /stake/delegators/{delegatorAddr}/delegations:
+    parameters:
+      - $ref: "#/parameters/delegatorAddr"
+    post:
+      summary: Submit delegation, unbonding and redelegation txs
+      tags:
+        - stake
+      security:
+        - kms: []
+      consumes:
+        - application/json
+      produces:
+        - application/json
+      parameters:
+        - in: body
+          name: delegations
+          description: The delegations, unbondings and redelegations of the delegator, signed with the key
+          required: true
+          schema:
+            $ref: "#/definitions/EditDelegationsBody"
+        - $ref: "#/parameters/simulate"
+        - $ref: "#/parameters/generate_only"
+      responses:
+        200:
+          description: The results of the txs, one per msg
+          schema:
+            type: array
+            items:
+              $ref: "#/definitions/ResultBroadcastTxCommit"
+        400:
+          description: The request is malformed
+        401:
+          description: Key or password is wrong
+        500:
+          description: The txs could not be broadcast
+  /stake/delegators/{delegatorAddr}/delegations/{validatorAddr}:
+    parameters:
+      - $ref: "#/parameters/delegatorAddr"
+      - $ref: "#/parameters/validatorAddr"
+    get:
+      summary: Query a delegation between a delegator and a validator
+      tags:
+        - stake
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The delegation
+          schema:
+            $ref: "#/definitions/Delegation"
+        400:
+          description: The addresses are malformed
+        500:
+          description: The delegation could not be queried
+  /stake/delegators/{delegatorAddr}/unbonding_delegations/{validatorAddr}:
+    parameters:
+      - $ref: "#/parameters/delegatorAddr"
+      - $ref: "#/parameters/validatorAddr"
+    get:
+      summary: Query the unbonding delegation between a delegator and a validator
+      tags:
+        - stake
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The unbonding delegation
+          schema:
+            $ref: "#/definitions/UnbondingDelegation"
+        400:
+          description: The addresses are malformed
+        500:
+          description: The unbonding delegation could not be queried
+  /stake/validators:
+    get:
+      summary: Get all validators
+      tags:
+        - stake
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The validators
+          schema:
+            type: array
+            items:
+              $ref: "#/definitions/Validator"
+        500:
+          description: The validators could not be queried
+  /stake/validators/{addr}:
+    parameters:
+      - in: path
+        name: addr
+        description: Bech32 operator address of the validator
+        required: true
+        type: string
+    get:
+      summary: Get a single validator info
+      tags:
+        - stake
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The validator
+          schema:
+            $ref: "#/definitions/Validator"
+        400:
+          description: The address is malformed
+        500:
+          description: The validator could not be queried
+  /stake/pool:
+    get:
+      summary: Get the current state of the staking pool
+      tags:
+        - stake
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The staking pool
+          schema:
+            $ref: "#/definitions/Pool"
+        500:
+          description: The pool could not be queried
+  /stake/parameters:
+    get:
+      summary: Get the current staking parameter values
+      tags:
+        - stake
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The staking parameters
+          schema:
+            $ref: "#/definitions/StakeParams"
+        500:
+          description: The parameters could not be queried
+  /stake/historical_info/{height}:
+    parameters:
+      - $ref: "#/parameters/height"
+    get:
+      summary: Get the bonded validator set recorded at a height
+      tags:
+        - stake
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The historical info at the height
+          schema:
+            $ref: "#/definitions/HistoricalInfo"
+        400:
+          description: The height is not a number
+        500:
+          description: No historical info is recorded at the height
+
+  /slashing/signing_info/{validator}:
+    parameters:
+      - in: path
+        name: validator
+        description: Bech32 consensus public key of the validator
+        required: true
+        type: string
+    get:
+      summary: Get the signing info of a validator
+      tags:
+        - slashing
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The signing info
+          schema:
+            $ref: "#/definitions/ValidatorSigningInfo"
+        400:
+          description: The public key is malformed
+        500:
+          description: The signing info could not be queried
+  /slashing/missed_blocks/{validator}:
+    parameters:
+      - in: path
+        name: validator
+        description: Bech32 consensus public key of the validator
+        required: true
+        type: string
+    get:
+      summary: Get the blocks missed by a validator in the current signed blocks window, oldest first
+      tags:
+        - slashing
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The missed blocks
+          schema:
+            $ref: "#/definitions/ValidatorMissedBlocks"
+        400:
+          description: The public key is malformed
+        500:
+          description: The missed blocks could not be queried
+  /slashing/slashes/{validator}:
+    parameters:
+      - in: path
+        name: validator
+        description: Bech32 operator address of the validator
+        required: true
+        type: string
+    get:
+      summary: Get the slashes applied to a validator
+      tags:
+        - slashing
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The slash events
+          schema:
+            type: array
+            items:
+              $ref: "#/definitions/SlashEvent"
+        400:
+          description: The address is malformed
+        500:
+          description: The slashes could not be queried
+  /slashing/unjail:
+    post:
+      summary: Unjail a jailed validator
+      tags:
+        - slashing
+      security:
+        - kms: []
+      consumes:
+        - application/json
+      produces:
+        - application/json
+      parameters:
+        - in: body
+          name: unjail
+          description: The validator to unjail and the key of its operator signing the tx
+          required: true
+          schema:
+            $ref: "#/definitions/UnjailBody"
+        - $ref: "#/parameters/simulate"
+        - $ref: "#/parameters/generate_only"
+      responses:
+        200:
+          description: The result of the tx, the gas estimate if simulated, or the unsigned tx if generated only
+          schema:
+            $ref: "#/definitions/StdJSONResultBroadcastTxCommit"
+        400:
+          description: The request is malformed
+        401:
+          description: The key is not the key of the operator of the validator
+        500:
+          description: The tx could not be broadcast
+
+  /gov/proposals:
+    post:
+      summary: Submit a proposal
+      tags:
+        - gov
+      security:
+        - kms: []
+      consumes:
+        - application/json
+      produces:
+        - application/json
+      parameters:
+        - in: body
+          name: proposal
+          description: The proposal and its initial deposit
+          required: true
+          schema:
+            $ref: "#/definitions/PostProposalReq"
+        - $ref: "#/parameters/simulate"
+        - $ref: "#/parameters/generate_only"
+      responses:
+        200:
+          description: The result of the tx, the gas estimate if simulated, or the unsigned tx if generated only
+          schema:
+            $ref: "#/definitions/ResultBroadcastTxCommit"
+        400:
+          description: The request is malformed
+        401:
+          description: Key or password is wrong
+        500:
+          description: The tx could not be broadcast
+    get:
+      summary: Query the proposals
+      tags:
+        - gov
+      produces:
+        - application/json
+      parameters:
+        - in: query
+          name: voter
+          description: Only the proposals voted by the bech32 address
+          type: string
+        - in: query
+          name: depositer
+          description: Only the proposals deposited on by the bech32 address
+          type: string
+        - in: query
+          name: status
+          description: Only the proposals of the status
+          type: string
+          enum:
+            - DepositPeriod
+            - VotingPeriod
+            - Passed
+            - Rejected
+        - in: query
+          name: latest
+          description: Only the latest proposals
+          type: integer
+      responses:
+        200:
+          description: The proposals
+          schema:
+            type: array
+            items:
+              $ref: "#/definitions/Proposal"
+        400:
+          description: The parameters are malformed
+        500:
+          description: The proposals could not be queried
+  /gov/proposals/{proposal-id}:
+    parameters:
+      - $ref: "#/parameters/proposal-id"
+    get:
+      summary: Query a proposal
+      tags:
+        - gov
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The proposal
+          schema:
+            $ref: "#/definitions/Proposal"
+        400:
+          description: The proposal ID is malformed
+        500:
+          description: The proposal could not be queried
+  /gov/proposals/{proposal-id}/deposits:
+    parameters:
+      - $ref: "#/parameters/proposal-id"
+    post:
+      summary: Deposit on a proposal
+      tags:
+        - gov
+      security:
+        - kms: []
+      consumes:
+        - application/json
+      produces:
+        - application/json
+      parameters:
+        - in: body
+          name: deposit
+          description: The deposit
+          required: true
+          schema:
+            $ref: "#/definitions/DepositReq"
+        - $ref: "#/parameters/simulate"
+        - $ref: "#/parameters/generate_only"
+      responses:
+        200:
+          description: The result of the tx, the gas estimate if simulated, or the unsigned tx if generated only
+          schema:
+            $ref: "#/definitions/ResultBroadcastTxCommit"
+        400:
+          description: The request is malformed
+        401:
+          description: Key or password is wrong
+        500:
+          description: The tx could not be broadcast
+  /gov/proposals/{proposal-id}/deposits/{depositer}:
+    parameters:
+      - $ref: "#/parameters/proposal-id"
+      - in: path
+        name: depositer
+        description: Bech32 address of the depositer
+        required: true
+        type: string
+    get:
+      summary: Query the deposit of a depositer on a proposal
+      tags:
+        - gov
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The deposit
+          schema:
+            $ref: "#/definitions/Deposit"
+        400:
+          description: The parameters are malformed, or the depositer did not deposit on the proposal
+        404:
+          description: The proposal does not exist
+  /gov/proposals/{proposal-id}/votes:
+    parameters:
+      - $ref: "#/parameters/proposal-id"
+    post:
+      summary: Vote on a proposal
+      tags:
+        - gov
+      security:
+        - kms: []
+      consumes:
+        - application/json
+      produces:
+        - application/json
+      parameters:
+        - in: body
+          name: vote
+          description: The vote
+          required: true
+          schema:
+            $ref: "#/definitions/VoteReq"
+        - $ref: "#/parameters/simulate"
+        - $ref: "#/parameters/generate_only"
+      responses:
+        200:
+          description: The result of the tx, the gas estimate if simulated, or the unsigned tx if generated only
+          schema:
+            $ref: "#/definitions/ResultBroadcastTxCommit"
+        400:
+          description: The request is malformed
+        401:
+          description: Key or password is wrong
+        500:
+          description: The tx could not be broadcast
+    get:
+      summary: Query the votes on a proposal
+      tags:
+        - gov
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The votes
+          schema:
+            type: array
+            items:
+              $ref: "#/definitions/Vote"
+        400:
+          description: The proposal ID is malformed
+        500:
+          description: The votes could not be queried
+  /gov/proposals/{proposal-id}/votes/{voter}:
+    parameters:
+      - $ref: "#/parameters/proposal-id"
+      - in: path
+        name: voter
+        description: Bech32 address of the voter
+        required: true
+        type: string
+    get:
+      summary: Query the vote of a voter on a proposal
+      tags:
+        - gov
+      produces:
+        - application/json
+      responses:
+        200:
+          description: The vote
+          schema:
+            $ref: "#/definitions/Vote"
+        400:
+          description: The parameters are malformed, or the voter did not vote on the proposal
+        404:
+          description: The proposal does not exist
+
+parameters:
+  address:
+    in: path
+    name: address
+    description: Account address in bech32 format
+    required: true
+    type: string
+  name:
+    in: path
+    name: name
+    description: Key name
+    required: true
+    type: string
+  height:
+    in: path
+    name: height
+    description: Block height
+    required: true
+    type: integer
+  delegatorAddr:
+    in: path
+    name: delegatorAddr
+    description: Bech32 AccAddress of the delegator
+    required: true
+    type: string
+  validatorAddr:
+    in: path
+    name: validatorAddr
+    description: Bech32 operator address of the validator
+    required: true
+    type: string
+  proposal-id:
+    in: path
+    name: proposal-id
+    description: ID of the proposal
+    required: true
+    type: integer
+  simulate:
+    in: query
+    name: simulate
+    description: Only estimate the gas of the tx, returned as {"gas_estimate":<gas>}
+    type: boolean
+  generate_only:
+    in: query
+    name: generate_only
+    description: Only build the tx, returned unsigned as a StdTx
+    type: boolean
+
+definitions:
+  Address:
+    type: string
+    description: bech32 encoded address
+    example: cosmos1depk54cuajgkzea6zpgkq36tnjwdzv4ak663u6
+  ValidatorAddress:
+    type: string
+    description: bech32 encoded operator address
+    example: cosmosvaloper16xyempempp92x9hyzz9wrgf94r6j9h5f2w4n2l
+  ConsensusPubKey:
+    type: string
+    description: bech32 encoded consensus public key
+    example: cosmosvalconspub1zcjduepqwhwqn4h5v6mqa7k3kmy7cjzchsx5ptsrqaulwrgfmghy3k9jtdzs6rdddm
+  PubKey:
+    type: object
+    description: amino encoded public key
+    properties:
+      type:
+        type: string
+        example: tendermint/PubKeySecp256k1
+      value:
+        type: string
+        example: Avz04VhtKJh8ACCVzlI8aTosGy0ikFXKIVHQ3jKMrosH
+  Dec:
+    type: string
+    example: "0.5000000000"
+  Hash:
+    type: string
+    example: EE5F3404034C524501629B56E0DDC38FAD651F04
+  Coin:
+    type: object
+    required:
+      - denom
+      - amount
+    properties:
+      denom:
+        type: string
+        example: steak
+      amount:
+        type: string
+        example: "50"
+  Coins:
+    type: array
+    items:
+      $ref: "#/definitions/Coin"
+  Msg:
+    type: object
+    description: amino encoded msg
+    properties:
+      type:
+        type: string
+        example: cosmos-sdk/Send
+      value:
+        type: object
+
+  KeyOutput:
+    type: object
+    required:
+      - name
+      - type
+      - address
+      - pub_key
+    properties:
+      name:
+        type: string
+        example: alice
+      type:
+        type: string
+        example: local
+      address:
+        $ref: "#/definitions/Address"
+      pub_key:
+        type: string
+        example: cosmospub1addwnpepqgaf7fhzr68mpmz7ycpmtn7ru6wyvp60n2wm4qk7jr9akefalnwxv7mln8d
+      seed:
+        type: string
+        description: The seed of the key, only returned when the key is created
+  NewKeyBody:
+    type: object
+    required:
+      - name
+      - password
+      - seed
+      - algo
+    properties:
+      name:
+        type: string
+      password:
+        type: string
+      seed:
+        type: string
+        description: The seed of the key, a new one is generated if empty
+      algo:
+        type: string
+        description: The signing algorithm of the key, secp256k1 if empty
+  UpdateKeyBody:
+    type: object
+    required:
+      - new_password
+      - old_password
+    properties:
+      new_password:
+        type: string
+      old_password:
+        type: string
+  DeleteKeyBody:
+    type: object
+    required:
+      - password
+    properties:
+      password:
+        type: string
+  ExportKeyBody:
+    type: object
+    required:
+      - password
+      - export_password
+    properties:
+      password:
+        type: string
+      export_password:
+        type: string
+  ImportKeyBody:
+    type: object
+    required:
+      - keystore
+      - keystore_password
+      - password
+    properties:
+      keystore:
+        $ref: "#/definitions/Web3Keystore"
+      keystore_password:
+        type: string
+      password:
+        type: string
+  Web3Keystore:
+    type: object
+    description: Web3 secret-storage keystore, version 3
+    properties:
+      address:
+        type: string
+      crypto:
+        type: object
+        properties:
+          cipher:
+            type: string
+            example: aes-128-ctr
+          ciphertext:
+            type: string
+          cipherparams:
+            type: object
+            properties:
+              iv:
+                type: string
+          kdf:
+            type: string
+            example: scrypt
+          kdfparams:
+            type: object
+          mac:
+            type: string
+      id:
+        type: string
+      version:
+        type: integer
+        example: 3
+  SignMessageBody:
+    type: object
+    required:
+      - password
+      - data
+    properties:
+      password:
+        type: string
+      data:
+        type: string
+        format: byte
+  StdSignedMessage:
+    type: object
+    required:
+      - signer
+      - data
+      - pub_key
+      - signature
+    properties:
+      signer:
+        $ref: "#/definitions/Address"
+      data:
+        type: string
+        format: byte
+      pub_key:
+        $ref: "#/definitions/PubKey"
+      signature:
+        type: string
+        format: byte
+
+  NodeInfo:
+    type: object
+    required:
+      - id
+      - listen_addr
+      - network
+      - version
+      - channels
+      - moniker
+      - other
+    properties:
+      id:
+        type: string
+      listen_addr:
+        type: string
+        example: 192.168.56.1:26656
+      network:
+        type: string
+        example: gaia-2
+      version:
+        description: Tendermint version
+        type: string
+        example: 0.23.1
+      channels:
+        type: string
+      moniker:
+        type: string
+        example: validator-name
+      other:
+        description: more information on versions
+        type: array
+        items:
+          type: string
+  ResultBlock:
+    type: object
+    required:
+      - block_meta
+      - block
+    properties:
+      block_meta:
+        type: object
+        description: The ID and the header of the block
+      block:
+        type: object
+        description: The header, txs, evidence and last commit of the block
+  ValidatorOutput:
+    type: object
+    required:
+      - address
+      - pub_key
+      - accum
+      - voting_power
+    properties:
+      address:
+        $ref: "#/definitions/ValidatorAddress"
+      pub_key:
+        $ref: "#/definitions/ConsensusPubKey"
+      accum:
+        type: string
+        example: "1000"
+      voting_power:
+        type: string
+        example: "1000"
+  ResultValidatorsOutput:
+    type: object
+    required:
+      - block_height
+      - validators
+    properties:
+      block_height:
+        type: string
+        example: "1"
+      validators:
+        type: array
+        items:
+          $ref: "#/definitions/ValidatorOutput"
+  EventsRequest:
+    type: object
+    description: Message of the client on the /events websocket
+    required:
+      - action
+      - id
+      - type
+      - tags
+    properties:
+      action:
+        type: string
+        enum:
+          - subscribe
+          - unsubscribe
+      id:
+        type: string
+      type:
+        type: string
+        enum:
+          - new_block
+          - tx
+          - validator_set_updates
+      tags:
+        type: array
+        description: The tags the txs match, key='value', the value not holding quotes
+        items:
+          type: string
+          example: action='send'
+  EventsResponse:
+    type: object
+    description: Message of the server on the /events websocket
+    required:
+      - id
+    properties:
+      id:
+        type: string
+      type:
+        type: string
+      data:
+        type: object
+      error:
+        type: string
+
+  StdFee:
+    type: object
+    required:
+      - amount
+      - gas
+    properties:
+      amount:
+        $ref: "#/definitions/Coins"
+      gas:
+        type: string
+        example: "200000"
+  StdSignature:
+    type: object
+    required:
+      - pub_key
+      - signature
+      - account_number
+      - sequence
+    properties:
+      pub_key:
+        $ref: "#/definitions/PubKey"
+      signature:
+        type: string
+        format: byte
+      account_number:
+        type: string
+        example: "0"
+      sequence:
+        type: string
+        example: "0"
+  StdTxValue:
+    type: object
+    required:
+      - msg
+      - fee
+      - signatures
+      - memo
+    properties:
+      msg:
+        type: array
+        items:
+          $ref: "#/definitions/Msg"
+      fee:
+        $ref: "#/definitions/StdFee"
+      signatures:
+        type: array
+        items:
+          $ref: "#/definitions/StdSignature"
+      memo:
+        type: string
+  StdTx:
+    type: object
+    description: amino encoded StdTx
+    required:
+      - type
+      - value
+    properties:
+      type:
+        type: string
+        example: auth/StdTx
+      value:
+        $ref: "#/definitions/StdTxValue"
+  ResponseDeliverTx:
+    type: object
+    properties:
+      code:
+        type: integer
+      data:
+        type: string
+        format: byte
+      log:
+        type: string
+      info:
+        type: string
+      gas_wanted:
+        type: string
+      gas_used:
+        type: string
+      tags:
+        type: array
+        items:
+          type: object
+          properties:
+            key:
+              type: string
+              format: byte
+            value:
+              type: string
+              format: byte
+      fee:
+        type: object
+  ResponseCheckTx:
+    $ref: "#/definitions/ResponseDeliverTx"
+  ResultBroadcastTxCommit:
+    type: object
+    required:
+      - check_tx
+      - deliver_tx
+      - hash
+      - height
+    properties:
+      check_tx:
+        $ref: "#/definitions/ResponseCheckTx"
+      deliver_tx:
+        $ref: "#/definitions/ResponseDeliverTx"
+      hash:
+        $ref: "#/definitions/Hash"
+      height:
+        type: string
+        example: "1"
+  StdJSONResponseDeliverTx:
+    type: object
+    description: ResponseDeliverTx encoded with encoding/json rather than amino, so its integers are numbers
+    properties:
+      code:
+        type: integer
+      data:
+        type: string
+        format: byte
+      log:
+        type: string
+      info:
+        type: string
+      gas_wanted:
+        type: integer
+      gas_used:
+        type: integer
+      tags:
+        type: array
+        items:
+          type: object
+          properties:
+            key:
+              type: string
+              format: byte
+            value:
+              type: string
+              format: byte
+      fee:
+        type: object
+  StdJSONResultBroadcastTxCommit:
+    type: object
+    description: ResultBroadcastTxCommit encoded with encoding/json rather than amino, so its integers are numbers
+    required:
+      - check_tx
+      - deliver_tx
+      - hash
+      - height
+    properties:
+      check_tx:
+        $ref: "#/definitions/StdJSONResponseDeliverTx"
+      deliver_tx:
+        $ref: "#/definitions/StdJSONResponseDeliverTx"
+      hash:
+        $ref: "#/definitions/Hash"
+      height:
+        type: integer
+        example: 1
+  TxInfo:
+    type: object
+    required:
+      - hash
+      - height
+      - tx
+      - result
+    properties:
+      hash:
+        $ref: "#/definitions/Hash"
+      height:
+        type: string
+        example: "1"
+      tx:
+        $ref: "#/definitions/StdTx"
+      result:
+        $ref: "#/definitions/ResponseDeliverTx"
+
+  BaseAccount:
+    type: object
+    required:
+      - address
+      - coins
+      - public_key
+      - account_number
+      - sequence
+    properties:
+      address:
+        $ref: "#/definitions/Address"
+      coins:
+        $ref: "#/definitions/Coins"
+      public_key:
+        $ref: "#/definitions/PubKey"
+      account_number:
+        type: string
+        example: "0"
+      sequence:
+        type: string
+        example: "0"
+  Account:
+    type: object
+    description: amino encoded account
+    required:
+      - type
+      - value
+    properties:
+      type:
+        type: string
+        example: auth/Account
+      value:
+        $ref: "#/definitions/BaseAccount"
+  SignBody:
+    type: object
+    required:
+      - tx
+      - name
+      - password
+      - chain_id
+      - account_number
+      - sequence
+      - append_sig
+    properties:
+      tx:
+        $ref: "#/definitions/StdTxValue"
+      name:
+        type: string
+      password:
+        type: string
+      chain_id:
+        type: string
+      account_number:
+        type: string
+        example: "0"
+      sequence:
+        type: string
+        example: "0"
+      append_sig:
+        type: boolean
+  SendBody:
+    type: object
+    required:
+      - amount
+      - name
+      - password
+      - chain_id
+      - account_number
+      - sequence
+      - gas
+      - gas_adjustment
+    properties:
+      amount:
+        $ref: "#/definitions/Coins"
+      name:
+        type: string
+      password:
+        type: string
+      chain_id:
+        type: string
+      account_number:
+        type: string
+        example: "0"
+      sequence:
+        type: string
+        example: "0"
+      gas:
+        type: string
+        description: The gas limit, or "simulate" to estimate it
+      gas_adjustment:
+        type: string
+        example: "1.0"
+  BroadcastBody:
+    type: object
+    required:
+      - tx
+    properties:
+      tx:
+        $ref: "#/definitions/StdTxValue"
+  TransferBody:
+    type: object
+    required:
+      - amount
+      - name
+      - password
+      - src_chain_id
+      - account_number
+      - sequence
+      - gas
+      - gas_adjustment
+      - timeout_height
+      - timeout_timestamp
+    properties:
+      amount:
+        $ref: "#/definitions/Coins"
+      name:
+        type: string
+      password:
+        type: string
+      src_chain_id:
+        type: string
+      account_number:
+        type: string
+        example: "0"
+      sequence:
+        type: string
+        example: "0"
+      gas:
+        type: string
+        description: The gas limit, or "simulate" to estimate it
+      gas_adjustment:
+        type: string
+        example: "1.0"
+      timeout_height:
+        type: string
+        description: The height of the destination chain after which the transfer times out, none if 0
+        example: "0"
+      timeout_timestamp:
+        type: string
+        description: The unix time after which the transfer times out, none if 0
+        example: "0"
+  DenomTrace:
+    type: object
+    required:
+      - denom
+      - path
+      - base_denom
+    properties:
+      denom:
+        type: string
+      path:
+        type: array
+        items:
+          type: string
+      base_denom:
+        type: string
+
+  Description:
+    type: object
+    required:
+      - moniker
+      - identity
+      - website
+      - details
+    properties:
+      moniker:
+        type: string
+      identity:
+        type: string
+      website:
+        type: string
+      details:
+        type: string
+  Validator:
+    type: object
+    required:
+      - operator_address
+      - consensus_pubkey
+      - jailed
+      - status
+      - tokens
+      - delegator_shares
+      - description
+      - bond_height
+      - bond_intra_tx_counter
+      - unbonding_height
+      - unbonding_time
+      - commission
+      - commission_max
+      - commission_change_rate
+      - commission_change_today
+    properties:
+      operator_address:
+        $ref: "#/definitions/ValidatorAddress"
+      consensus_pubkey:
+        $ref: "#/definitions/ConsensusPubKey"
+      jailed:
+        type: boolean
+      status:
+        type: integer
+        description: 0 for unbonded, 1 for unbonding, 2 for bonded
+      tokens:
+        $ref: "#/definitions/Dec"
+      delegator_shares:
+        $ref: "#/definitions/Dec"
+      description:
+        $ref: "#/definitions/Description"
+      bond_height:
+        type: string
+        example: "0"
+      bond_intra_tx_counter:
+        type: integer
+      unbonding_height:
+        type: string
+        example: "0"
+      unbonding_time:
+        type: string
+        format: date-time
+      commission:
+        $ref: "#/definitions/Dec"
+      commission_max:
+        $ref: "#/definitions/Dec"
+      commission_change_rate:
+        $ref: "#/definitions/Dec"
+      commission_change_today:
+        $ref: "#/definitions/Dec"
+  Delegation:
+    type: object
+    required:
+      - delegator_addr
+      - validator_addr
+      - shares
+      - height
+      - auto_restake
+    properties:
+      delegator_addr:
+        $ref: "#/definitions/Address"
+      validator_addr:
+        $ref: "#/definitions/ValidatorAddress"
+      shares:
+        $ref: "#/definitions/Dec"
+      height:
+        type: string
+        example: "0"
+      auto_restake:
+        type: boolean
+  UnbondingDelegation:
+    type: object
+    required:
+      - delegator_addr
+      - validator_addr
+      - creation_height
+      - min_time
+      - initial_balance
+      - balance
+    properties:
+      delegator_addr:
+        $ref: "#/definitions/Address"
+      validator_addr:
+        $ref: "#/definitions/ValidatorAddress"
+      creation_height:
+        type: string
+        example: "0"
+      min_time:
+        type: string
+        format: date-time
+      initial_balance:
+        $ref: "#/definitions/Coin"
+      balance:
+        $ref: "#/definitions/Coin"
+  Redelegation:
+    type: object
+    required:
+      - delegator_addr
+      - validator_src_addr
+      - validator_dst_addr
+      - creation_height
+      - min_time
+      - initial_balance
+      - balance
+      - shares_src
+      - shares_dst
+    properties:
+      delegator_addr:
+        $ref: "#/definitions/Address"
+      validator_src_addr:
+        $ref: "#/definitions/ValidatorAddress"
+      validator_dst_addr:
+        $ref: "#/definitions/ValidatorAddress"
+      creation_height:
+        type: string
+        example: "0"
+      min_time:
+        type: string
+        format: date-time
+      initial_balance:
+        $ref: "#/definitions/Coin"
+      balance:
+        $ref: "#/definitions/Coin"
+      shares_src:
+        $ref: "#/definitions/Dec"
+      shares_dst:
+        $ref: "#/definitions/Dec"
+  DelegationSummary:
+    type: object
+    required:
+      - delegations
+      - unbonding_delegations
+      - redelegations
+    properties:
+      delegations:
+        type: array
+        items:
+          $ref: "#/definitions/Delegation"
+      unbonding_delegations:
+        type: array
+        items:
+          $ref: "#/definitions/UnbondingDelegation"
+      redelegations:
+        type: array
+        items:
+          $ref: "#/definitions/Redelegation"
+  Pool:
+    type: object
+    required:
+      - loose_tokens
+      - bonded_tokens
+      - inflation_last_time
+      - inflation
+      - date_last_commission_reset
+      - prev_bonded_shares
+    properties:
+      loose_tokens:
+        $ref: "#/definitions/Dec"
+      bonded_tokens:
+        $ref: "#/definitions/Dec"
+      inflation_last_time:
+        type: string
+        format: date-time
+      inflation:
+        $ref: "#/definitions/Dec"
+      date_last_commission_reset:
+        type: string
+        example: "0"
+      prev_bonded_shares:
+        $ref: "#/definitions/Dec"
+  StakeParams:
+    type: object
+    required:
+      - inflation_rate_change
+      - inflation_max
+      - inflation_min
+      - goal_bonded
+      - unbonding_time
+      - max_validators
+      - bond_denom
+      - historical_entries
+    properties:
+      inflation_rate_change:
+        $ref: "#/definitions/Dec"
+      inflation_max:
+        $ref: "#/definitions/Dec"
+      inflation_min:
+        $ref: "#/definitions/Dec"
+      goal_bonded:
+        $ref: "#/definitions/Dec"
+      unbonding_time:
+        type: string
+        description: The unbonding time in nanoseconds
+        example: "259200000000000"
+      max_validators:
+        type: integer
+        example: 100
+      bond_denom:
+        type: string
+        example: steak
+      historical_entries:
+        type: integer
+        example: 100
+  HistoricalValidator:
+    type: object
+    required:
+      - operator_address
+      - tokens
+      - delegator_shares
+      - status
+    properties:
+      operator_address:
+        $ref: "#/definitions/ValidatorAddress"
+      tokens:
+        $ref: "#/definitions/Dec"
+      delegator_shares:
+        $ref: "#/definitions/Dec"
+      status:
+        type: integer
+  HistoricalInfo:
+    type: object
+    required:
+      - height
+      - header_hash
+      - validators
+    properties:
+      height:
+        type: string
+        example: "1"
+      header_hash:
+        $ref: "#/definitions/Hash"
+      validators:
+        type: array
+        items:
+          $ref: "#/definitions/HistoricalValidator"
+  EditDelegationsBody:
+    type: object
+    required:
+      - name
+      - password
+      - chain_id
+      - account_number
+      - sequence
+      - gas
+      - gas_adjustment
+      - delegations
+      - begin_unbondings
+      - complete_unbondings
+      - begin_redelegates
+      - complete_redelegates
+    properties:
+      name:
+        type: string
+      password:
+        type: string
+      chain_id:
+        type: string
+      account_number:
+        type: string
+        example: "0"
+      sequence:
+        type: string
+        example: "0"
+      gas:
+        type: string
+        description: The gas limit, or "simulate" to estimate it
+      gas_adjustment:
+        type: string
+        example: "1.0"
+      delegations:
+        type: array
+        items:
+          type: object
+          properties:
+            delegator_addr:
+              $ref: "#/definitions/Address"
+            validator_addr:
+              $ref: "#/definitions/ValidatorAddress"
+            delegation:
+              $ref: "#/definitions/Coin"
+      begin_unbondings:
+        type: array
+        items:
+          type: object
+          properties:
+            delegator_addr:
+              $ref: "#/definitions/Address"
+            validator_addr:
+              $ref: "#/definitions/ValidatorAddress"
+            shares:
+              type: string
+      complete_unbondings:
+        type: array
+        items:
+          type: object
+          properties:
+            delegator_addr:
+              $ref: "#/definitions/Address"
+            validator_addr:
+              $ref: "#/definitions/ValidatorAddress"
+      begin_redelegates:
+        type: array
+        items:
+          type: object
+          properties:
+            delegator_addr:
+              $ref: "#/definitions/Address"
+            validator_src_addr:
+              $ref: "#/definitions/ValidatorAddress"
+            validator_dst_addr:
+              $ref: "#/definitions/ValidatorAddress"
+            shares:
+              type: string
+      complete_redelegates:
+        type: array
+        items:
+          type: object
+          properties:
+            delegator_addr:
+              $ref: "#/definitions/Address"
+            validator_src_addr:
+              $ref: "#/definitions/ValidatorAddress"
+            validator_dst_addr:
+              $ref: "#/definitions/ValidatorAddress"
+
+  ValidatorSigningInfo:
+    type: object
+    required:
+      - start_height
+      - index_offset
+      - jailed_until
+      - signed_blocks_counter
+    properties:
+      start_height:
+        type: string
+        example: "0"
+      index_offset:
+        type: string
+        example: "0"
+      jailed_until:
+        type: string
+        format: date-time
+      signed_blocks_counter:
+        type: string
+        example: "0"
+  MissedBlock:
+    type: object
+    required:
+      - height
+      - missed
+    properties:
+      height:
+        type: string
+        example: "100"
+      missed:
+        type: boolean
+  ValidatorMissedBlocks:
+    type: object
+    required:
+      - address
+      - signed_blocks_window
+      - signed_blocks_counter
+      - missed_blocks
+    properties:
+      address:
+        type: string
+        description: bech32 encoded consensus address
+      signed_blocks_window:
+        type: string
+        example: "100"
+      signed_blocks_counter:
+        type: string
+        example: "0"
+      missed_blocks:
+        type: array
+        items:
+          $ref: "#/definitions/MissedBlock"
+  SlashEvent:
+    type: object
+    required:
+      - validator_addr
+      - height
+      - infraction_height
+      - fraction
+      - reason
+      - tokens_burned
+    properties:
+      validator_addr:
+        $ref: "#/definitions/ValidatorAddress"
+      height:
+        type: string
+        example: "1"
+      infraction_height:
+        type: string
+        example: "1"
+      fraction:
+        $ref: "#/definitions/Dec"
+      reason:
+        type: string
+        enum:
+          - double_sign
+          - downtime
+      tokens_burned:
+        $ref: "#/definitions/Dec"
+  UnjailBody:
+    type: object
+    description: Encoded with encoding/json rather than amino, so its integers are numbers
+    required:
+      - name
+      - password
+      - chain_id
+      - account_number
+      - sequence
+      - gas
+      - gas_adjustment
+      - validator_addr
+    properties:
+      name:
+        type: string
+      password:
+        type: string
+      chain_id:
+        type: string
+      account_number:
+        type: integer
+        example: 0
+      sequence:
+        type: integer
+        example: 0
+      gas:
+        type: integer
+        description: The gas limit, estimated if 0
+        example: 0
+      gas_adjustment:
+        type: string
+        example: "1.0"
+      validator_addr:
+        $ref: "#/definitions/ValidatorAddress"
+
+  BaseReq:
+    type: object
+    required:
+      - name
+      - password
+      - chain_id
+      - account_number
+      - sequence
+      - gas
+      - gas_adjustment
+    properties:
+      name:
+        type: string
+      password:
+        type: string
+      chain_id:
+        type: string
+      account_number:
+        type: string
+        example: "0"
+      sequence:
+        type: string
+        example: "0"
+      gas:
+        type: string
+        description: The gas limit, or "simulate" to estimate it
+      gas_adjustment:
+        type: string
+        example: "1.0"
+  PostProposalReq:
+    type: object
+    required:
+      - base_req
+      - title
+      - description
+      - proposal_type
+      - proposer
+      - initial_deposit
+    properties:
+      base_req:
+        $ref: "#/definitions/BaseReq"
+      title:
+        type: string
+      description:
+        type: string
+      proposal_type:
+        type: string
+        enum:
+          - Text
+          - ParameterChange
+          - SoftwareUpgrade
+      proposer:
+        $ref: "#/definitions/Address"
+      initial_deposit:
+        $ref: "#/definitions/Coins"
+  DepositReq:
+    type: object
+    required:
+      - base_req
+      - depositer
+      - amount
+    properties:
+      base_req:
+        $ref: "#/definitions/BaseReq"
+      depositer:
+        $ref: "#/definitions/Address"
+      amount:
+        $ref: "#/definitions/Coins"
+  VoteReq:
+    type: object
+    required:
+      - base_req
+      - voter
+      - option
+    properties:
+      base_req:
+        $ref: "#/definitions/BaseReq"
+      voter:
+        $ref: "#/definitions/Address"
+      option:
+        type: string
+        enum:
+          - "Yes"
+          - Abstain
+          - "No"
+          - NoWithVeto
+  TallyResult:
+    type: object
+    required:
+      - "yes"
+      - abstain
+      - "no"
+      - no_with_veto
+    properties:
+      "yes":
+        $ref: "#/definitions/Dec"
+      abstain:
+        $ref: "#/definitions/Dec"
+      "no":
+        $ref: "#/definitions/Dec"
+      no_with_veto:
+        $ref: "#/definitions/Dec"
+  TextProposal:
+    type: object
+    required:
+      - proposal_id
+      - title
+      - description
+      - proposal_type
+      - proposal_status
+      - tally_result
+      - submit_block
+      - total_deposit
+      - voting_start_block
+    properties:
+      proposal_id:
+        type: string
+        example: "1"
+      title:
+        type: string
+      description:
+        type: string
+      proposal_type:
+        type: string
+        example: Text
+      proposal_status:
+        type: string
+        example: DepositPeriod
+      tally_result:
+        $ref: "#/definitions/TallyResult"
+      submit_block:
+        type: string
+        example: "1"
+      total_deposit:
+        $ref: "#/definitions/Coins"
+      voting_start_block:
+        type: string
+        description: The height the voting period started at, -1 during the deposit period
+        example: "-1"
+  Proposal:
+    type: object
+    description: amino encoded proposal
+    required:
+      - type
+      - value
+    properties:
+      type:
+        type: string
+        example: gov/TextProposal
+      value:
+        $ref: "#/definitions/TextProposal"
+  Deposit:
+    type: object
+    required:
+      - depositer
+      - proposal_id
+      - amount
+    properties:
+      depositer:
+        $ref: "#/definitions/Address"
+      proposal_id:
+        type: string
+        example: "1"
+      amount:
+        $ref: "#/definitions/Coins"
+  Vote:
+    type: object
+    required:
+      - voter
+      - proposal_id
+      - option
+    properties:
+      voter:
+        $ref: "#/definitions/Address"
+      proposal_id:
+        type: string
+        example: "1"
+      option:
+        type: string
+        example: "Yes"
+`
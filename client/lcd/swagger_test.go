@@ -0,0 +1,416 @@
+package lcd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/p2p"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	yaml "gopkg.in/yaml.v2"
+
+	keys "github.com/cosmos/cosmos-sdk/client/keys"
+	rpc "github.com/cosmos/cosmos-sdk/client/rpc"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	gapp "github.com/cosmos/cosmos-sdk/cmd/gaia/app"
+	"github.com/cosmos/cosmos-sdk/codec"
+	cryptokeys "github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authrest "github.com/cosmos/cosmos-sdk/x/auth/client/rest"
+	bankrest "github.com/cosmos/cosmos-sdk/x/bank/client/rest"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govrest "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+	ibcrest "github.com/cosmos/cosmos-sdk/x/ibc/client/rest"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	slashingrest "github.com/cosmos/cosmos-sdk/x/slashing/client/rest"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+	stakerest "github.com/cosmos/cosmos-sdk/x/stake/client/rest"
+	staketypes "github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// swaggerSample holds samples of the request body and of the response of a
+// route, marshalled to the JSON the route reads and writes. A nil sample is
+// no body, or a response which isn't JSON.
+type swaggerSample struct {
+	req interface{}
+	res interface{}
+}
+
+// stdJSON marshals the sample with encoding/json, for the routes not using amino
+type stdJSON struct {
+	v interface{}
+}
+
+// swaggerSamples returns the samples of the registered routes, by method and
+// path. The slices of the samples hold an element, so that the schema of their
+// items is checked.
+func swaggerSamples(t *testing.T) map[string]swaggerSample {
+	pk := ed25519.GenPrivKey().PubKey()
+	keystore, err := cryptokeys.EncryptWeb3Keystore(secp256k1.GenPrivKey(), "passphrase")
+	require.NoError(t, err)
+	validator := stake.NewValidator(sdk.ValAddress(pk.Address()), pk, stake.Description{})
+	txInfo := tx.Info{Tx: auth.StdTx{}}
+	broadcastRes := &ctypes.ResultBroadcastTxCommit{}
+	keyOutput := stdJSON{keys.KeyOutput{}}
+
+	return map[string]swaggerSample{
+		"GET /version":      {},
+		"GET /node_version": {},
+		"GET /node_info":    {res: p2p.NodeInfo{}},
+		"GET /syncing":      {},
+
+		"GET /blocks/latest":          {res: ctypes.ResultBlock{}},
+		"GET /blocks/{height}":        {res: ctypes.ResultBlock{}},
+		"GET /validatorsets/latest":   {res: rpc.ResultValidatorsOutput{Validators: []rpc.ValidatorOutput{{}}}},
+		"GET /validatorsets/{height}": {res: rpc.ResultValidatorsOutput{Validators: []rpc.ValidatorOutput{{}}}},
+		"GET /events":                 {},
+		"GET /txs/{hash}":             {res: txInfo},
+		"GET /txs":                    {res: []tx.Info{txInfo}},
+
+		"GET /keys":                      {res: stdJSON{[]keys.KeyOutput{{}}}},
+		"POST /keys":                     {req: stdJSON{keys.NewKeyBody{}}, res: stdJSON{keys.KeyOutput{Seed: "seed"}}},
+		"GET /keys/seed":                 {},
+		"GET /keys/{name}":               {res: keyOutput},
+		"PUT /keys/{name}":               {req: stdJSON{keys.UpdateKeyBody{}}},
+		"DELETE /keys/{name}":            {req: stdJSON{keys.DeleteKeyBody{}}},
+		"POST /keys/{name}/export":       {req: stdJSON{keys.ExportKeyBody{}}, res: stdJSON{json.RawMessage(keystore)}},
+		"POST /keys/{name}/import":       {req: stdJSON{keys.ImportKeyBody{Keystore: json.RawMessage(`{}`)}}, res: keyOutput},
+		"POST /keys/{name}/sign-message": {req: stdJSON{keys.SignMessageBody{}}, res: auth.StdSignedMessage{}},
+		"POST /keys/verify-message":      {req: auth.StdSignedMessage{}},
+
+		"GET /accounts/{address}":       {res: &auth.BaseAccount{}},
+		"POST /accounts/{address}/send": {req: bankrest.SendBody{}, res: broadcastRes},
+		"POST /tx/sign":                 {req: authrest.SignBody{}, res: auth.StdTx{}},
+		"POST /tx/broadcast":            {req: bankrest.BroadcastBody{}, res: broadcastRes},
+
+		"POST /ibc/{destchain}/{address}/send": {req: ibcrest.TransferBody{}, res: broadcastRes},
+		"GET /ibc/denom_traces/{denom}":        {res: ibc.DenomTrace{}},
+
+		"GET /stake/delegators/{delegatorAddr}": {res: stake.DelegationSummary{
+			Delegations:          []stake.Delegation{{}},
+			UnbondingDelegations: []stake.UnbondingDelegation{{}},
+			Redelegations:        []stake.Redelegation{{}},
+		}},
+		"GET /stake/delegators/{delegatorAddr}/txs":                                   {res: []tx.Info{txInfo}},
+		"GET /stake/delegators/{delegatorAddr}/validators":                            {res: []stake.Validator{validator}},
+		"GET /stake/delegators/{delegatorAddr}/validators/{validatorAddr}":            {res: validator},
+		"POST /stake/delegators/{delegatorAddr}/delegations":                          {req: stakerest.EditDelegationsBody{}, res: []*ctypes.ResultBroadcastTxCommit{broadcastRes}},
+		"GET /stake/delegators/{delegatorAddr}/delegations/{validatorAddr}":           {res: stake.Delegation{}},
+		"GET /stake/delegators/{delegatorAddr}/unbonding_delegations/{validatorAddr}": {res: stake.UnbondingDelegation{}},
+		"GET /stake/validators":               {res: []stake.Validator{validator}},
+		"GET /stake/validators/{addr}":        {res: validator},
+		"GET /stake/pool":                     {res: stake.Pool{}},
+		"GET /stake/parameters":               {res: stake.Params{}},
+		"GET /stake/historical_info/{height}": {res: stake.HistoricalInfo{Validators: []staketypes.HistoricalValidator{{}}}},
+
+		"GET /slashing/signing_info/{validator}":  {res: slashing.ValidatorSigningInfo{}},
+		"GET /slashing/missed_blocks/{validator}": {res: slashing.ValidatorMissedBlocks{MissedBlocks: []slashing.MissedBlock{{}}}},
+		"GET /slashing/slashes/{validator}":       {res: []slashing.SlashEvent{{}}},
+		"POST /slashing/unjail":                   {req: stdJSON{slashingrest.UnjailBody{}}, res: stdJSON{broadcastRes}},
+
+		"POST /gov/proposals":                                   {req: govrest.PostProposalReq{}, res: broadcastRes},
+		"GET /gov/proposals":                                    {res: []gov.Proposal{&gov.TextProposal{}}},
+		"GET /gov/proposals/{proposal-id}":                      {res: &gov.TextProposal{}},
+		"POST /gov/proposals/{proposal-id}/deposits":            {req: govrest.DepositReq{}, res: broadcastRes},
+		"GET /gov/proposals/{proposal-id}/deposits/{depositer}": {res: gov.Deposit{}},
+		"POST /gov/proposals/{proposal-id}/votes":               {req: govrest.VoteReq{}, res: broadcastRes},
+		"GET /gov/proposals/{proposal-id}/votes":                {res: []gov.Vote{{}}},
+		"GET /gov/proposals/{proposal-id}/votes/{voter}":        {res: gov.Vote{}},
+	}
+}
+
+// swaggerDefinitionSamples returns samples of the definitions of the JSON not
+// sent in the body of a route, such as the messages of the websockets
+func swaggerDefinitionSamples() map[string]interface{} {
+	return map[string]interface{}{
+		"EventsRequest":  stdJSON{rpc.EventsRequest{Tags: []string{}}},
+		"EventsResponse": stdJSON{rpc.EventsResponse{ID: "id", Type: rpc.EventTx, Data: json.RawMessage(`{}`), Error: "error"}},
+	}
+}
+
+// the variables of the path templates of the router, with their patterns
+var pathVarRegexp = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+func TestSwaggerSpec(t *testing.T) {
+	GetKeyBase(t)
+	cdc := gapp.MakeCodec()
+	r := createHandler(cdc)
+
+	var doc interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(swaggerYAML), &doc))
+	spec := swaggerSpec{normalizeYAML(doc).(map[string]interface{})}
+	paths := spec.object("paths")
+
+	samples := swaggerSamples(t)
+	routes := make(map[string]bool)
+	err = r.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		tpl, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		// the specification itself
+		if strings.HasPrefix(tpl, "/swagger") {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return fmt.Errorf("route %s has no method: %v", tpl, err)
+		}
+		path := pathVarRegexp.ReplaceAllString(tpl, "{$1}")
+
+		for _, method := range methods {
+			name := method + " " + path
+			routes[name] = true
+
+			sample, ok := samples[name]
+			require.True(t, ok, "route %s has no sample", name)
+
+			item, ok := paths[path].(map[string]interface{})
+			require.True(t, ok, "route %s is not in the specification", name)
+			op, ok := item[strings.ToLower(method)].(map[string]interface{})
+			require.True(t, ok, "route %s is not in the specification", name)
+
+			spec.checkParams(t, name, path, item, op)
+			spec.checkRequest(t, cdc, name, item, op, sample.req)
+			spec.checkResponse(t, cdc, name, op, sample.res)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	// the specification and the samples have no stale route
+	for path, item := range paths {
+		for method := range item.(map[string]interface{}) {
+			if method == "parameters" {
+				continue
+			}
+			name := strings.ToUpper(method) + " " + path
+			require.True(t, routes[name], "route %s of the specification is not registered", name)
+		}
+	}
+	for name := range samples {
+		require.True(t, routes[name], "route %s of the samples is not registered", name)
+	}
+
+	for name, sample := range swaggerDefinitionSamples() {
+		schema := map[string]interface{}{"$ref": "#/definitions/" + name}
+		spec.checkSchema(t, "definition "+name, schema, marshalSample(t, cdc, sample))
+	}
+}
+
+func TestSwaggerRoutes(t *testing.T) {
+	r := mux.NewRouter()
+	registerSwaggerRoutes(r)
+
+	for path, content := range map[string]string{
+		"/swagger/":             swaggerIndex,
+		"/swagger/swagger.yaml": swaggerYAML,
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		require.Equal(t, http.StatusOK, w.Code, path)
+		require.Equal(t, content, w.Body.String(), path)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/swagger", nil))
+	require.Equal(t, http.StatusMovedPermanently, w.Code)
+	require.Equal(t, "/swagger/", w.Header().Get("Location"))
+}
+
+// swaggerSpec is the OpenAPI specification decoded from YAML
+type swaggerSpec struct {
+	doc map[string]interface{}
+}
+
+func (spec swaggerSpec) object(key string) map[string]interface{} {
+	obj, _ := spec.doc[key].(map[string]interface{})
+	return obj
+}
+
+// resolve returns the object referenced by the $ref of the object, if any
+func (spec swaggerSpec) resolve(t *testing.T, obj map[string]interface{}) map[string]interface{} {
+	for {
+		ref, ok := obj["$ref"].(string)
+		if !ok {
+			return obj
+		}
+		parts := strings.Split(strings.TrimPrefix(ref, "#/"), "/")
+		require.Len(t, parts, 2, "unsupported reference %s", ref)
+		resolved, ok := spec.object(parts[0])[parts[1]].(map[string]interface{})
+		require.True(t, ok, "unresolved reference %s", ref)
+		obj = resolved
+	}
+}
+
+// params returns the parameters of the path and of the operation
+func (spec swaggerSpec) params(t *testing.T, item, op map[string]interface{}) []map[string]interface{} {
+	var params []map[string]interface{}
+	for _, obj := range []map[string]interface{}{item, op} {
+		list, _ := obj["parameters"].([]interface{})
+		for _, param := range list {
+			params = append(params, spec.resolve(t, param.(map[string]interface{})))
+		}
+	}
+	return params
+}
+
+// checkParams checks that the variables of the path are path parameters
+func (spec swaggerSpec) checkParams(t *testing.T, name, path string, item, op map[string]interface{}) {
+	var vars, params []string
+	for _, match := range pathVarRegexp.FindAllStringSubmatch(path, -1) {
+		vars = append(vars, match[1])
+	}
+	for _, param := range spec.params(t, item, op) {
+		if param["in"] == "path" {
+			params = append(params, param["name"].(string))
+		}
+	}
+	sort.Strings(vars)
+	sort.Strings(params)
+	require.Equal(t, vars, params, "path parameters of route %s", name)
+}
+
+// checkRequest checks the schema of the body parameter against the sample
+func (spec swaggerSpec) checkRequest(t *testing.T, cdc *codec.Codec, name string, item, op map[string]interface{}, sample interface{}) {
+	var body map[string]interface{}
+	for _, param := range spec.params(t, item, op) {
+		if param["in"] == "body" {
+			body = param
+		}
+	}
+	if sample == nil {
+		require.Nil(t, body, "route %s has no body", name)
+		return
+	}
+	require.NotNil(t, body, "route %s has no body parameter", name)
+	schema, ok := body["schema"].(map[string]interface{})
+	require.True(t, ok, "body of route %s has no schema", name)
+	spec.checkSchema(t, "request of "+name, schema, marshalSample(t, cdc, sample))
+}
+
+// checkResponse checks the schema of the successful response against the sample
+func (spec swaggerSpec) checkResponse(t *testing.T, cdc *codec.Codec, name string, op map[string]interface{}, sample interface{}) {
+	responses, _ := op["responses"].(map[string]interface{})
+	res, ok := responses["200"].(map[string]interface{})
+	if !ok {
+		res, ok = responses["101"].(map[string]interface{})
+	}
+	require.True(t, ok, "route %s has no successful response", name)
+	if sample == nil {
+		require.Nil(t, res["schema"], "route %s has no sample of its response", name)
+		return
+	}
+	schema, ok := res["schema"].(map[string]interface{})
+	require.True(t, ok, "response of route %s has no schema", name)
+	spec.checkSchema(t, "response of "+name, schema, marshalSample(t, cdc, sample))
+}
+
+// checkSchema checks that the properties of the objects of the schema are the
+// fields of the objects of the JSON value, all their required properties being
+// present, and recurses into the fields and the items of the arrays. The
+// scalars must have the type and the format of their schema, except null,
+// which the nil pointers and slices of the samples are marshalled to.
+func (spec swaggerSpec) checkSchema(t *testing.T, where string, schema map[string]interface{}, value interface{}) {
+	schema = spec.resolve(t, schema)
+	switch value := value.(type) {
+	case map[string]interface{}:
+		props, ok := schema["properties"].(map[string]interface{})
+		if !ok {
+			// free-form object
+			require.Equal(t, "object", schema["type"], "%s is not an object", where)
+			return
+		}
+		for key, field := range value {
+			prop, ok := props[key].(map[string]interface{})
+			require.True(t, ok, "%s has undocumented field %s", where, key)
+			spec.checkSchema(t, where+"."+key, prop, field)
+		}
+		required, _ := schema["required"].([]interface{})
+		for _, key := range required {
+			_, ok := value[key.(string)]
+			require.True(t, ok, "%s has no required field %s", where, key)
+		}
+
+	case []interface{}:
+		require.Equal(t, "array", schema["type"], "%s is not an array", where)
+		items, ok := schema["items"].(map[string]interface{})
+		require.True(t, ok, "array %s has no items", where)
+		for i, item := range value {
+			spec.checkSchema(t, fmt.Sprintf("%s[%d]", where, i), items, item)
+		}
+
+	case string:
+		require.Equal(t, "string", schema["type"], "%s is not a string", where)
+		switch schema["format"] {
+		case "byte":
+			_, err := base64.StdEncoding.DecodeString(value)
+			require.NoError(t, err, "%s is not base64 encoded", where)
+		case "date-time":
+			_, err := time.Parse(time.RFC3339Nano, value)
+			require.NoError(t, err, "%s is not a date-time", where)
+		}
+
+	case float64:
+		switch schema["type"] {
+		case "integer":
+			require.Equal(t, math.Trunc(value), value, "%s is not an integer", where)
+		case "number":
+		default:
+			require.Fail(t, "type mismatch", "%s is not a number", where)
+		}
+
+	case bool:
+		require.Equal(t, "boolean", schema["type"], "%s is not a boolean", where)
+	}
+}
+
+// marshalSample returns the JSON value of the sample
+func marshalSample(t *testing.T, cdc *codec.Codec, sample interface{}) interface{} {
+	var bz []byte
+	var err error
+	if s, ok := sample.(stdJSON); ok {
+		bz, err = json.Marshal(s.v)
+	} else {
+		bz, err = cdc.MarshalJSON(sample)
+	}
+	require.NoError(t, err)
+
+	var value interface{}
+	require.NoError(t, json.Unmarshal(bz, &value))
+	return value
+}
+
+// normalizeYAML converts the maps decoded from YAML to maps keyed by strings,
+// the response codes being decoded as integers
+func normalizeYAML(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[fmt.Sprint(key)] = normalizeYAML(value)
+		}
+		return m
+	case []interface{}:
+		for i, value := range v {
+			v[i] = normalizeYAML(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
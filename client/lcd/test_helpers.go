@@ -186,6 +186,8 @@ func InitializeTestLCD(t *testing.T, nValidators int, initAddrs []sdk.AccAddress
 	// XXX: Need to set this so LCD knows the tendermint node address!
 	viper.Set(client.FlagNode, config.RPC.ListenAddress)
 	viper.Set(client.FlagChainID, genDoc.ChainID)
+	// the LCD serves the custom queries of the in-process node, which carry no proof
+	viper.Set(client.FlagTrustNode, true)
 
 	node, err := startTM(config, logger, genDoc, privVal, app)
 	require.NoError(t, err)
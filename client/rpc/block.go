@@ -23,6 +23,7 @@ func BlockCommand() *cobra.Command {
 	cmd.Flags().StringP(client.FlagNode, "n", "tcp://localhost:26657", "Node to connect to")
 	// TODO: change this to false when we can
 	cmd.Flags().Bool(client.FlagTrustNode, true, "Don't verify proofs for responses")
+	cmd.Flags().String(client.FlagChainID, "", "Chain ID of Tendermint node, to verify the responses with --trust-node=false")
 	return cmd
 }
 
@@ -41,6 +42,13 @@ func getBlock(cliCtx context.CLIContext, height *int64) ([]byte, error) {
 		return nil, err
 	}
 
+	if !cliCtx.TrustNode {
+		err = cliCtx.VerifyBlock(res)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// TODO move maarshalling into cmd/rest functions
 	// output, err := tmcodec.MarshalJSON(res)
 	output, err := cdc.MarshalJSON(res)
@@ -0,0 +1,448 @@
+package rpc
+
+import (
+	gocontext "context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+)
+
+// types of the events the websocket clients subscribe to
+const (
+	EventNewBlock            = "new_block"
+	EventTx                  = "tx"
+	EventValidatorSetUpdates = "validator_set_updates"
+)
+
+// name of the LCD subscribing to the events of the node
+const eventsSubscriber = "lcd"
+
+// capacity of the queues of events, the events of a full queue are dropped
+const eventsQueueSize = 100
+
+// maximum number of subscriptions of a websocket client, and of all the clients
+const (
+	maxEventsSubscriptionsPerConn = 10
+	maxEventsSubscriptions        = 1000
+)
+
+// the tags filtering the txs, key='value', the value not holding quotes
+var eventsTagRegexp = regexp.MustCompile(`^([\w.-]+)='([^']*)'$`)
+
+// EventsRequest is a request of a websocket client, to subscribe to the
+// events of a type under an ID, or to unsubscribe from the events of an ID.
+// The txs are filtered by the tags they match, given as key='value' such as
+// action='send', the value not holding quotes.
+type EventsRequest struct {
+	Action string   `json:"action"` // subscribe|unsubscribe
+	ID     string   `json:"id"`
+	Type   string   `json:"type"`
+	Tags   []string `json:"tags"`
+}
+
+// EventsResponse is an event sent to a websocket client for one of its
+// subscriptions, the data is the JSON returned by the REST endpoints: the
+// block of /blocks/{height}, the tx of /txs/{hash} and the validators of
+// /validatorsets/{height}. Errors are sent in responses without data.
+type EventsResponse struct {
+	ID    string          `json:"id"`
+	Type  string          `json:"type,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// eventsQuery returns the query of the events of a subscription request. The
+// tags are parsed and quoted again, so that they are only equality conditions
+// on the tags of the txs.
+func eventsQuery(req EventsRequest) (tmpubsub.Query, error) {
+	switch req.Type {
+	case EventNewBlock:
+		return tmtypes.EventQueryNewBlock, nil
+	case EventValidatorSetUpdates:
+		return tmtypes.EventQueryValidatorSetUpdates, nil
+	case EventTx:
+		conditions := []string{fmt.Sprintf("%s='%s'", tmtypes.EventTypeKey, tmtypes.EventTx)}
+		for _, tag := range req.Tags {
+			match := eventsTagRegexp.FindStringSubmatch(tag)
+			if match == nil {
+				return nil, fmt.Errorf("invalid tag %q, expected key='value'", tag)
+			}
+			if match[1] == tmtypes.EventTypeKey {
+				return nil, fmt.Errorf("invalid tag %q, the key %s is reserved", tag, tmtypes.EventTypeKey)
+			}
+			conditions = append(conditions, fmt.Sprintf("%s='%s'", match[1], match[2]))
+		}
+		return tmquery.New(strings.Join(conditions, " AND "))
+	default:
+		return nil, fmt.Errorf("unknown event type %q", req.Type)
+	}
+}
+
+// formatEvent returns the event as the JSON returned by the REST endpoints
+func formatEvent(cliCtx context.CLIContext, event interface{}) (string, []byte, error) {
+	switch data := event.(type) {
+	case tmtypes.EventDataNewBlock:
+		height := data.Block.Height
+		output, err := getBlock(cliCtx, &height)
+		return EventNewBlock, output, err
+
+	case tmtypes.EventDataTx:
+		res := &ctypes.ResultTx{
+			Hash:     data.Tx.Hash(),
+			Height:   data.Height,
+			Index:    data.Index,
+			TxResult: data.Result,
+			Tx:       data.Tx,
+		}
+		if !cliCtx.TrustNode {
+			err := verifyTxEvent(cliCtx, res)
+			if err != nil {
+				return EventTx, nil, err
+			}
+		}
+		infos, err := tx.FormatTxResults(cliCtx.Codec, []*ctypes.ResultTx{res})
+		if err != nil {
+			return EventTx, nil, err
+		}
+		output, err := cliCtx.Codec.MarshalJSON(infos[0])
+		return EventTx, output, err
+
+	case tmtypes.EventDataValidatorSetUpdates:
+		validators := make([]ValidatorOutput, len(data.ValidatorUpdates))
+		for i, validator := range data.ValidatorUpdates {
+			var err error
+			validators[i], err = bech32ValidatorOutput(validator)
+			if err != nil {
+				return EventValidatorSetUpdates, nil, err
+			}
+		}
+		output, err := cdc.MarshalJSON(validators)
+		return EventValidatorSetUpdates, output, err
+
+	default:
+		return "", nil, fmt.Errorf("unknown event %T", event)
+	}
+}
+
+// verifyTxEvent verifies the tx of an event with the proof of its inclusion
+// in its block, as the events don't carry proofs
+func verifyTxEvent(cliCtx context.CLIContext, res *ctypes.ResultTx) error {
+	node, err := cliCtx.GetNode()
+	if err != nil {
+		return err
+	}
+	block, err := node.Block(&res.Height)
+	if err != nil {
+		return err
+	}
+	if int(res.Index) >= len(block.Block.Data.Txs) {
+		return fmt.Errorf("tx %d not found in block %d", res.Index, res.Height)
+	}
+	res.Proof = block.Block.Data.Txs.Proof(int(res.Index))
+	return cliCtx.VerifyTx(res)
+}
+
+// eventHub subscribes once to the events of each query of the websocket
+// clients, as the node serves each query once per connection, and forwards
+// the events to the subscriptions of the clients.
+type eventHub struct {
+	cliCtx context.CLIContext
+
+	mtx     sync.Mutex
+	queries map[string]*eventQuery
+	count   int // number of subscriptions of all the clients
+}
+
+// eventQuery is a query the hub subscribed to, with the subscriptions of the
+// clients to its events. Closing quit stops forwarding its events.
+type eventQuery struct {
+	subs map[*eventSubscription]struct{}
+	quit chan struct{}
+}
+
+// eventSubscription is a subscription of a websocket client
+type eventSubscription struct {
+	id    string
+	query tmpubsub.Query
+	conn  *eventConn
+}
+
+func newEventHub(cliCtx context.CLIContext) *eventHub {
+	return &eventHub{
+		cliCtx:  cliCtx,
+		queries: make(map[string]*eventQuery),
+	}
+}
+
+func (hub *eventHub) subscribe(sub *eventSubscription) error {
+	hub.mtx.Lock()
+	defer hub.mtx.Unlock()
+
+	if hub.count >= maxEventsSubscriptions {
+		return fmt.Errorf("too many subscriptions, the limit is %d", maxEventsSubscriptions)
+	}
+	q := sub.query.String()
+	if query, ok := hub.queries[q]; ok {
+		query.subs[sub] = struct{}{}
+		hub.count++
+		return nil
+	}
+
+	node, err := hub.cliCtx.GetNode()
+	if err != nil {
+		return err
+	}
+	if !node.IsRunning() {
+		if err = node.Start(); err != nil {
+			return err
+		}
+	}
+	out := make(chan interface{}, eventsQueueSize)
+	if err = node.Subscribe(gocontext.Background(), eventsSubscriber, sub.query, out); err != nil {
+		return err
+	}
+	query := &eventQuery{
+		subs: map[*eventSubscription]struct{}{sub: {}},
+		quit: make(chan struct{}),
+	}
+	hub.queries[q] = query
+	hub.count++
+	go hub.forward(q, query, out)
+	return nil
+}
+
+func (hub *eventHub) unsubscribe(sub *eventSubscription) error {
+	hub.mtx.Lock()
+	defer hub.mtx.Unlock()
+
+	q := sub.query.String()
+	query, ok := hub.queries[q]
+	if !ok {
+		return nil
+	}
+	if _, ok = query.subs[sub]; !ok {
+		return nil
+	}
+	delete(query.subs, sub)
+	hub.count--
+	if len(query.subs) > 0 {
+		return nil
+	}
+
+	// the node doesn't close the channel of the events once unsubscribed
+	delete(hub.queries, q)
+	close(query.quit)
+
+	node, err := hub.cliCtx.GetNode()
+	if err != nil {
+		return err
+	}
+	return node.Unsubscribe(gocontext.Background(), eventsSubscriber, sub.query)
+}
+
+// forward formats the events of the query and sends them to its subscriptions
+// until the hub unsubscribes from the query, or the node closes the channel of
+// its events
+func (hub *eventHub) forward(q string, query *eventQuery, out <-chan interface{}) {
+	for {
+		var event interface{}
+		var ok bool
+		select {
+		case event, ok = <-out:
+		case <-query.quit:
+			return
+		}
+		if !ok {
+			hub.drop(q, query)
+			return
+		}
+
+		typ, data, err := formatEvent(hub.cliCtx, event)
+		hub.mtx.Lock()
+		for sub := range query.subs {
+			res := EventsResponse{ID: sub.id, Type: typ, Data: data}
+			if err != nil {
+				res = EventsResponse{ID: sub.id, Type: typ, Error: err.Error()}
+			}
+			sub.conn.send(res)
+		}
+		hub.mtx.Unlock()
+	}
+}
+
+// drop removes a query whose events the node stopped sending, notifying its
+// subscriptions, which the clients have to subscribe again
+func (hub *eventHub) drop(q string, query *eventQuery) {
+	hub.mtx.Lock()
+	defer hub.mtx.Unlock()
+
+	if hub.queries[q] == query {
+		delete(hub.queries, q)
+	}
+	for sub := range query.subs {
+		sub.conn.mtx.Lock()
+		if sub.conn.subs[sub.id] == sub {
+			delete(sub.conn.subs, sub.id)
+		}
+		sub.conn.mtx.Unlock()
+		sub.conn.send(EventsResponse{ID: sub.id, Error: "the node closed the subscription"})
+		hub.count--
+	}
+	query.subs = nil
+}
+
+// eventConn is the websocket connection of a client
+type eventConn struct {
+	ws    *websocket.Conn
+	queue chan EventsResponse
+
+	mtx  sync.Mutex // locked after the mutex of the hub
+	subs map[string]*eventSubscription
+}
+
+// send queues the response, and drops it if the queue of the client is full
+func (conn *eventConn) send(res EventsResponse) {
+	select {
+	case conn.queue <- res:
+	default:
+	}
+}
+
+func (conn *eventConn) writeLoop() {
+	for res := range conn.queue {
+		if err := conn.ws.WriteJSON(res); err != nil {
+			return
+		}
+	}
+}
+
+// subscriptions returns the subscriptions of the client
+func (conn *eventConn) subscriptions() []*eventSubscription {
+	conn.mtx.Lock()
+	defer conn.mtx.Unlock()
+
+	subs := make([]*eventSubscription, 0, len(conn.subs))
+	for _, sub := range conn.subs {
+		subs = append(subs, sub)
+	}
+	return subs
+}
+
+// add records a new subscription of the client
+func (conn *eventConn) add(sub *eventSubscription) error {
+	conn.mtx.Lock()
+	defer conn.mtx.Unlock()
+
+	if _, ok := conn.subs[sub.id]; ok {
+		return fmt.Errorf("subscription %q already exists", sub.id)
+	}
+	if len(conn.subs) >= maxEventsSubscriptionsPerConn {
+		return fmt.Errorf("too many subscriptions, the limit is %d per connection", maxEventsSubscriptionsPerConn)
+	}
+	conn.subs[sub.id] = sub
+	return nil
+}
+
+// remove removes a subscription of the client, returning it if it exists
+func (conn *eventConn) remove(id string) (*eventSubscription, bool) {
+	conn.mtx.Lock()
+	defer conn.mtx.Unlock()
+
+	sub, ok := conn.subs[id]
+	delete(conn.subs, id)
+	return sub, ok
+}
+
+func (conn *eventConn) handle(hub *eventHub, req EventsRequest) error {
+	switch req.Action {
+	case "subscribe":
+		// the updates of the validator set aren't part of the headers
+		if req.Type == EventValidatorSetUpdates && !hub.cliCtx.TrustNode {
+			return fmt.Errorf("%s events can't be verified without trusting the node", req.Type)
+		}
+		query, err := eventsQuery(req)
+		if err != nil {
+			return err
+		}
+		sub := &eventSubscription{id: req.ID, query: query, conn: conn}
+		if err = conn.add(sub); err != nil {
+			return err
+		}
+		if err = hub.subscribe(sub); err != nil {
+			conn.remove(req.ID)
+			return err
+		}
+		return nil
+
+	case "unsubscribe":
+		sub, ok := conn.remove(req.ID)
+		if !ok {
+			return fmt.Errorf("subscription %q does not exist", req.ID)
+		}
+		return hub.unsubscribe(sub)
+
+	default:
+		return fmt.Errorf("unknown action %q", req.Action)
+	}
+}
+
+// the events are public, so they are served to the pages of any origin
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// REST handler upgrading to a websocket connection, on which the client
+// subscribes to the events of the node
+func EventsRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	hub := newEventHub(cliCtx)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			// the upgrader already replied with an error
+			return
+		}
+		conn := &eventConn{
+			ws:    ws,
+			queue: make(chan EventsResponse, eventsQueueSize),
+			subs:  make(map[string]*eventSubscription),
+		}
+		go conn.writeLoop()
+
+		defer func() {
+			for _, sub := range conn.subscriptions() {
+				hub.unsubscribe(sub) // nolint: errcheck
+			}
+			// stop the writes once the subscriptions stopped sending
+			hub.mtx.Lock()
+			close(conn.queue)
+			hub.mtx.Unlock()
+			ws.Close()
+		}()
+
+		for {
+			_, bz, err := ws.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req EventsRequest
+			if err = json.Unmarshal(bz, &req); err == nil {
+				err = conn.handle(hub, req)
+			}
+			if err != nil {
+				conn.send(EventsResponse{ID: req.ID, Error: err.Error()})
+			}
+		}
+	}
+}
@@ -27,6 +27,7 @@ func ValidatorCommand() *cobra.Command {
 	cmd.Flags().StringP(client.FlagNode, "n", "tcp://localhost:26657", "Node to connect to")
 	// TODO: change this to false when we can
 	cmd.Flags().Bool(client.FlagTrustNode, true, "Don't verify proofs for responses")
+	cmd.Flags().String(client.FlagChainID, "", "Chain ID of Tendermint node, to verify the responses with --trust-node=false")
 	return cmd
 }
 
@@ -70,6 +71,13 @@ func getValidators(cliCtx context.CLIContext, height *int64) ([]byte, error) {
 		return nil, err
 	}
 
+	if !cliCtx.TrustNode {
+		err = cliCtx.VerifyValidators(validatorsRes.BlockHeight, validatorsRes.Validators)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	outputValidatorsRes := ResultValidatorsOutput{
 		BlockHeight: validatorsRes.BlockHeight,
 		Validators:  make([]ValidatorOutput, len(validatorsRes.Validators)),
@@ -48,6 +48,7 @@ func QueryTxCmd(cdc *codec.Codec) *cobra.Command {
 
 	// TODO: change this to false when we can
 	cmd.Flags().Bool(client.FlagTrustNode, true, "Don't verify proofs for responses")
+	cmd.Flags().String(client.FlagChainID, "", "Chain ID of Tendermint node, to verify the responses with --trust-node=false")
 	return cmd
 }
 
@@ -67,6 +68,13 @@ func queryTx(cdc *codec.Codec, cliCtx context.CLIContext, hashHexStr string, tru
 		return nil, err
 	}
 
+	if !trustNode {
+		err = cliCtx.VerifyTx(res)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	info, err := formatTxResult(cdc, res)
 	if err != nil {
 		return nil, err
@@ -76,7 +84,6 @@ func queryTx(cdc *codec.Codec, cliCtx context.CLIContext, hashHexStr string, tru
 }
 
 func formatTxResult(cdc *codec.Codec, res *ctypes.ResultTx) (Info, error) {
-	// TODO: verify the proof if requested
 	tx, err := parseTx(cdc, res.Tx)
 	if err != nil {
 		return Info{}, err
@@ -116,10 +123,11 @@ func QueryTxRequestHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.H
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 		hashHexStr := vars["hash"]
-		trustNode, err := strconv.ParseBool(r.FormValue("trust_node"))
-		// trustNode defaults to true
-		if err != nil {
-			trustNode = true
+		// clients can ask for the verification of the tx, but can't skip it
+		// when the LCD doesn't trust its node
+		trustNode := cliCtx.TrustNode
+		if trust, err := strconv.ParseBool(r.FormValue("trust_node")); err == nil {
+			trustNode = trustNode && trust
 		}
 
 		output, err := queryTx(cdc, cliCtx, hashHexStr, trustNode)
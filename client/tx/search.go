@@ -65,6 +65,7 @@ $ gaiacli tendermint txs --tag test1,test2 --any
 
 	// TODO: change this to false once proofs built in
 	cmd.Flags().Bool(client.FlagTrustNode, true, "Don't verify proofs for responses")
+	cmd.Flags().String(client.FlagChainID, "", "Chain ID of Tendermint node, to verify the responses with --trust-node=false")
 	cmd.Flags().StringSlice(flagTags, nil, "Comma-separated list of tags that must match")
 	cmd.Flags().Bool(flagAny, false, "Return transactions that match ANY tag, rather than ALL")
 	return cmd
@@ -84,7 +85,7 @@ func searchTxs(cliCtx context.CLIContext, cdc *codec.Codec, tags []string) ([]In
 		return nil, err
 	}
 
-	prove := !viper.GetBool(client.FlagTrustNode)
+	prove := !cliCtx.TrustNode
 
 	// TODO: take these as args
 	page := 0
@@ -94,6 +95,15 @@ func searchTxs(cliCtx context.CLIContext, cdc *codec.Codec, tags []string) ([]In
 		return nil, err
 	}
 
+	if prove {
+		for _, tx := range res.Txs {
+			err = cliCtx.VerifyTx(tx)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	info, err := FormatTxResults(cdc, res.Txs)
 	if err != nil {
 		return nil, err
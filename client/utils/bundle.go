@@ -0,0 +1,247 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	crkeys "github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	amino "github.com/tendermint/go-amino"
+)
+
+// TxBundle is a self-contained unsigned transaction: it holds the chain ID
+// and the account number and sequence of each signer of the transaction, so
+// that it can be signed offline.
+type TxBundle struct {
+	ChainID string         `json:"chain_id"`
+	Tx      auth.StdTx     `json:"tx"`
+	Signers []BundleSigner `json:"signers"`
+}
+
+// BundleSigner is a signer of the transaction of a TxBundle, in the order of
+// the signatures of the transaction.
+type BundleSigner struct {
+	Address       sdk.AccAddress `json:"address"`
+	AccountNumber int64          `json:"account_number"`
+	Sequence      int64          `json:"sequence"`
+}
+
+// PrepareTxBundle queries the account number and sequence of the signers of
+// the transaction and returns them in a bundle with the chain ID.
+func PrepareTxBundle(cliCtx context.CLIContext, chainID string, stdTx auth.StdTx) (TxBundle, error) {
+	if chainID == "" {
+		return TxBundle{}, errors.New("chain ID required but not specified")
+	}
+	signers := stdTx.GetSigners()
+	if len(signers) == 0 {
+		return TxBundle{}, errors.New("the transaction has no signer")
+	}
+
+	bundle := TxBundle{ChainID: chainID, Tx: stdTx}
+	for _, addr := range signers {
+		accNum, err := cliCtx.GetAccountNumber(addr)
+		if err != nil {
+			return TxBundle{}, err
+		}
+		accSeq, err := cliCtx.GetAccountSequence(addr)
+		if err != nil {
+			return TxBundle{}, err
+		}
+		bundle.Signers = append(bundle.Signers, BundleSigner{
+			Address:       addr,
+			AccountNumber: accNum,
+			Sequence:      accSeq,
+		})
+	}
+	return bundle, nil
+}
+
+// TxSummary describes the transaction signed for the given chain in a human
+// readable form
+func TxSummary(chainID string, stdTx auth.StdTx) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Chain ID: %s\n", chainID)
+	fmt.Fprintf(&sb, "Fee: %s, gas: %d\n", stdTx.Fee.Amount, stdTx.Fee.Gas)
+	if stdTx.Memo != "" {
+		fmt.Fprintf(&sb, "Memo: %s\n", stdTx.Memo)
+	}
+	fmt.Fprintf(&sb, "Messages:\n")
+	for i, msg := range stdTx.GetMsgs() {
+		fmt.Fprintf(&sb, " %d: %s %s\n", i, msg.Type(), msg.GetSignBytes())
+	}
+	return sb.String()
+}
+
+// Summary describes the transaction of the bundle and its signers in a human
+// readable form. It is built from the content of the bundle, which is what the
+// signers sign.
+func (bundle TxBundle) Summary() string {
+	var sb strings.Builder
+	sb.WriteString(TxSummary(bundle.ChainID, bundle.Tx))
+	fmt.Fprintf(&sb, "Signers:\n")
+	for i, signer := range bundle.Signers {
+		fmt.Fprintf(&sb, " %d: %s (account number %d, sequence %d)\n",
+			i, signer.Address, signer.AccountNumber, signer.Sequence)
+	}
+	return sb.String()
+}
+
+// SignBundleOffline signs the transaction of the bundle with the key of the
+// given name, without querying a node, and returns a copy of the bundle with
+// the signature appended. The signers must sign in their order in the bundle.
+// The summary of the bundle is printed to the standard error before asking
+// for the passphrase.
+func SignBundleOffline(name string, bundle TxBundle) (TxBundle, error) {
+	keybase, err := keys.GetKeyBase()
+	if err != nil {
+		return bundle, err
+	}
+	fmt.Fprintf(os.Stderr, "%s\n", bundle.Summary())
+	passphrase, err := keys.GetPassphrase(name)
+	if err != nil {
+		return bundle, err
+	}
+	return signTxBundle(keybase, name, passphrase, bundle)
+}
+
+func signTxBundle(keybase crkeys.Keybase, name, passphrase string, bundle TxBundle) (TxBundle, error) {
+	info, err := keybase.Get(name)
+	if err != nil {
+		return bundle, err
+	}
+	addr := sdk.AccAddress(info.GetPubKey().Address())
+
+	sigs := bundle.Tx.GetSignatures()
+	idx := bundle.signerIndex(addr)
+	switch {
+	case idx < 0:
+		return bundle, fmt.Errorf("key %s is not a signer of the transaction", name)
+	case idx < len(sigs):
+		return bundle, fmt.Errorf("key %s already signed the transaction", name)
+	case idx > len(sigs):
+		return bundle, fmt.Errorf("signer %d must sign the transaction before key %s",
+			len(sigs), name)
+	}
+
+	signer := bundle.Signers[idx]
+	sigBytes, pubkey, err := keybase.Sign(name, passphrase, bundle.signBytes(signer))
+	if err != nil {
+		return bundle, err
+	}
+
+	sig := auth.StdSignature{
+		AccountNumber: signer.AccountNumber,
+		Sequence:      signer.Sequence,
+		PubKey:        pubkey,
+		Signature:     sigBytes,
+	}
+	newSigs := append(append([]auth.StdSignature{}, sigs...), sig)
+	bundle.Tx = auth.NewStdTx(bundle.Tx.GetMsgs(), bundle.Tx.Fee, newSigs, bundle.Tx.GetMemo())
+	return bundle, nil
+}
+
+// ValidateSigned checks that the transaction of the bundle is valid and signed
+// by all its signers, with the account numbers and sequences of the bundle.
+func (bundle TxBundle) ValidateSigned() error {
+	if len(bundle.Tx.GetMsgs()) == 0 {
+		return errors.New("the transaction has no message")
+	}
+	for _, msg := range bundle.Tx.GetMsgs() {
+		if err := msg.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+
+	signers := bundle.Tx.GetSigners()
+	if len(signers) != len(bundle.Signers) {
+		return fmt.Errorf("the bundle has %d signers, the transaction %d", len(bundle.Signers), len(signers))
+	}
+	sigs := bundle.Tx.GetSignatures()
+	if len(sigs) != len(signers) {
+		return fmt.Errorf("the transaction has %d signatures, %d are required", len(sigs), len(signers))
+	}
+
+	for i, sig := range sigs {
+		signer := bundle.Signers[i]
+		if !bytes.Equal(signer.Address, signers[i]) {
+			return fmt.Errorf("signer %d of the bundle is not signer %d of the transaction", i, i)
+		}
+		if sig.PubKey == nil || !bytes.Equal(sig.PubKey.Address(), signers[i]) {
+			return fmt.Errorf("signature %d is not from signer %s", i, signers[i])
+		}
+		if sig.AccountNumber != signer.AccountNumber || sig.Sequence != signer.Sequence {
+			return fmt.Errorf("signature %d does not sign the account number and sequence of the bundle", i)
+		}
+		if !sig.PubKey.VerifyBytes(bundle.signBytes(signer), sig.Signature) {
+			return fmt.Errorf("invalid signature %d from signer %s", i, signers[i])
+		}
+	}
+	return nil
+}
+
+// ValidateAgainstNode checks that the bundle is for the chain of the node, and
+// that the sequences of its signers are the current ones.
+func (bundle TxBundle) ValidateAgainstNode(cliCtx context.CLIContext) error {
+	node, err := cliCtx.GetNode()
+	if err != nil {
+		return err
+	}
+	status, err := node.Status()
+	if err != nil {
+		return err
+	}
+	if status.NodeInfo.Network != bundle.ChainID {
+		return fmt.Errorf("the bundle is for chain %s, the node is on chain %s",
+			bundle.ChainID, status.NodeInfo.Network)
+	}
+
+	for _, signer := range bundle.Signers {
+		accSeq, err := cliCtx.GetAccountSequence(signer.Address)
+		if err != nil {
+			return err
+		}
+		if accSeq != signer.Sequence {
+			return fmt.Errorf("the sequence of signer %s is %d, the bundle signs %d",
+				signer.Address, accSeq, signer.Sequence)
+		}
+	}
+	return nil
+}
+
+// signBytes returns the bytes the signer signs
+func (bundle TxBundle) signBytes(signer BundleSigner) []byte {
+	return auth.StdSignBytes(bundle.ChainID, signer.AccountNumber, signer.Sequence,
+		bundle.Tx.Fee, bundle.Tx.GetMsgs(), bundle.Tx.GetMemo())
+}
+
+func (bundle TxBundle) signerIndex(addr sdk.AccAddress) int {
+	for i, signer := range bundle.Signers {
+		if bytes.Equal(signer.Address, addr) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ReadTxBundle reads a TxBundle from the JSON file, or from the standard input
+// if the filename is a dash (-).
+func ReadTxBundle(cdc *amino.Codec, filename string) (bundle TxBundle, err error) {
+	var bz []byte
+	if filename == "-" {
+		bz, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		bz, err = ioutil.ReadFile(filename)
+	}
+	if err != nil {
+		return
+	}
+	err = cdc.UnmarshalJSON(bz, &bundle)
+	return
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/cmd/gaia/app"
+	crkeys "github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+func TestSignTxBundle(t *testing.T) {
+	crkeys.BcryptSecurityParameter = 1
+	cdc := app.MakeCodec()
+	kb := crkeys.New(dbm.NewMemDB())
+	info1, _, err := kb.CreateMnemonic("alice", crkeys.English, "1234567890", crkeys.Secp256k1)
+	require.NoError(t, err)
+	info2, _, err := kb.CreateMnemonic("bob", crkeys.English, "1234567890", crkeys.Secp256k1)
+	require.NoError(t, err)
+	_, _, err = kb.CreateMnemonic("eve", crkeys.English, "1234567890", crkeys.Secp256k1)
+	require.NoError(t, err)
+	addr1 := sdk.AccAddress(info1.GetPubKey().Address())
+	addr2 := sdk.AccAddress(info2.GetPubKey().Address())
+
+	coins := sdk.Coins{sdk.NewInt64Coin("steak", 10)}
+	msg := bank.NewMsgSend(
+		[]bank.Input{bank.NewInput(addr1, coins), bank.NewInput(addr2, coins)},
+		[]bank.Output{bank.NewOutput(addr1, coins.Plus(coins))})
+	stdTx := auth.NewStdTx([]sdk.Msg{msg}, auth.NewStdFee(200000), nil, "memo")
+	bundle := TxBundle{
+		ChainID: "test-chain",
+		Tx:      stdTx,
+		Signers: []BundleSigner{{addr1, 3, 7}, {addr2, 5, 0}},
+	}
+	require.Contains(t, bundle.Summary(), "test-chain")
+	require.Contains(t, bundle.Summary(), addr2.String())
+	require.Contains(t, bundle.Summary(), "memo")
+
+	// the bundle round trips through its JSON encoding
+	bz, err := cdc.MarshalJSON(bundle)
+	require.NoError(t, err)
+	var decoded TxBundle
+	require.NoError(t, cdc.UnmarshalJSON(bz, &decoded))
+	require.Equal(t, bundle.Signers, decoded.Signers)
+	bundle = decoded
+
+	// the signers sign in order, once
+	_, err = signTxBundle(kb, "bob", "1234567890", bundle)
+	require.Error(t, err)
+	_, err = signTxBundle(kb, "eve", "1234567890", bundle)
+	require.Error(t, err)
+	_, err = signTxBundle(kb, "alice", "wrong", bundle)
+	require.Error(t, err)
+	signed, err := signTxBundle(kb, "alice", "1234567890", bundle)
+	require.NoError(t, err)
+	require.Empty(t, bundle.Tx.GetSignatures())
+	require.Error(t, signed.ValidateSigned())
+	_, err = signTxBundle(kb, "alice", "1234567890", signed)
+	require.Error(t, err)
+	signed, err = signTxBundle(kb, "bob", "1234567890", signed)
+	require.NoError(t, err)
+	require.NoError(t, signed.ValidateSigned())
+
+	sigs := signed.Tx.GetSignatures()
+	require.Equal(t, int64(3), sigs[0].AccountNumber)
+	require.Equal(t, int64(7), sigs[0].Sequence)
+
+	// a signature over another chain ID is invalid
+	tampered := signed
+	tampered.ChainID = "other-chain"
+	require.Error(t, tampered.ValidateSigned())
+
+	// the signatures must match the signers of the bundle
+	tampered = signed
+	tampered.Signers = []BundleSigner{signed.Signers[0], {addr2, 5, 1}}
+	require.Error(t, tampered.ValidateSigned())
+
+	tampered = signed
+	tampered.Tx = auth.NewStdTx(signed.Tx.GetMsgs(), signed.Tx.Fee,
+		[]auth.StdSignature{sigs[1], sigs[0]}, signed.Tx.GetMemo())
+	require.Error(t, tampered.ValidateSigned())
+}
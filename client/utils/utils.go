@@ -126,6 +126,7 @@ func SignStdTx(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, name string,
 		txBldr = txBldr.WithSequence(accSeq)
 	}
 
+	fmt.Fprintf(os.Stderr, "%s\n", TxSummary(txBldr.ChainID, stdTx))
 	passphrase, err := keys.GetPassphrase(name)
 	if err != nil {
 		return signedStdTx, err
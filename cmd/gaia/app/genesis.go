@@ -12,6 +12,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 	stakeTypes "github.com/cosmos/cosmos-sdk/x/stake/types"
 
@@ -35,6 +36,7 @@ type GenesisState struct {
 	Accounts  []GenesisAccount   `json:"accounts"`
 	StakeData stake.GenesisState `json:"stake"`
 	GovData   gov.GenesisState   `json:"gov"`
+	IBCData   ibc.GenesisState   `json:"ibc"`
 }
 
 // GenesisAccount doesn't need pubkey or sequence
@@ -195,6 +197,7 @@ func GaiaAppGenState(cdc *codec.Codec, appGenTxs []json.RawMessage) (genesisStat
 		Accounts:  genaccs,
 		StakeData: stakeData,
 		GovData:   gov.DefaultGenesisState(),
+		IBCData:   ibc.DefaultGenesisState(),
 	}
 	return
 }
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 
 	"github.com/tendermint/tendermint/libs/cli"
@@ -61,6 +63,10 @@ func main() {
 			ibccmd.IBCTransferCmd(cdc),
 			ibccmd.IBCRelayCmd(cdc),
 		)...)
+	ibcCmd.AddCommand(
+		client.GetCommands(
+			ibccmd.GetCmdQueryDenomTrace("ibc", cdc),
+		)...)
 
 	rootCmd.AddCommand(
 		tendermintCmd,
@@ -86,15 +92,22 @@ func main() {
 			stakecmd.GetCmdQueryUnbondingDelegations("stake", cdc),
 			stakecmd.GetCmdQueryRedelegation("stake", cdc),
 			stakecmd.GetCmdQueryRedelegations("stake", cdc),
+			stakecmd.GetCmdQueryHistoricalInfo("stake", cdc),
 			slashingcmd.GetCmdQuerySigningInfo("slashing", cdc),
+			slashingcmd.GetCmdQueryMissedBlocks("slashing", cdc),
+			slashingcmd.GetCmdQuerySlashes("slashing", cdc),
 		)...)
 	stakeCmd.AddCommand(
 		client.PostCommands(
 			stakecmd.GetCmdCreateValidator(cdc),
 			stakecmd.GetCmdEditValidator(cdc),
+			stakecmd.GetCmdRotateConsPubKey(cdc),
 			stakecmd.GetCmdDelegate(cdc),
+			stakecmd.GetCmdSetAutoRestake(cdc),
 			stakecmd.GetCmdUnbond("stake", cdc),
 			stakecmd.GetCmdRedelegate("stake", cdc),
+			stakecmd.GetCmdTokenizeShares(cdc),
+			stakecmd.GetCmdRedeemTokens(cdc),
 			slashingcmd.GetCmdUnjail(cdc),
 		)...)
 	rootCmd.AddCommand(
@@ -127,6 +140,8 @@ func main() {
 	rootCmd.AddCommand(
 		client.GetCommands(
 			authcmd.GetAccountCmd("acc", cdc, authcmd.GetAccountDecoder(cdc)),
+			authcmd.GetAccountsCmd("acc", cdc, authcmd.GetAccountDecoder(cdc)),
+			authcmd.GetPrepareCommand(cdc, authcmd.GetAccountDecoder(cdc)),
 			authcmd.GetSignCommand(cdc, authcmd.GetAccountDecoder(cdc)),
 		)...)
 	rootCmd.AddCommand(
@@ -142,6 +157,12 @@ func main() {
 		version.VersionCmd,
 	)
 
+	// select the keybase of the commands signing with a key
+	rootCmd.PersistentFlags().String(client.FlagKeyringBackend, keys.BackendDB,
+		fmt.Sprintf("Keyring backend to use: %q, %q, %q or %q", keys.BackendDB, keys.BackendFile, keys.BackendTest, keys.BackendRemote))
+	rootCmd.PersistentFlags().String(client.FlagRemoteSignerAddr, keys.DefaultRemoteSignerAddr,
+		"Address of the remote signer of the remote keyring backend, unix://<path> or tcp://<signer-id>@<host>:<port> (default unix://<home>/signer/signer.sock)")
+
 	// prepare and add flags
 	executor := cli.PrepareMainCmd(rootCmd, "GA", app.DefaultCLIHome)
 	err := executor.Execute()
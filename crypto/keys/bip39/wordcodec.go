@@ -1,9 +1,13 @@
 package bip39
 
 import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/bartekn/go-bip39"
+	"golang.org/x/text/unicode/norm"
 )
 
 // ValidSentenceLen defines the mnemonic sentence lengths supported by this BIP 39 library.
@@ -20,9 +24,28 @@ const (
 	FreshKeyChecksumSize = 8
 )
 
+// number of bits of entropy encoded by a word
+const bitsPerWord = 11
+
+var (
+	// ErrInvalidChecksum is returned when the checksum of a mnemonic does not
+	// match its entropy.
+	ErrInvalidChecksum = errors.New("invalid mnemonic checksum")
+
+	// ErrUnknownLanguage is returned when the words of a mnemonic are not all
+	// in any of the word lists.
+	ErrUnknownLanguage = errors.New("mnemonic words are not in any BIP 39 word list")
+)
+
 // NewMnemonic will return a string consisting of the mnemonic words for
 // the given sentence length.
 func NewMnemonic(len ValidSentenceLen) (words []string, err error) {
+	return NewMnemonicWithWordList(len, English)
+}
+
+// NewMnemonicWithWordList returns the words of a new mnemonic of the given
+// sentence length in the language of the word list.
+func NewMnemonicWithWordList(len ValidSentenceLen, wl *WordList) (words []string, err error) {
 	// len = (entropySize + checksum) / 11
 	var entropySize int
 	switch len {
@@ -38,29 +61,102 @@ func NewMnemonic(len ValidSentenceLen) (words []string, err error) {
 	if err != nil {
 		return
 	}
-	var mnemonic string
-	mnemonic, err = bip39.NewMnemonic(entropy)
-	if err != nil {
-		return
-	}
-	words = strings.Split(mnemonic, " ")
+	words = entropyToWords(entropy, wl)
 	return
 }
 
+// SplitMnemonic returns the words of a mnemonic, normalized to the NFKD form
+// of the word lists.
+func SplitMnemonic(mne string) []string {
+	return strings.Fields(norm.NFKD.String(mne))
+}
+
+// MnemonicToEntropy returns the entropy encoded by the mnemonic in the language
+// of the word list, after validating its checksum.
+func MnemonicToEntropy(mne string, wl *WordList) ([]byte, error) {
+	words := SplitMnemonic(mne)
+	if len(words) < 12 || len(words)%3 != 0 || len(words) > 24 {
+		return nil, fmt.Errorf("invalid number of words in the mnemonic: %d", len(words))
+	}
+
+	// the words encode the entropy followed by its checksum of one bit every
+	// 32 bits of entropy
+	data := make([]byte, (len(words)*bitsPerWord+7)/8)
+	for i, word := range words {
+		idx, ok := wl.GetWordIndex(word)
+		if !ok {
+			return nil, fmt.Errorf("word %q is not in the %s word list", word, wl.Name())
+		}
+		for j := 0; j < bitsPerWord; j++ {
+			if idx&(1<<uint(bitsPerWord-1-j)) != 0 {
+				bit := i*bitsPerWord + j
+				data[bit/8] |= 1 << uint(7-bit%8)
+			}
+		}
+	}
+
+	// the checksum bits all fall in the last word
+	checksumBits := len(words) * bitsPerWord / 33
+	entropy := data[:checksumBits*4]
+	last := len(words) - 1
+	if entropyToWords(entropy, wl)[last] != words[last] {
+		return nil, ErrInvalidChecksum
+	}
+	return entropy, nil
+}
+
+// DetectWordList returns the word list of the language of a mnemonic. The
+// mnemonic must be valid in the language, so its checksum is validated.
+func DetectWordList(mne string) (*WordList, error) {
+	err := ErrUnknownLanguage
+	for _, wl := range WordLists {
+		_, wlErr := MnemonicToEntropy(mne, wl)
+		if wlErr == nil {
+			return wl, nil
+		}
+		// the words of the mnemonic are in the list but not its checksum
+		if wlErr == ErrInvalidChecksum {
+			err = wlErr
+		}
+	}
+	return nil, err
+}
+
 // MnemonicToSeed creates a BIP 39 seed from the passed mnemonic (with an empty BIP 39 password).
 // This method does not validate the mnemonics checksum.
 func MnemonicToSeed(mne string) (seed []byte) {
 	// we do not checksum here...
-	seed = bip39.NewSeed(mne, "")
+	seed = bip39.NewSeed(norm.NFKD.String(mne), "")
 	return
 }
 
 // MnemonicToSeedWithErrChecking returns the same seed as MnemonicToSeed.
 // It creates a BIP 39 seed from the passed mnemonic (with an empty BIP 39 password).
 //
-// Different from MnemonicToSeed it validates the checksum.
-// For details on the checksum see the BIP 39 spec.
+// Different from MnemonicToSeed it validates the checksum, detecting the
+// language of the mnemonic. For details on the checksum see the BIP 39 spec.
 func MnemonicToSeedWithErrChecking(mne string) (seed []byte, err error) {
-	seed, err = bip39.NewSeedWithErrorChecking(mne, "")
+	if _, err = DetectWordList(mne); err != nil {
+		return
+	}
+	seed = MnemonicToSeed(mne)
 	return
 }
+
+// entropyToWords encodes the entropy followed by its checksum with the words
+// of the list, each word encoding 11 bits.
+func entropyToWords(entropy []byte, wl *WordList) []string {
+	checksum := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), checksum[0])
+
+	words := make([]string, (len(entropy)*8+len(entropy)/4)/bitsPerWord)
+	for i := range words {
+		idx := 0
+		for j := 0; j < bitsPerWord; j++ {
+			bit := i*bitsPerWord + j
+			idx = idx<<1 | int(data[bit/8]>>uint(7-bit%8)&1)
+		}
+		words[i] = wl.words[idx]
+	}
+	return words
+}
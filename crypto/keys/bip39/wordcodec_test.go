@@ -1,9 +1,14 @@
 package bip39
 
 import (
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/text/unicode/norm"
 )
 
 func TestWordCodec_NewMnemonic(t *testing.T) {
@@ -13,3 +18,113 @@ func TestWordCodec_NewMnemonic(t *testing.T) {
 	_, err = NewMnemonic(FreshKey)
 	require.NoError(t, err, "unexpected error generating new 24-word mnemonic")
 }
+
+// the CRC32 checksums of the word lists of the BIP 39 specification
+func TestWordListChecksums(t *testing.T) {
+	cases := []struct {
+		words    string
+		checksum string
+	}{
+		{englishWords, "c1dbd296"},
+		{japaneseWords, "acc1419"},
+		{koreanWords, "4ef461eb"},
+		{spanishWords, "266e4f3d"},
+		{chineseSimplifiedWords, "e3721bbf"},
+		{chineseTraditionalWords, "3c20b443"},
+		{frenchWords, "3e56b216"},
+		{italianWords, "2fc7d07e"},
+	}
+	for i, tc := range cases {
+		require.Equal(t, tc.checksum, fmt.Sprintf("%x", crc32.ChecksumIEEE([]byte(tc.words))), "%d", i)
+		require.Equal(t, 2048, len(WordLists[i].words), WordLists[i].Name())
+	}
+}
+
+// test vectors of the BIP 39 specification, with an empty password
+func TestMnemonicVectors(t *testing.T) {
+	cases := []struct {
+		wl       *WordList
+		entropy  string
+		mnemonic string
+		seed     string
+	}{
+		{
+			English,
+			"00000000000000000000000000000000",
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			"5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4",
+		},
+		{
+			English,
+			"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			"legal winner thank year wave sausage worth useful legal winner thank yellow",
+			"878386efb78845b3355bd15ea4d39ef97d179cb712b77d5c12b6be415fffeffe5f377ba02bf3f8544ab800b955e51fbff09828f682052a20faa6addbbddfb096",
+		},
+		{
+			Japanese,
+			"00000000000000000000000000000000",
+			"あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あおぞら",
+			"646f1a38134c556e948e6daef213609a62915ef568edb07ffa6046c87638b4b140fef2e0c6d7233af640c4a63de6d1a293288058c8ac1d113255d0504e63f301",
+		},
+	}
+	for _, tc := range cases {
+		entropy, err := hex.DecodeString(tc.entropy)
+		require.NoError(t, err)
+		// the words of the lists are in the NFKD form
+		require.Equal(t, SplitMnemonic(tc.mnemonic), entropyToWords(entropy, tc.wl))
+
+		decoded, err := MnemonicToEntropy(tc.mnemonic, tc.wl)
+		require.NoError(t, err)
+		require.Equal(t, entropy, decoded)
+
+		seed, err := MnemonicToSeedWithErrChecking(tc.mnemonic)
+		require.NoError(t, err)
+		require.Equal(t, tc.seed, hex.EncodeToString(seed))
+	}
+}
+
+func TestDetectWordList(t *testing.T) {
+	for _, wl := range WordLists {
+		words, err := NewMnemonicWithWordList(FreshKey, wl)
+		require.NoError(t, err)
+		require.Equal(t, int(FreshKey), len(words))
+		mnemonic := wl.Join(words)
+
+		// the language of the mnemonic is detected
+		detected, err := DetectWordList(mnemonic)
+		require.NoError(t, err, wl.Name())
+		seed, err := MnemonicToSeedWithErrChecking(mnemonic)
+		require.NoError(t, err, wl.Name())
+		require.Equal(t, MnemonicToSeed(mnemonic), seed)
+
+		// chinese mnemonics may be valid in both lists, with the same seed
+		if !strings.HasPrefix(wl.Name(), "chinese") {
+			require.Equal(t, wl, detected)
+		}
+
+		// the composed form of the words is normalized
+		composed := norm.NFC.String(mnemonic)
+		seed, err = MnemonicToSeedWithErrChecking(composed)
+		require.NoError(t, err, wl.Name())
+		require.Equal(t, MnemonicToSeed(mnemonic), seed)
+	}
+}
+
+func TestInvalidMnemonics(t *testing.T) {
+	valid := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	_, err := DetectWordList(valid)
+	require.NoError(t, err)
+
+	// the checksum is in the last word
+	_, err = MnemonicToSeedWithErrChecking(strings.Replace(valid, "yellow", "year", 1))
+	require.Equal(t, ErrInvalidChecksum, err)
+
+	_, err = MnemonicToSeedWithErrChecking(strings.Replace(valid, "legal", "cosmos", 1))
+	require.Equal(t, ErrUnknownLanguage, err)
+
+	_, err = MnemonicToEntropy(valid, French)
+	require.Error(t, err)
+
+	_, err = MnemonicToEntropy("legal winner thank year wave sausage worth useful legal winner thank", English)
+	require.Error(t, err)
+}
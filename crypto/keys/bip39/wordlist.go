@@ -0,0 +1,72 @@
+package bip39
+
+import (
+	"strings"
+)
+
+// WordList is a word list of the BIP 39 specification, encoding the entropy of
+// the mnemonics in a language. The words of the specification are in the
+// NFKD form, as are the mnemonics they are compared with.
+type WordList struct {
+	name      string
+	separator string
+	words     []string
+	index     map[string]int
+}
+
+func newWordList(name, separator, list string) *WordList {
+	words := strings.Split(strings.TrimSpace(list), "\n")
+	index := make(map[string]int, len(words))
+	for i, word := range words {
+		index[word] = i
+	}
+	return &WordList{
+		name:      name,
+		separator: separator,
+		words:     words,
+		index:     index,
+	}
+}
+
+// The word lists of the BIP 39 specification.
+var (
+	English            = newWordList("english", " ", englishWords)
+	Japanese           = newWordList("japanese", "\u3000", japaneseWords)
+	Korean             = newWordList("korean", " ", koreanWords)
+	Spanish            = newWordList("spanish", " ", spanishWords)
+	ChineseSimplified  = newWordList("chinese-simplified", " ", chineseSimplifiedWords)
+	ChineseTraditional = newWordList("chinese-traditional", " ", chineseTraditionalWords)
+	French             = newWordList("french", " ", frenchWords)
+	Italian            = newWordList("italian", " ", italianWords)
+)
+
+// WordLists holds all the word lists, in the order a mnemonic is matched
+// against them when detecting its language.
+var WordLists = []*WordList{
+	English,
+	Japanese,
+	Korean,
+	Spanish,
+	ChineseSimplified,
+	ChineseTraditional,
+	French,
+	Italian,
+}
+
+// Name returns the name of the language of the word list.
+func (wl *WordList) Name() string {
+	return wl.name
+}
+
+// Join joins the words of a mnemonic with the separator of the language, the
+// ideographic space for japanese and a space otherwise.
+func (wl *WordList) Join(words []string) string {
+	return strings.Join(words, wl.separator)
+}
+
+// GetWordIndex returns the index of a word in the list, and whether the word
+// is in the list at all.
+func (wl *WordList) GetWordIndex(word string) (int, bool) {
+	idx, ok := wl.index[word]
+	return idx, ok
+}
@@ -16,4 +16,14 @@ func init() {
 	cdc.RegisterConcrete(localInfo{}, "crypto/keys/localInfo", nil)
 	cdc.RegisterConcrete(ledgerInfo{}, "crypto/keys/ledgerInfo", nil)
 	cdc.RegisterConcrete(offlineInfo{}, "crypto/keys/offlineInfo", nil)
+	cdc.RegisterConcrete(remoteInfo{}, "crypto/keys/remoteInfo", nil)
+
+	cdc.RegisterInterface((*RemoteSignerMsg)(nil), nil)
+	cdc.RegisterConcrete(&ListKeysRequest{}, "crypto/keys/ListKeysRequest", nil)
+	cdc.RegisterConcrete(&ListKeysResponse{}, "crypto/keys/ListKeysResponse", nil)
+	cdc.RegisterConcrete(&GetKeyRequest{}, "crypto/keys/GetKeyRequest", nil)
+	cdc.RegisterConcrete(&GetKeyResponse{}, "crypto/keys/GetKeyResponse", nil)
+	cdc.RegisterConcrete(&SignRequest{}, "crypto/keys/SignRequest", nil)
+	cdc.RegisterConcrete(&SignResponse{}, "crypto/keys/SignResponse", nil)
+	cdc.RegisterConcrete(&RemoteSignerError{}, "crypto/keys/RemoteSignerError", nil)
 }
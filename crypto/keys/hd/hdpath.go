@@ -62,6 +62,24 @@ func NewFundraiserParams(account uint32, addressIdx uint32) *BIP44Params {
 	return NewParams(44, 118, account, false, addressIdx)
 }
 
+// HardenedString returns the path of the params with every level hardened,
+// as required by the SLIP-0010 derivation of ed25519 keys:
+// m / purpose' / coin_type' / account' / change' / address_index'
+func (p BIP44Params) HardenedString() string {
+	var changeStr string
+	if p.change {
+		changeStr = "1"
+	} else {
+		changeStr = "0"
+	}
+	return fmt.Sprintf("%d'/%d'/%d'/%s'/%d'",
+		p.purpose,
+		p.coinType,
+		p.account,
+		changeStr,
+		p.addressIdx)
+}
+
 func (p BIP44Params) String() string {
 	var changeStr string
 	if p.change {
@@ -92,20 +110,11 @@ func DerivePrivateKeyForPath(privKeyBytes [32]byte, chainCode [32]byte, path str
 	data := privKeyBytes
 	parts := strings.Split(path, "/")
 	for _, part := range parts {
-		// do we have an apostrophe?
-		harden := part[len(part)-1:] == "'"
-		// harden == private derivation, else public derivation:
-		if harden {
-			part = part[:len(part)-1]
-		}
-		idx, err := strconv.Atoi(part)
+		idx, harden, err := parsePathPart(part)
 		if err != nil {
-			return [32]byte{}, fmt.Errorf("invalid BIP 32 path: %s", err)
+			return [32]byte{}, err
 		}
-		if idx < 0 {
-			return [32]byte{}, errors.New("invalid BIP 32 path: index negative ot too large")
-		}
-		data, chainCode = derivePrivateKey(data, chainCode, uint32(idx), harden)
+		data, chainCode = derivePrivateKey(data, chainCode, idx, harden)
 	}
 	var derivedKey [32]byte
 	n := copy(derivedKey[:], data[:])
@@ -116,6 +125,28 @@ func DerivePrivateKeyForPath(privKeyBytes [32]byte, chainCode [32]byte, path str
 	return derivedKey, nil
 }
 
+// parsePathPart returns the index of a part of a BIP 32 path, and whether the
+// derivation is hardened.
+func parsePathPart(part string) (uint32, bool, error) {
+	if part == "" {
+		return 0, false, errors.New("invalid BIP 32 path: empty index")
+	}
+	// do we have an apostrophe?
+	harden := part[len(part)-1:] == "'"
+	// harden == private derivation, else public derivation:
+	if harden {
+		part = part[:len(part)-1]
+	}
+	idx, err := strconv.Atoi(part)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid BIP 32 path: %s", err)
+	}
+	if idx < 0 {
+		return 0, false, errors.New("invalid BIP 32 path: index negative ot too large")
+	}
+	return uint32(idx), harden, nil
+}
+
 // derivePrivateKey derives the private key with index and chainCode.
 // If harden is true, the derivation is 'hardened'.
 // It returns the new private key and new chain code.
@@ -0,0 +1,46 @@
+package hd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ComputeMastersFromSeedEd25519 returns the master secret and chain code of
+// the ed25519 keys derived from the seed, as specified by SLIP-0010:
+//  https://github.com/satoshilabs/slips/blob/master/slip-0010.md
+func ComputeMastersFromSeedEd25519(seed []byte) (secret [32]byte, chainCode [32]byte) {
+	masterSecret := []byte("ed25519 seed")
+	secret, chainCode = i64(masterSecret, seed)
+
+	return
+}
+
+// DerivePrivateKeyForPathEd25519 derives the ed25519 private key seed by
+// following the BIP 32 path from privKeyBytes, using the given chainCode, as
+// specified by SLIP-0010. Ed25519 keys only support hardened derivation, so
+// every index of the path must be hardened.
+func DerivePrivateKeyForPathEd25519(privKeyBytes [32]byte, chainCode [32]byte, path string) ([32]byte, error) {
+	data := privKeyBytes
+	parts := strings.Split(path, "/")
+	for _, part := range parts {
+		idx, harden, err := parsePathPart(part)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		if !harden {
+			return [32]byte{}, fmt.Errorf("invalid SLIP-0010 path: ed25519 keys only support hardened derivation, got index %s", part)
+		}
+		data, chainCode = derivePrivateKeyEd25519(data, chainCode, idx)
+	}
+
+	return data, nil
+}
+
+// derivePrivateKeyEd25519 derives the hardened child private key with index
+// and chainCode. It returns the new private key and new chain code.
+func derivePrivateKeyEd25519(privKeyBytes [32]byte, chainCode [32]byte, index uint32) ([32]byte, [32]byte) {
+	index = index | 0x80000000
+	data := append([]byte{byte(0)}, privKeyBytes[:]...)
+	data = append(data, uint32ToBytes(index)...)
+	return i64(chainCode[:], data)
+}
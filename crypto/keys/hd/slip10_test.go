@@ -0,0 +1,41 @@
+package hd
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// test vector 1 for ed25519 of SLIP-0010
+func TestDerivePrivateKeyForPathEd25519(t *testing.T) {
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+	master, ch := ComputeMastersFromSeedEd25519(seed)
+	require.Equal(t, "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7", hex.EncodeToString(master[:]))
+	require.Equal(t, "90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb", hex.EncodeToString(ch[:]))
+
+	cases := []struct {
+		path string
+		priv string
+	}{
+		{"0'", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3"},
+		{"0'/1'", "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2"},
+		{"0'/1'/2'", "92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9"},
+		{"0'/1'/2'/2'", "30d1dc7e5fc04c31219ab25a27ae00b50f6fd66622f6e9c913253d6511d1e662"},
+		{"0'/1'/2'/2'/1000000000'", "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793"},
+	}
+	for _, tc := range cases {
+		priv, err := DerivePrivateKeyForPathEd25519(master, ch, tc.path)
+		require.NoError(t, err, tc.path)
+		require.Equal(t, tc.priv, hex.EncodeToString(priv[:]), tc.path)
+	}
+
+	// only hardened derivation is supported
+	_, err = DerivePrivateKeyForPathEd25519(master, ch, "0'/1")
+	require.Error(t, err)
+	_, err = DerivePrivateKeyForPathEd25519(master, ch, FullFundraiserPath)
+	require.Error(t, err)
+	_, err = DerivePrivateKeyForPathEd25519(master, ch, NewFundraiserParams(0, 0).HardenedString())
+	require.NoError(t, err)
+}
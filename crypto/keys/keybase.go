@@ -2,6 +2,7 @@ package keys
 
 import (
 	"bufio"
+	"encoding/base64"
 	"fmt"
 	"os"
 	"strings"
@@ -10,7 +11,9 @@ import (
 	"github.com/cosmos/cosmos-sdk/crypto/keys/bip39"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
 	"github.com/pkg/errors"
+	tmed25519 "github.com/tendermint/ed25519"
 	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/encoding/amino"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	dbm "github.com/tendermint/tendermint/libs/db"
@@ -19,82 +22,161 @@ import (
 var _ Keybase = dbKeybase{}
 
 // Language is a language to create the BIP 39 mnemonic in.
-// Currently, only english is supported though.
 // Find a list of all supported languages in the BIP 39 spec (word lists).
 type Language int
 
 const (
 	// English is the default language to create a mnemonic.
-	// It is the only supported language by this package.
 	English Language = iota + 1
-	// Japanese is currently not supported.
+	// Japanese mnemonics are separated by ideographic spaces.
 	Japanese
-	// Korean is currently not supported.
+	// Korean mnemonics.
 	Korean
-	// Spanish is currently not supported.
+	// Spanish mnemonics.
 	Spanish
-	// ChineseSimplified is currently not supported.
+	// ChineseSimplified mnemonics.
 	ChineseSimplified
-	// ChineseTraditional is currently not supported.
+	// ChineseTraditional mnemonics.
 	ChineseTraditional
-	// French is currently not supported.
+	// French mnemonics.
 	French
-	// Italian is currently not supported.
+	// Italian mnemonics.
 	Italian
 )
 
+// wordLists maps the languages to their BIP 39 word lists.
+var wordLists = map[Language]*bip39.WordList{
+	English:            bip39.English,
+	Japanese:           bip39.Japanese,
+	Korean:             bip39.Korean,
+	Spanish:            bip39.Spanish,
+	ChineseSimplified:  bip39.ChineseSimplified,
+	ChineseTraditional: bip39.ChineseTraditional,
+	French:             bip39.French,
+	Italian:            bip39.Italian,
+}
+
+// String returns the name of the language, as accepted by ParseLanguage.
+func (l Language) String() string {
+	wl, ok := wordLists[l]
+	if !ok {
+		return fmt.Sprintf("Language(%d)", int(l))
+	}
+	return wl.Name()
+}
+
+// ParseLanguage returns the language of the name, e.g. "english" or
+// "chinese-simplified".
+func ParseLanguage(name string) (Language, error) {
+	for l, wl := range wordLists {
+		if wl.Name() == name {
+			return l, nil
+		}
+	}
+	return 0, ErrUnsupportedLanguage
+}
+
+// DetectLanguage returns the language of a mnemonic, which must have a valid
+// checksum in the language.
+func DetectLanguage(mnemonic string) (Language, error) {
+	wl, err := bip39.DetectWordList(mnemonic)
+	if err != nil {
+		return 0, err
+	}
+	for l, lwl := range wordLists {
+		if lwl == wl {
+			return l, nil
+		}
+	}
+	return 0, ErrUnsupportedLanguage
+}
+
 var (
 	// ErrUnsupportedSigningAlgo is raised when the caller tries to use a
-	// different signing scheme than secp256k1.
-	ErrUnsupportedSigningAlgo = errors.New("unsupported signing algo: only secp256k1 is supported")
+	// signing scheme not supported by the operation: keys are derived with
+	// secp256k1 or ed25519, and Ledger keys with secp256k1 only.
+	ErrUnsupportedSigningAlgo = errors.New("unsupported signing algo")
 
 	// ErrUnsupportedLanguage is raised when the caller tries to use a
-	// different language than english for creating a mnemonic sentence.
-	ErrUnsupportedLanguage = errors.New("unsupported language: only english is supported")
+	// language without a BIP 39 word list for creating a mnemonic sentence.
+	ErrUnsupportedLanguage = errors.New("unsupported language")
 )
 
 // dbKeybase combines encryption and storage implementation to provide
 // a full-featured key manager
 type dbKeybase struct {
 	db dbm.DB
+
+	kdf        string // encrypting the private keys
+	encodeInfo func(Info) []byte
+	decodeInfo func([]byte) (Info, error)
 }
 
 // New creates a new keybase instance using the passed DB for reading and writing keys.
 func New(db dbm.DB) Keybase {
 	return dbKeybase{
-		db: db,
+		db:         db,
+		kdf:        kdfBcrypt,
+		encodeInfo: writeInfo,
+		decodeInfo: readInfo,
+	}
+}
+
+// NewFile creates a new keybase instance storing each key in a JSON file of
+// the directory, with the private keys encrypted with a key derived from the
+// passphrase by scrypt. The files can be copied to another keybase.
+func NewFile(dir string) Keybase {
+	return dbKeybase{
+		db:         dbm.NewFSDB(dir),
+		kdf:        kdfScrypt,
+		encodeInfo: writeInfoJSON,
+		decodeInfo: readInfoJSON,
+	}
+}
+
+// NewTest creates a new keybase instance using the passed DB for reading and
+// writing keys, with the private keys stored unencrypted. The passphrases are
+// ignored, so it must only be used for testing.
+func NewTest(db dbm.DB) Keybase {
+	return dbKeybase{
+		db:         db,
+		kdf:        kdfNone,
+		encodeInfo: writeInfo,
+		decodeInfo: readInfo,
 	}
 }
 
 // CreateMnemonic generates a new key and persists it to storage, encrypted
 // using the provided password.
-// It returns the generated mnemonic and the key Info.
+// It returns the generated mnemonic, in the given language, and the key Info.
 // It returns an error if it fails to
 // generate a key for the given algo type, or if another key is
 // already stored under the same name.
 func (kb dbKeybase) CreateMnemonic(name string, language Language, passwd string, algo SigningAlgo) (info Info, mnemonic string, err error) {
-	if language != English {
+	wl, ok := wordLists[language]
+	if !ok {
 		return nil, "", ErrUnsupportedLanguage
 	}
-	if algo != Secp256k1 {
+	if algo != Secp256k1 && algo != Ed25519 {
 		err = ErrUnsupportedSigningAlgo
 		return
 	}
 
 	// default number of words (24):
-	mnemonicS, err := bip39.NewMnemonic(bip39.FreshKey)
+	mnemonicS, err := bip39.NewMnemonicWithWordList(bip39.FreshKey, wl)
 	if err != nil {
 		return
 	}
-	mnemonic = strings.Join(mnemonicS, " ")
+	mnemonic = wl.Join(mnemonicS)
 	seed := bip39.MnemonicToSeed(mnemonic)
-	info, err = kb.persistDerivedKey(seed, passwd, name, hd.FullFundraiserPath)
+	info, err = kb.persistDerivedKey(seed, passwd, name, *hd.NewFundraiserParams(0, 0), algo)
 	return
 }
 
 // TEMPORARY METHOD UNTIL WE FIGURE OUT USER FACING HD DERIVATION API
+// The language of the mnemonic is detected from its words.
 func (kb dbKeybase) CreateKey(name, mnemonic, passwd string) (info Info, err error) {
-	words := strings.Split(mnemonic, " ")
+	words := bip39.SplitMnemonic(mnemonic)
 	if len(words) != 12 && len(words) != 24 {
 		err = fmt.Errorf("recovering only works with 12 word (fundraiser) or 24 word mnemonics, got: %v words", len(words))
 		return
@@ -103,7 +185,7 @@ func (kb dbKeybase) CreateKey(name, mnemonic, passwd string) (info Info, err err
 	if err != nil {
 		return
 	}
-	info, err = kb.persistDerivedKey(seed, passwd, name, hd.FullFundraiserPath)
+	info, err = kb.persistDerivedKey(seed, passwd, name, *hd.NewFundraiserParams(0, 0), Secp256k1)
 	return
 }
 
@@ -111,7 +193,7 @@ func (kb dbKeybase) CreateKey(name, mnemonic, passwd string) (info Info, err err
 // encrypted with the given password.
 // TODO(ismail)
 func (kb dbKeybase) CreateFundraiserKey(name, mnemonic, passwd string) (info Info, err error) {
-	words := strings.Split(mnemonic, " ")
+	words := bip39.SplitMnemonic(mnemonic)
 	if len(words) != 12 {
 		err = fmt.Errorf("recovering only works with 12 word (fundraiser), got: %v words", len(words))
 		return
@@ -120,16 +202,19 @@ func (kb dbKeybase) CreateFundraiserKey(name, mnemonic, passwd string) (info Inf
 	if err != nil {
 		return
 	}
-	info, err = kb.persistDerivedKey(seed, passwd, name, hd.FullFundraiserPath)
+	info, err = kb.persistDerivedKey(seed, passwd, name, *hd.NewFundraiserParams(0, 0), Secp256k1)
 	return
 }
 
-func (kb dbKeybase) Derive(name, mnemonic, passwd string, params hd.BIP44Params) (info Info, err error) {
+// Derive derives the key of the algo at the BIP 44 path of the params from the
+// mnemonic, and persists it encrypted with the given password. The language of
+// the mnemonic is detected from its words.
+func (kb dbKeybase) Derive(name, mnemonic, passwd string, params hd.BIP44Params, algo SigningAlgo) (info Info, err error) {
 	seed, err := bip39.MnemonicToSeedWithErrChecking(mnemonic)
 	if err != nil {
 		return
 	}
-	info, err = kb.persistDerivedKey(seed, passwd, name, params.String())
+	info, err = kb.persistDerivedKey(seed, passwd, name, params, algo)
 
 	return
 }
@@ -145,41 +230,64 @@ func (kb dbKeybase) CreateLedger(name string, path crypto.DerivationPath, algo S
 		return nil, err
 	}
 	pub := priv.PubKey()
-	return kb.writeLedgerKey(pub, path, name), nil
+	return kb.writeLedgerKey(pub, path, name, algo), nil
 }
 
 // CreateOffline creates a new reference to an offline keypair
 // It returns the created key info
 func (kb dbKeybase) CreateOffline(name string, pub tmcrypto.PubKey) (Info, error) {
-	return kb.writeOfflineKey(pub, name), nil
+	return kb.writeOfflineKey(pub, name, pubKeyAlgo(pub)), nil
 }
 
-func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name, fullHdPath string) (info Info, err error) {
+func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name string, params hd.BIP44Params, algo SigningAlgo) (info Info, err error) {
 	// create master key and derive first key:
-	masterPriv, ch := hd.ComputeMastersFromSeed(seed)
-	derivedPriv, err := hd.DerivePrivateKeyForPath(masterPriv, ch, fullHdPath)
-	if err != nil {
-		return
+	var derivedPriv tmcrypto.PrivKey
+	switch algo {
+	case Secp256k1:
+		masterPriv, ch := hd.ComputeMastersFromSeed(seed)
+		privBytes, err := hd.DerivePrivateKeyForPath(masterPriv, ch, params.String())
+		if err != nil {
+			return nil, err
+		}
+		derivedPriv = secp256k1.PrivKeySecp256k1(privBytes)
+	case Ed25519:
+		// SLIP-0010 only supports hardened derivation of ed25519 keys
+		masterPriv, ch := hd.ComputeMastersFromSeedEd25519(seed)
+		privBytes, err := hd.DerivePrivateKeyForPathEd25519(masterPriv, ch, params.HardenedString())
+		if err != nil {
+			return nil, err
+		}
+		derivedPriv = ed25519PrivKeyFromSeed(privBytes)
+	default:
+		return nil, ErrUnsupportedSigningAlgo
 	}
 
-	// if we have a password, use it to encrypt the private key and store it
-	// else store the public key only
-	if passwd != "" {
-		info = kb.writeLocalKey(secp256k1.PrivKeySecp256k1(derivedPriv), name, passwd)
+	// if we have a password, or if the private keys are not encrypted, store
+	// the private key, else store the public key only
+	if passwd != "" || kb.kdf == kdfNone {
+		info = kb.writeLocalKey(derivedPriv, name, passwd, algo)
 	} else {
-		pubk := secp256k1.PrivKeySecp256k1(derivedPriv).PubKey()
-		info = kb.writeOfflineKey(pubk, name)
+		info = kb.writeOfflineKey(derivedPriv.PubKey(), name, algo)
 	}
 	return
 }
 
+// returns the ed25519 private key of a 32 bytes seed
+func ed25519PrivKeyFromSeed(seed [32]byte) ed25519.PrivKeyEd25519 {
+	privKey := new([64]byte)
+	copy(privKey[:32], seed[:])
+	// fills in the public key
+	tmed25519.MakePublicKey(privKey)
+	return ed25519.PrivKeyEd25519(*privKey)
+}
+
 // List returns the keys from storage in alphabetical order.
 func (kb dbKeybase) List() ([]Info, error) {
 	var res []Info
 	iter := kb.db.Iterator(nil, nil)
 	defer iter.Close()
 	for ; iter.Valid(); iter.Next() {
-		info, err := readInfo(iter.Value())
+		info, err := kb.decodeInfo(iter.Value())
 		if err != nil {
 			return nil, err
 		}
@@ -194,7 +302,7 @@ func (kb dbKeybase) Get(name string) (Info, error) {
 	if len(bs) == 0 {
 		return nil, fmt.Errorf("Key %s not found", name)
 	}
-	return readInfo(bs)
+	return kb.decodeInfo(bs)
 }
 
 // Sign signs the msg with the named key.
@@ -212,7 +320,7 @@ func (kb dbKeybase) Sign(name, passphrase string, msg []byte) (sig []byte, pub t
 			err = fmt.Errorf("private key not available")
 			return
 		}
-		priv, err = unarmorDecryptPrivKey(linfo.PrivKeyArmor, passphrase)
+		priv, err = unarmorDecryptPrivKey(linfo.PrivKeyArmor, passphrase, kb.kdf)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -229,7 +337,7 @@ func (kb dbKeybase) Sign(name, passphrase string, msg []byte) (sig []byte, pub t
 			return nil, nil, err
 		}
 		buf := bufio.NewReader(os.Stdin)
-		_, err = fmt.Fprintf(os.Stderr, "\nEnter Amino-encoded signature:\n")
+		_, err = fmt.Fprintf(os.Stderr, "\nEnter base64-encoded signature:\n")
 		if err != nil {
 			return nil, nil, err
 		}
@@ -238,7 +346,10 @@ func (kb dbKeybase) Sign(name, passphrase string, msg []byte) (sig []byte, pub t
 		if err != nil {
 			return nil, nil, err
 		}
-		cdc.MustUnmarshalBinary([]byte(signed), sig)
+		sig, err = base64.StdEncoding.DecodeString(strings.TrimSpace(signed))
+		if err != nil {
+			return nil, nil, err
+		}
 		return sig, linfo.GetPubKey(), nil
 	}
 	sig, err = priv.Sign(msg)
@@ -262,7 +373,7 @@ func (kb dbKeybase) ExportPrivateKeyObject(name string, passphrase string) (tmcr
 			err = fmt.Errorf("private key not available")
 			return nil, err
 		}
-		priv, err = unarmorDecryptPrivKey(linfo.PrivKeyArmor, passphrase)
+		priv, err = unarmorDecryptPrivKey(linfo.PrivKeyArmor, passphrase, kb.kdf)
 		if err != nil {
 			return nil, err
 		}
@@ -279,7 +390,12 @@ func (kb dbKeybase) Export(name string) (armor string, err error) {
 	if bz == nil {
 		return "", fmt.Errorf("no key to export with name %s", name)
 	}
-	return armorInfoBytes(bz), nil
+	// export the info in the binary encoding whatever the storage encoding
+	info, err := kb.decodeInfo(bz)
+	if err != nil {
+		return
+	}
+	return armorInfoBytes(writeInfo(info)), nil
 }
 
 // ExportPubKey returns public keys in ASCII armored format.
@@ -290,7 +406,7 @@ func (kb dbKeybase) ExportPubKey(name string) (armor string, err error) {
 	if bz == nil {
 		return "", fmt.Errorf("no key to export with name %s", name)
 	}
-	info, err := readInfo(bz)
+	info, err := kb.decodeInfo(bz)
 	if err != nil {
 		return
 	}
@@ -306,7 +422,17 @@ func (kb dbKeybase) Import(name string, armor string) (err error) {
 	if err != nil {
 		return
 	}
-	kb.db.Set(infoKey(name), infoBytes)
+	info, err := readInfo(infoBytes)
+	if err != nil {
+		return
+	}
+	// keys exported from a test keybase cannot be stored unencrypted
+	if linfo, ok := info.(localInfo); ok && kb.kdf != kdfNone {
+		if err = checkArmorPrivKeyEncrypted(linfo.PrivKeyArmor); err != nil {
+			return
+		}
+	}
+	kb.db.Set(infoKey(name), kb.encodeInfo(info))
 	return nil
 }
 
@@ -326,10 +452,36 @@ func (kb dbKeybase) ImportPubKey(name string, armor string) (err error) {
 	if err != nil {
 		return
 	}
-	kb.writeOfflineKey(pubKey, name)
+	kb.writeOfflineKey(pubKey, name, pubKeyAlgo(pubKey))
 	return
 }
 
+// ExportWeb3 returns the JSON keystore of the Web3 secret storage holding the
+// private key, decrypted with the passphrase and encrypted with the
+// exportPassphrase.
+func (kb dbKeybase) ExportWeb3(name, passphrase, exportPassphrase string) ([]byte, error) {
+	priv, err := kb.ExportPrivateKeyObject(name, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return EncryptWeb3Keystore(priv, exportPassphrase)
+}
+
+// ImportWeb3 stores the private key of a JSON keystore of the Web3 secret
+// storage, decrypted with the importPassphrase, as a local key encrypted with
+// the passphrase.
+func (kb dbKeybase) ImportWeb3(name string, keyJSON []byte, importPassphrase, passphrase string) (Info, error) {
+	bz := kb.db.Get(infoKey(name))
+	if len(bz) > 0 {
+		return nil, errors.New("Cannot overwrite data for name " + name)
+	}
+	priv, err := DecryptWeb3Keystore(keyJSON, importPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	return kb.writeLocalKey(priv, name, passphrase, pubKeyAlgo(priv.PubKey())), nil
+}
+
 // Delete removes key forever, but we must present the
 // proper passphrase before deleting it (for security).
 // A passphrase of 'yes' is used to delete stored
@@ -343,7 +495,7 @@ func (kb dbKeybase) Delete(name, passphrase string) error {
 	switch info.(type) {
 	case localInfo:
 		linfo := info.(localInfo)
-		_, err = unarmorDecryptPrivKey(linfo.PrivKeyArmor, passphrase)
+		_, err = unarmorDecryptPrivKey(linfo.PrivKeyArmor, passphrase, kb.kdf)
 		if err != nil {
 			return err
 		}
@@ -374,7 +526,7 @@ func (kb dbKeybase) Update(name, oldpass string, getNewpass func() (string, erro
 	switch info.(type) {
 	case localInfo:
 		linfo := info.(localInfo)
-		key, err := unarmorDecryptPrivKey(linfo.PrivKeyArmor, oldpass)
+		key, err := unarmorDecryptPrivKey(linfo.PrivKeyArmor, oldpass, kb.kdf)
 		if err != nil {
 			return err
 		}
@@ -382,38 +534,38 @@ func (kb dbKeybase) Update(name, oldpass string, getNewpass func() (string, erro
 		if err != nil {
 			return err
 		}
-		kb.writeLocalKey(key, name, newpass)
+		kb.writeLocalKey(key, name, newpass, linfo.GetAlgo())
 		return nil
 	default:
 		return fmt.Errorf("locally stored key required")
 	}
 }
 
-func (kb dbKeybase) writeLocalKey(priv tmcrypto.PrivKey, name, passphrase string) Info {
+func (kb dbKeybase) writeLocalKey(priv tmcrypto.PrivKey, name, passphrase string, algo SigningAlgo) Info {
 	// encrypt private key using passphrase
-	privArmor := encryptArmorPrivKey(priv, passphrase)
+	privArmor := encryptArmorPrivKey(priv, passphrase, kb.kdf)
 	// make Info
 	pub := priv.PubKey()
-	info := newLocalInfo(name, pub, privArmor)
+	info := newLocalInfo(name, pub, privArmor, algo)
 	kb.writeInfo(info, name)
 	return info
 }
 
-func (kb dbKeybase) writeLedgerKey(pub tmcrypto.PubKey, path crypto.DerivationPath, name string) Info {
-	info := newLedgerInfo(name, pub, path)
+func (kb dbKeybase) writeLedgerKey(pub tmcrypto.PubKey, path crypto.DerivationPath, name string, algo SigningAlgo) Info {
+	info := newLedgerInfo(name, pub, path, algo)
 	kb.writeInfo(info, name)
 	return info
 }
 
-func (kb dbKeybase) writeOfflineKey(pub tmcrypto.PubKey, name string) Info {
-	info := newOfflineInfo(name, pub)
+func (kb dbKeybase) writeOfflineKey(pub tmcrypto.PubKey, name string, algo SigningAlgo) Info {
+	info := newOfflineInfo(name, pub, algo)
 	kb.writeInfo(info, name)
 	return info
 }
 
 func (kb dbKeybase) writeInfo(info Info, name string) {
 	// write the info by key
-	kb.db.SetSync(infoKey(name), writeInfo(info))
+	kb.db.SetSync(infoKey(name), kb.encodeInfo(info))
 }
 
 func infoKey(name string) []byte {
@@ -2,12 +2,17 @@ package keys
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/armor"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 
 	dbm "github.com/tendermint/tendermint/libs/db"
@@ -15,325 +20,527 @@ import (
 
 func init() {
 	BcryptSecurityParameter = 1
+	ScryptN = 2
+}
+
+// run a test against a new keybase of each backend, passing whether the keys
+// are protected by their passphrase
+func forEachBackend(t *testing.T, test func(t *testing.T, cstore Keybase, protected bool)) {
+	t.Run("db", func(t *testing.T) {
+		test(t, New(dbm.NewMemDB()), true)
+	})
+	t.Run("file", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "keybase")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		test(t, NewFile(dir), true)
+	})
+	t.Run("test", func(t *testing.T) {
+		test(t, NewTest(dbm.NewMemDB()), false)
+	})
 }
 
 // TestKeyManagement makes sure we can manipulate these keys well
 func TestKeyManagement(t *testing.T) {
-	// make the storage with reasonable defaults
-	cstore := New(
-		dbm.NewMemDB(),
-	)
-
-	algo := Secp256k1
-	n1, n2, n3 := "personal", "business", "other"
-	p1, p2 := "1234", "really-secure!@#$"
-
-	// Check empty state
-	l, err := cstore.List()
-	require.Nil(t, err)
-	assert.Empty(t, l)
-
-	_, _, err = cstore.CreateMnemonic(n1, English, p1, Ed25519)
-	require.Error(t, err, "ed25519 keys are currently not supported by keybase")
-
-	// create some keys
-	_, err = cstore.Get(n1)
-	require.Error(t, err)
-	i, _, err := cstore.CreateMnemonic(n1, English, p1, algo)
-
-	require.NoError(t, err)
-	require.Equal(t, n1, i.GetName())
-	_, _, err = cstore.CreateMnemonic(n2, English, p2, algo)
-	require.NoError(t, err)
-
-	// we can get these keys
-	i2, err := cstore.Get(n2)
-	require.NoError(t, err)
-	_, err = cstore.Get(n3)
-	require.NotNil(t, err)
-
-	// list shows them in order
-	keyS, err := cstore.List()
-	require.NoError(t, err)
-	require.Equal(t, 2, len(keyS))
-	// note these are in alphabetical order
-	require.Equal(t, n2, keyS[0].GetName())
-	require.Equal(t, n1, keyS[1].GetName())
-	require.Equal(t, i2.GetPubKey(), keyS[0].GetPubKey())
-
-	// deleting a key removes it
-	err = cstore.Delete("bad name", "foo")
-	require.NotNil(t, err)
-	err = cstore.Delete(n1, p1)
-	require.NoError(t, err)
-	keyS, err = cstore.List()
-	require.NoError(t, err)
-	require.Equal(t, 1, len(keyS))
-	_, err = cstore.Get(n1)
-	require.Error(t, err)
-
-	// create an offline key
-	o1 := "offline"
-	priv1 := ed25519.GenPrivKey()
-	pub1 := priv1.PubKey()
-	i, err = cstore.CreateOffline(o1, pub1)
-	require.Nil(t, err)
-	require.Equal(t, pub1, i.GetPubKey())
-	require.Equal(t, o1, i.GetName())
-	keyS, err = cstore.List()
-	require.NoError(t, err)
-	require.Equal(t, 2, len(keyS))
-
-	// delete the offline key
-	err = cstore.Delete(o1, "no")
-	require.NotNil(t, err)
-	err = cstore.Delete(o1, "yes")
-	require.NoError(t, err)
-	keyS, err = cstore.List()
-	require.NoError(t, err)
-	require.Equal(t, 1, len(keyS))
+	forEachBackend(t, func(t *testing.T, cstore Keybase, protected bool) {
+		algo := Secp256k1
+		n1, n2, n3 := "personal", "business", "other"
+		p1, p2 := "1234", "really-secure!@#$"
+
+		// Check empty state
+		l, err := cstore.List()
+		require.Nil(t, err)
+		assert.Empty(t, l)
+
+		_, _, err = cstore.CreateMnemonic(n1, English, p1, SigningAlgo("sr25519"))
+		require.Equal(t, ErrUnsupportedSigningAlgo, err)
+
+		// create some keys
+		_, err = cstore.Get(n1)
+		require.Error(t, err)
+		i, _, err := cstore.CreateMnemonic(n1, English, p1, algo)
+
+		require.NoError(t, err)
+		require.Equal(t, n1, i.GetName())
+		_, _, err = cstore.CreateMnemonic(n2, English, p2, algo)
+		require.NoError(t, err)
+
+		// we can get these keys
+		i2, err := cstore.Get(n2)
+		require.NoError(t, err)
+		_, err = cstore.Get(n3)
+		require.NotNil(t, err)
+
+		// list shows them in order
+		keyS, err := cstore.List()
+		require.NoError(t, err)
+		require.Equal(t, 2, len(keyS))
+		// note these are in alphabetical order
+		require.Equal(t, n2, keyS[0].GetName())
+		require.Equal(t, n1, keyS[1].GetName())
+		require.Equal(t, i2.GetPubKey(), keyS[0].GetPubKey())
+
+		// deleting a key removes it
+		err = cstore.Delete("bad name", "foo")
+		require.NotNil(t, err)
+		err = cstore.Delete(n1, p1)
+		require.NoError(t, err)
+		keyS, err = cstore.List()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(keyS))
+		_, err = cstore.Get(n1)
+		require.Error(t, err)
+
+		// create an offline key
+		o1 := "offline"
+		priv1 := ed25519.GenPrivKey()
+		pub1 := priv1.PubKey()
+		i, err = cstore.CreateOffline(o1, pub1)
+		require.Nil(t, err)
+		require.Equal(t, pub1, i.GetPubKey())
+		require.Equal(t, o1, i.GetName())
+		keyS, err = cstore.List()
+		require.NoError(t, err)
+		require.Equal(t, 2, len(keyS))
+
+		// delete the offline key
+		err = cstore.Delete(o1, "no")
+		require.NotNil(t, err)
+		err = cstore.Delete(o1, "yes")
+		require.NoError(t, err)
+		keyS, err = cstore.List()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(keyS))
+	})
 }
 
 // TestSignVerify does some detailed checks on how we sign and validate
 // signatures
 func TestSignVerify(t *testing.T) {
-	cstore := New(
-		dbm.NewMemDB(),
-	)
-	algo := Secp256k1
-
-	n1, n2, n3 := "some dude", "a dudette", "dude-ish"
-	p1, p2, p3 := "1234", "foobar", "foobar"
-
-	// create two users and get their info
-	i1, _, err := cstore.CreateMnemonic(n1, English, p1, algo)
-	require.Nil(t, err)
-
-	i2, _, err := cstore.CreateMnemonic(n2, English, p2, algo)
-	require.Nil(t, err)
-
-	// Import a public key
-	armor, err := cstore.ExportPubKey(n2)
-	require.Nil(t, err)
-	cstore.ImportPubKey(n3, armor)
-	i3, err := cstore.Get(n3)
-	require.NoError(t, err)
-	require.Equal(t, i3.GetName(), n3)
-
-	// let's try to sign some messages
-	d1 := []byte("my first message")
-	d2 := []byte("some other important info!")
-	d3 := []byte("feels like I forgot something...")
-
-	// try signing both data with both ..
-	s11, pub1, err := cstore.Sign(n1, p1, d1)
-	require.Nil(t, err)
-	require.Equal(t, i1.GetPubKey(), pub1)
-
-	s12, pub1, err := cstore.Sign(n1, p1, d2)
-	require.Nil(t, err)
-	require.Equal(t, i1.GetPubKey(), pub1)
-
-	s21, pub2, err := cstore.Sign(n2, p2, d1)
-	require.Nil(t, err)
-	require.Equal(t, i2.GetPubKey(), pub2)
-
-	s22, pub2, err := cstore.Sign(n2, p2, d2)
-	require.Nil(t, err)
-	require.Equal(t, i2.GetPubKey(), pub2)
-
-	// let's try to validate and make sure it only works when everything is proper
-	cases := []struct {
-		key   crypto.PubKey
-		data  []byte
-		sig   []byte
-		valid bool
-	}{
-		// proper matches
-		{i1.GetPubKey(), d1, s11, true},
-		// change data, pubkey, or signature leads to fail
-		{i1.GetPubKey(), d2, s11, false},
-		{i2.GetPubKey(), d1, s11, false},
-		{i1.GetPubKey(), d1, s21, false},
-		// make sure other successes
-		{i1.GetPubKey(), d2, s12, true},
-		{i2.GetPubKey(), d1, s21, true},
-		{i2.GetPubKey(), d2, s22, true},
-	}
-
-	for i, tc := range cases {
-		valid := tc.key.VerifyBytes(tc.data, tc.sig)
-		require.Equal(t, tc.valid, valid, "%d", i)
-	}
-
-	// Now try to sign data with a secret-less key
-	_, _, err = cstore.Sign(n3, p3, d3)
-	require.NotNil(t, err)
+	forEachBackend(t, func(t *testing.T, cstore Keybase, protected bool) {
+		algo := Secp256k1
+
+		n1, n2, n3 := "some dude", "a dudette", "dude-ish"
+		p1, p2, p3 := "1234", "foobar", "foobar"
+
+		// create two users and get their info
+		i1, _, err := cstore.CreateMnemonic(n1, English, p1, algo)
+		require.Nil(t, err)
+
+		i2, _, err := cstore.CreateMnemonic(n2, English, p2, algo)
+		require.Nil(t, err)
+
+		// Import a public key
+		armor, err := cstore.ExportPubKey(n2)
+		require.Nil(t, err)
+		cstore.ImportPubKey(n3, armor)
+		i3, err := cstore.Get(n3)
+		require.NoError(t, err)
+		require.Equal(t, i3.GetName(), n3)
+
+		// let's try to sign some messages
+		d1 := []byte("my first message")
+		d2 := []byte("some other important info!")
+		d3 := []byte("feels like I forgot something...")
+
+		// try signing both data with both ..
+		s11, pub1, err := cstore.Sign(n1, p1, d1)
+		require.Nil(t, err)
+		require.Equal(t, i1.GetPubKey(), pub1)
+
+		s12, pub1, err := cstore.Sign(n1, p1, d2)
+		require.Nil(t, err)
+		require.Equal(t, i1.GetPubKey(), pub1)
+
+		s21, pub2, err := cstore.Sign(n2, p2, d1)
+		require.Nil(t, err)
+		require.Equal(t, i2.GetPubKey(), pub2)
+
+		s22, pub2, err := cstore.Sign(n2, p2, d2)
+		require.Nil(t, err)
+		require.Equal(t, i2.GetPubKey(), pub2)
+
+		// let's try to validate and make sure it only works when everything is proper
+		cases := []struct {
+			key   crypto.PubKey
+			data  []byte
+			sig   []byte
+			valid bool
+		}{
+			// proper matches
+			{i1.GetPubKey(), d1, s11, true},
+			// change data, pubkey, or signature leads to fail
+			{i1.GetPubKey(), d2, s11, false},
+			{i2.GetPubKey(), d1, s11, false},
+			{i1.GetPubKey(), d1, s21, false},
+			// make sure other successes
+			{i1.GetPubKey(), d2, s12, true},
+			{i2.GetPubKey(), d1, s21, true},
+			{i2.GetPubKey(), d2, s22, true},
+		}
+
+		for i, tc := range cases {
+			valid := tc.key.VerifyBytes(tc.data, tc.sig)
+			require.Equal(t, tc.valid, valid, "%d", i)
+		}
+
+		// Now try to sign data with a secret-less key
+		_, _, err = cstore.Sign(n3, p3, d3)
+		require.NotNil(t, err)
+	})
 }
 
-func assertPassword(t *testing.T, cstore Keybase, name, pass, badpass string) {
+func assertPassword(t *testing.T, cstore Keybase, protected bool, name, pass, badpass string) {
 	getNewpass := func() (string, error) { return pass, nil }
-	err := cstore.Update(name, badpass, getNewpass)
-	require.NotNil(t, err)
-	err = cstore.Update(name, pass, getNewpass)
+	if protected {
+		err := cstore.Update(name, badpass, getNewpass)
+		require.NotNil(t, err)
+	}
+	err := cstore.Update(name, pass, getNewpass)
 	require.Nil(t, err, "%+v", err)
 }
 
 // TestExportImport tests exporting and importing
 func TestExportImport(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, cstore Keybase, protected bool) {
+		info, _, err := cstore.CreateMnemonic("john", English, "secretcpw", Secp256k1)
+		require.NoError(t, err)
+		require.Equal(t, info.GetName(), "john")
 
-	// make the storage with reasonable defaults
-	db := dbm.NewMemDB()
-	cstore := New(
-		db,
-	)
-
-	info, _, err := cstore.CreateMnemonic("john", English, "secretcpw", Secp256k1)
-	require.NoError(t, err)
-	require.Equal(t, info.GetName(), "john")
-
-	john, err := cstore.Get("john")
-	require.NoError(t, err)
-	require.Equal(t, info.GetName(), "john")
-	johnAddr := info.GetPubKey().Address()
+		john, err := cstore.Get("john")
+		require.NoError(t, err)
+		require.Equal(t, info.GetName(), "john")
+		johnAddr := info.GetPubKey().Address()
 
-	armor, err := cstore.Export("john")
-	require.NoError(t, err)
+		armor, err := cstore.Export("john")
+		require.NoError(t, err)
 
-	err = cstore.Import("john2", armor)
-	require.NoError(t, err)
+		err = cstore.Import("john2", armor)
+		require.NoError(t, err)
 
-	john2, err := cstore.Get("john2")
-	require.NoError(t, err)
+		john2, err := cstore.Get("john2")
+		require.NoError(t, err)
 
-	require.Equal(t, john.GetPubKey().Address(), johnAddr)
-	require.Equal(t, john.GetName(), "john")
-	require.Equal(t, john, john2)
+		require.Equal(t, john.GetPubKey().Address(), johnAddr)
+		require.Equal(t, john.GetName(), "john")
+		require.Equal(t, john, john2)
+	})
 }
 
 //
 func TestExportImportPubKey(t *testing.T) {
-	// make the storage with reasonable defaults
-	db := dbm.NewMemDB()
-	cstore := New(
-		db,
-	)
+	forEachBackend(t, func(t *testing.T, cstore Keybase, protected bool) {
+		// CreateMnemonic a private-public key pair and ensure consistency
+		notPasswd := "n9y25ah7"
+		info, _, err := cstore.CreateMnemonic("john", English, notPasswd, Secp256k1)
+		require.Nil(t, err)
+		require.NotEqual(t, info, "")
+		require.Equal(t, info.GetName(), "john")
+		addr := info.GetPubKey().Address()
+		john, err := cstore.Get("john")
+		require.NoError(t, err)
+		require.Equal(t, john.GetName(), "john")
+		require.Equal(t, john.GetPubKey().Address(), addr)
+
+		// Export the public key only
+		armor, err := cstore.ExportPubKey("john")
+		require.NoError(t, err)
+		// Import it under a different name
+		err = cstore.ImportPubKey("john-pubkey-only", armor)
+		require.NoError(t, err)
+		// Ensure consistency
+		john2, err := cstore.Get("john-pubkey-only")
+		require.NoError(t, err)
+		// Compare the public keys
+		require.True(t, john.GetPubKey().Equals(john2.GetPubKey()))
+		// Ensure the original key hasn't changed
+		john, err = cstore.Get("john")
+		require.NoError(t, err)
+		require.Equal(t, john.GetPubKey().Address(), addr)
+		require.Equal(t, john.GetName(), "john")
+
+		// Ensure keys cannot be overwritten
+		err = cstore.ImportPubKey("john-pubkey-only", armor)
+		require.NotNil(t, err)
+	})
+}
 
-	// CreateMnemonic a private-public key pair and ensure consistency
-	notPasswd := "n9y25ah7"
-	info, _, err := cstore.CreateMnemonic("john", English, notPasswd, Secp256k1)
-	require.Nil(t, err)
-	require.NotEqual(t, info, "")
-	require.Equal(t, info.GetName(), "john")
-	addr := info.GetPubKey().Address()
-	john, err := cstore.Get("john")
-	require.NoError(t, err)
-	require.Equal(t, john.GetName(), "john")
-	require.Equal(t, john.GetPubKey().Address(), addr)
+// TestAdvancedKeyManagement verifies update, import, export functionality
+func TestAdvancedKeyManagement(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, cstore Keybase, protected bool) {
+		algo := Secp256k1
+		n1, n2 := "old-name", "new name"
+		p1, p2 := "1234", "foobar"
+
+		// make sure key works with initial password
+		_, _, err := cstore.CreateMnemonic(n1, English, p1, algo)
+		require.Nil(t, err, "%+v", err)
+		assertPassword(t, cstore, protected, n1, p1, p2)
+
+		// update password requires the existing password
+		getNewpass := func() (string, error) { return p2, nil }
+		if protected {
+			err = cstore.Update(n1, "jkkgkg", getNewpass)
+			require.NotNil(t, err)
+		}
+		assertPassword(t, cstore, protected, n1, p1, p2)
+
+		// then it changes the password when correct
+		err = cstore.Update(n1, p1, getNewpass)
+		require.NoError(t, err)
+		// p2 is now the proper one!
+		assertPassword(t, cstore, protected, n1, p2, p1)
+
+		// exporting requires the proper name and passphrase
+		_, err = cstore.Export(n1 + ".notreal")
+		require.NotNil(t, err)
+		_, err = cstore.Export(" " + n1)
+		require.NotNil(t, err)
+		_, err = cstore.Export(n1 + " ")
+		require.NotNil(t, err)
+		_, err = cstore.Export("")
+		require.NotNil(t, err)
+		exported, err := cstore.Export(n1)
+		require.Nil(t, err, "%+v", err)
+
+		// import succeeds
+		err = cstore.Import(n2, exported)
+		require.NoError(t, err)
+
+		// second import fails
+		err = cstore.Import(n2, exported)
+		require.NotNil(t, err)
+	})
+}
 
-	// Export the public key only
-	armor, err := cstore.ExportPubKey("john")
-	require.NoError(t, err)
-	// Import it under a different name
-	err = cstore.ImportPubKey("john-pubkey-only", armor)
-	require.NoError(t, err)
-	// Ensure consistency
-	john2, err := cstore.Get("john-pubkey-only")
-	require.NoError(t, err)
-	// Compare the public keys
-	require.True(t, john.GetPubKey().Equals(john2.GetPubKey()))
-	// Ensure the original key hasn't changed
-	john, err = cstore.Get("john")
-	require.NoError(t, err)
-	require.Equal(t, john.GetPubKey().Address(), addr)
-	require.Equal(t, john.GetName(), "john")
+// TestSeedPhrase verifies restoring from a seed phrase
+func TestSeedPhrase(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, cstore Keybase, protected bool) {
+		algo := Secp256k1
+		n1, n2 := "lost-key", "found-again"
+		p1, p2 := "1234", "foobar"
+
+		// make sure key works with initial password
+		info, mnemonic, err := cstore.CreateMnemonic(n1, English, p1, algo)
+		require.Nil(t, err, "%+v", err)
+		require.Equal(t, n1, info.GetName())
+		assert.NotEmpty(t, mnemonic)
+
+		// now, let us delete this key
+		err = cstore.Delete(n1, p1)
+		require.Nil(t, err, "%+v", err)
+		_, err = cstore.Get(n1)
+		require.NotNil(t, err)
+
+		// let us re-create it from the mnemonic-phrase
+		params := *hd.NewFundraiserParams(0, 0)
+		newInfo, err := cstore.Derive(n2, mnemonic, p2, params, algo)
+		require.NoError(t, err)
+		require.Equal(t, n2, newInfo.GetName())
+		require.Equal(t, info.GetPubKey().Address(), newInfo.GetPubKey().Address())
+		require.Equal(t, info.GetPubKey(), newInfo.GetPubKey())
+	})
+}
 
-	// Ensure keys cannot be overwritten
-	err = cstore.ImportPubKey("john-pubkey-only", armor)
-	require.NotNil(t, err)
+// TestEd25519Keys verifies deriving, persisting and signing with ed25519 keys
+func TestEd25519Keys(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, cstore Keybase, protected bool) {
+		n1, n2 := "ed", "ed-again"
+		p1 := "1234"
+
+		info, mnemonic, err := cstore.CreateMnemonic(n1, English, p1, Ed25519)
+		require.NoError(t, err)
+		require.Equal(t, Ed25519, info.GetAlgo())
+		require.IsType(t, ed25519.PubKeyEd25519{}, info.GetPubKey())
+
+		// the algorithm is persisted
+		stored, err := cstore.Get(n1)
+		require.NoError(t, err)
+		require.Equal(t, Ed25519, stored.GetAlgo())
+		require.Equal(t, info.GetPubKey(), stored.GetPubKey())
+
+		msg := []byte("hello")
+		sig, pub, err := cstore.Sign(n1, p1, msg)
+		require.NoError(t, err)
+		require.Equal(t, info.GetPubKey(), pub)
+		require.True(t, pub.VerifyBytes(msg, sig))
+
+		// the same key is derived again from the mnemonic, and another key
+		// from the secp256k1 algo
+		params := *hd.NewFundraiserParams(0, 0)
+		newInfo, err := cstore.Derive(n2, mnemonic, p1, params, Ed25519)
+		require.NoError(t, err)
+		require.Equal(t, info.GetPubKey(), newInfo.GetPubKey())
+		secpInfo, err := cstore.Derive(n2, mnemonic, p1, params, Secp256k1)
+		require.NoError(t, err)
+		require.Equal(t, Secp256k1, secpInfo.GetAlgo())
+		require.NotEqual(t, info.GetPubKey().Address(), secpInfo.GetPubKey().Address())
+	})
 }
 
-// TestAdvancedKeyManagement verifies update, import, export functionality
-func TestAdvancedKeyManagement(t *testing.T) {
+// TestWeb3ExportImport verifies moving keys through Web3 JSON keystores
+func TestWeb3ExportImport(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, cstore Keybase, protected bool) {
+		p1, p2, pExport := "1234", "foobar", "export"
+
+		for _, algo := range []SigningAlgo{Secp256k1, Ed25519} {
+			info, _, err := cstore.CreateMnemonic("john", English, p1, algo)
+			require.NoError(t, err)
+
+			if protected {
+				_, err = cstore.ExportWeb3("john", p2, pExport)
+				require.Error(t, err)
+			}
+			keyJSON, err := cstore.ExportWeb3("john", p1, pExport)
+			require.NoError(t, err)
+
+			_, err = cstore.ImportWeb3("john2", keyJSON, p1, p2)
+			require.Equal(t, ErrWeb3MACMismatch, err)
+			imported, err := cstore.ImportWeb3("john2", keyJSON, pExport, p2)
+			require.NoError(t, err)
+			require.Equal(t, TypeLocal, imported.GetType())
+			require.Equal(t, algo, imported.GetAlgo())
+			require.Equal(t, info.GetPubKey(), imported.GetPubKey())
+
+			// the imported key signs with the new passphrase
+			msg := []byte("hello")
+			sig, pub, err := cstore.Sign("john2", p2, msg)
+			require.NoError(t, err)
+			require.True(t, pub.VerifyBytes(msg, sig))
+
+			// existing keys are not overwritten
+			_, err = cstore.ImportWeb3("john", keyJSON, pExport, p2)
+			require.Error(t, err)
+
+			require.NoError(t, cstore.Delete("john", p1))
+			require.NoError(t, cstore.Delete("john2", p2))
+		}
+	})
+}
 
-	// make the storage with reasonable defaults
-	cstore := New(
-		dbm.NewMemDB(),
-	)
+// TestMnemonicLanguages verifies recovering keys from mnemonics created in
+// each language
+func TestMnemonicLanguages(t *testing.T) {
+	cstore := New(dbm.NewMemDB())
+	p1 := "1234"
+
+	for l := English; l <= Italian; l++ {
+		parsed, err := ParseLanguage(l.String())
+		require.NoError(t, err)
+		require.Equal(t, l, parsed)
+
+		info, mnemonic, err := cstore.CreateMnemonic("created", l, p1, Secp256k1)
+		require.NoError(t, err, l.String())
+
+		// the language is detected on recovery
+		recovered, err := cstore.CreateKey("recovered", mnemonic, p1)
+		require.NoError(t, err, l.String())
+		require.Equal(t, info.GetPubKey(), recovered.GetPubKey())
+		derived, err := cstore.Derive("derived", mnemonic, p1, *hd.NewFundraiserParams(0, 0), Secp256k1)
+		require.NoError(t, err, l.String())
+		require.Equal(t, info.GetPubKey(), derived.GetPubKey())
+	}
 
-	algo := Secp256k1
-	n1, n2 := "old-name", "new name"
-	p1, p2 := "1234", "foobar"
+	_, err := ParseLanguage("klingon")
+	require.Equal(t, ErrUnsupportedLanguage, err)
+	_, _, err = cstore.CreateMnemonic("unknown", Language(42), p1, Secp256k1)
+	require.Equal(t, ErrUnsupportedLanguage, err)
 
-	// make sure key works with initial password
-	_, _, err := cstore.CreateMnemonic(n1, English, p1, algo)
-	require.Nil(t, err, "%+v", err)
-	assertPassword(t, cstore, n1, p1, p2)
+	_, mnemonic, err := cstore.CreateMnemonic("spanish", Spanish, p1, Secp256k1)
+	require.NoError(t, err)
+	detected, err := DetectLanguage(mnemonic)
+	require.NoError(t, err)
+	require.Equal(t, Spanish, detected)
+	_, err = DetectLanguage("cosmos " + mnemonic)
+	require.Error(t, err)
+}
 
-	// update password requires the existing password
-	getNewpass := func() (string, error) { return p2, nil }
-	err = cstore.Update(n1, "jkkgkg", getNewpass)
-	require.NotNil(t, err)
-	assertPassword(t, cstore, n1, p1, p2)
+// TestFileKeybase checks that the keys stored in files can be read by another
+// keybase
+func TestFileKeybase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keybase")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
 
-	// then it changes the password when correct
-	err = cstore.Update(n1, p1, getNewpass)
+	cstore := NewFile(dir)
+	info, _, err := cstore.CreateMnemonic("john", English, "secretcpw", Secp256k1)
 	require.NoError(t, err)
-	// p2 is now the proper one!
-	assertPassword(t, cstore, n1, p2, p1)
-
-	// exporting requires the proper name and passphrase
-	_, err = cstore.Export(n1 + ".notreal")
-	require.NotNil(t, err)
-	_, err = cstore.Export(" " + n1)
-	require.NotNil(t, err)
-	_, err = cstore.Export(n1 + " ")
-	require.NotNil(t, err)
-	_, err = cstore.Export("")
-	require.NotNil(t, err)
-	exported, err := cstore.Export(n1)
-	require.Nil(t, err, "%+v", err)
 
-	// import succeeds
-	err = cstore.Import(n2, exported)
+	// the key is stored in a JSON file, encrypted with scrypt
+	bz, err := ioutil.ReadFile(filepath.Join(dir, "john.info"))
 	require.NoError(t, err)
+	var stored Info
+	require.NoError(t, cdc.UnmarshalJSON(bz, &stored))
+	require.Contains(t, stored.(localInfo).PrivKeyArmor, "kdf: scrypt")
 
-	// second import fails
-	err = cstore.Import(n2, exported)
-	require.NotNil(t, err)
-}
+	// the file can be copied to another directory
+	dir2, err := ioutil.TempDir("", "keybase")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir2)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir2, "john.info"), bz, 0600))
 
-// TestSeedPhrase verifies restoring from a seed phrase
-func TestSeedPhrase(t *testing.T) {
+	cstore2 := NewFile(dir2)
+	msg := []byte("hello")
+	sig, pub, err := cstore2.Sign("john", "secretcpw", msg)
+	require.NoError(t, err)
+	require.Equal(t, info.GetPubKey(), pub)
+	require.True(t, pub.VerifyBytes(msg, sig))
+	_, _, err = cstore2.Sign("john", "wrong", msg)
+	require.Error(t, err)
+}
 
-	// make the storage with reasonable defaults
-	cstore := New(
-		dbm.NewMemDB(),
-	)
+// TestScryptParamsBounds checks that imported keys cannot make deriving their
+// key use unbounded memory or time
+func TestScryptParamsBounds(t *testing.T) {
+	priv := ed25519.GenPrivKey()
+	armorStr := encryptArmorPrivKey(priv, "secretcpw", kdfScrypt)
+	_, err := unarmorDecryptPrivKey(armorStr, "secretcpw", kdfScrypt)
+	require.NoError(t, err)
 
-	algo := Secp256k1
-	n1, n2 := "lost-key", "found-again"
-	p1, p2 := "1234", "foobar"
+	for _, params := range [][3]int{
+		{1 << 30, 8, 1},
+		{1 << 18, 16, 1},
+		{2, 1, 1 << 30},
+		{0, 8, 1},
+	} {
+		_, header, encBytes, err := armor.DecodeArmor(armorStr)
+		require.NoError(t, err)
+		header["n"] = strconv.Itoa(params[0])
+		header["r"] = strconv.Itoa(params[1])
+		header["p"] = strconv.Itoa(params[2])
+		_, err = unarmorDecryptPrivKey(armor.EncodeArmor(blockTypePrivKey, header, encBytes), "secretcpw", kdfScrypt)
+		require.Error(t, err, "%v", params)
+		require.Contains(t, err.Error(), "out of range")
+	}
+}
 
-	// make sure key works with initial password
-	info, mnemonic, err := cstore.CreateMnemonic(n1, English, p1, algo)
-	require.Nil(t, err, "%+v", err)
-	require.Equal(t, n1, info.GetName())
-	assert.NotEmpty(t, mnemonic)
+// TestTestKeybase checks that the test keybase needs no passphrase
+func TestTestKeybase(t *testing.T) {
+	cstore := NewTest(dbm.NewMemDB())
+	info, _, err := cstore.CreateMnemonic("john", English, "", Secp256k1)
+	require.NoError(t, err)
+	require.Equal(t, TypeLocal, info.GetType())
 
-	// now, let us delete this key
-	err = cstore.Delete(n1, p1)
-	require.Nil(t, err, "%+v", err)
-	_, err = cstore.Get(n1)
-	require.NotNil(t, err)
+	msg := []byte("hello")
+	sig, pub, err := cstore.Sign("john", "", msg)
+	require.NoError(t, err)
+	require.True(t, pub.VerifyBytes(msg, sig))
 
-	// let us re-create it from the mnemonic-phrase
-	params := *hd.NewFundraiserParams(0, 0)
-	newInfo, err := cstore.Derive(n2, mnemonic, p2, params)
+	// the unencrypted key cannot be imported by other keybases
+	armorStr, err := cstore.Export("john")
 	require.NoError(t, err)
-	require.Equal(t, n2, newInfo.GetName())
-	require.Equal(t, info.GetPubKey().Address(), newInfo.GetPubKey().Address())
-	require.Equal(t, info.GetPubKey(), newInfo.GetPubKey())
+	protected := New(dbm.NewMemDB())
+	err = protected.Import("john", armorStr)
+	require.Error(t, err)
+	_, err = protected.Get("john")
+	require.Error(t, err)
+
+	// nor used by them if it is stored anyway
+	protected.(dbKeybase).writeInfo(info, "john")
+	_, _, err = protected.Sign("john", "", msg)
+	require.Error(t, err)
+	_, err = protected.ExportPrivateKeyObject("john", "")
+	require.Error(t, err)
 }
 
 func ExampleNew() {
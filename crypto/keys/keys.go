@@ -6,7 +6,8 @@ type SigningAlgo string
 const (
 	// Secp256k1 uses the Bitcoin secp256k1 ECDSA parameters.
 	Secp256k1 = SigningAlgo("secp256k1")
-	// Ed25519 represents the Ed25519 signature system.
-	// It is currently not supported for end-user keys (wallets/ledgers).
+	// Ed25519 represents the Ed25519 signature system, with keys derived as
+	// specified by SLIP-0010.
+	// It is currently not supported for Ledger keys.
 	Ed25519 = SigningAlgo("ed25519")
 )
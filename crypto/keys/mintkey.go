@@ -2,9 +2,12 @@ package keys
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"strconv"
 
 	cmn "github.com/tendermint/tendermint/libs/common"
+	"golang.org/x/crypto/scrypt"
 
 	"github.com/cosmos/cosmos-sdk/crypto/keys/bcrypt"
 	"github.com/tendermint/tendermint/crypto"
@@ -13,6 +16,15 @@ import (
 	"github.com/tendermint/tendermint/crypto/xsalsa20symmetric"
 )
 
+// key derivation functions encrypting the armored private keys
+const (
+	kdfBcrypt = "bcrypt"
+	kdfScrypt = "scrypt"
+	kdfNone   = "none" // the private key is not encrypted
+)
+
+var errUnencryptedPrivKey = errors.New("the private key is not encrypted, it is only accepted by test keybases")
+
 const (
 	blockTypePrivKey = "TENDERMINT PRIVATE KEY"
 	blockTypeKeyInfo = "TENDERMINT KEY INFO"
@@ -34,6 +46,32 @@ const (
 // TODO: Consider increasing default
 var BcryptSecurityParameter = 12
 
+// ScryptN is the CPU/memory cost parameter of scrypt for the keys of a file
+// keybase. It is a var so that it can be lowered in tests, the parameters
+// used are stored with each key.
+var ScryptN = 1 << 15
+
+// the other scrypt parameters
+const (
+	scryptR = 8
+	scryptP = 1
+)
+
+// bounds of the scrypt parameters of imported keys, so that deriving their
+// key takes neither gigabytes of memory nor minutes
+const (
+	maxScryptMemory = 1 << 21 // n*r, the memory used is 128*n*r bytes
+	maxScryptCost   = 1 << 22 // n*r*p
+)
+
+func checkScryptParams(n, r, p int) error {
+	if n <= 0 || r <= 0 || p <= 0 ||
+		int64(n)*int64(r) > maxScryptMemory || int64(n)*int64(r)*int64(p) > maxScryptCost {
+		return fmt.Errorf("scrypt parameters n=%d, r=%d, p=%d are out of range", n, r, p)
+	}
+	return nil
+}
+
 func armorInfoBytes(bz []byte) string {
 	return armorBytes(bz, blockTypeKeyInfo)
 }
@@ -74,17 +112,36 @@ func unarmorBytes(armorStr, blockType string) (bz []byte, err error) {
 	return
 }
 
-func encryptArmorPrivKey(privKey crypto.PrivKey, passphrase string) string {
-	saltBytes, encBytes := encryptPrivKey(privKey, passphrase)
+func encryptArmorPrivKey(privKey crypto.PrivKey, passphrase, kdf string) string {
 	header := map[string]string{
-		"kdf":  "bcrypt",
-		"salt": fmt.Sprintf("%X", saltBytes),
+		"kdf": kdf,
+	}
+	var encBytes []byte
+	switch kdf {
+	case kdfBcrypt:
+		var saltBytes []byte
+		saltBytes, encBytes = encryptPrivKey(privKey, passphrase)
+		header["salt"] = fmt.Sprintf("%X", saltBytes)
+	case kdfScrypt:
+		var saltBytes []byte
+		saltBytes, encBytes = encryptPrivKeyScrypt(privKey, passphrase)
+		header["salt"] = fmt.Sprintf("%X", saltBytes)
+		header["n"] = strconv.Itoa(ScryptN)
+		header["r"] = strconv.Itoa(scryptR)
+		header["p"] = strconv.Itoa(scryptP)
+	case kdfNone:
+		encBytes = privKey.Bytes()
+	default:
+		panic(fmt.Sprintf("unknown KDF %q", kdf))
 	}
 	armorStr := armor.EncodeArmor(blockTypePrivKey, header, encBytes)
 	return armorStr
 }
 
-func unarmorDecryptPrivKey(armorStr string, passphrase string) (crypto.PrivKey, error) {
+// unarmorDecryptPrivKey decrypts the armored private key for a keybase
+// encrypting its keys with the given kdf. Unencrypted keys are only accepted
+// by the keybases storing them so, i.e. test keybases.
+func unarmorDecryptPrivKey(armorStr string, passphrase string, kdf string) (crypto.PrivKey, error) {
 	var privKey crypto.PrivKey
 	blockType, header, encBytes, err := armor.DecodeArmor(armorStr)
 	if err != nil {
@@ -93,8 +150,14 @@ func unarmorDecryptPrivKey(armorStr string, passphrase string) (crypto.PrivKey,
 	if blockType != blockTypePrivKey {
 		return privKey, fmt.Errorf("Unrecognized armor type: %v", blockType)
 	}
-	if header["kdf"] != "bcrypt" {
-		return privKey, fmt.Errorf("Unrecognized KDF type: %v", header["KDF"])
+	if header["kdf"] == kdfNone {
+		if kdf != kdfNone {
+			return privKey, errUnencryptedPrivKey
+		}
+		return cryptoAmino.PrivKeyFromBytes(encBytes)
+	}
+	if header["kdf"] != kdfBcrypt && header["kdf"] != kdfScrypt {
+		return privKey, fmt.Errorf("Unrecognized KDF type: %v", header["kdf"])
 	}
 	if header["salt"] == "" {
 		return privKey, fmt.Errorf("Missing salt bytes")
@@ -103,10 +166,26 @@ func unarmorDecryptPrivKey(armorStr string, passphrase string) (crypto.PrivKey,
 	if err != nil {
 		return privKey, fmt.Errorf("Error decoding salt: %v", err.Error())
 	}
+	if header["kdf"] == kdfScrypt {
+		return decryptPrivKeyScrypt(saltBytes, encBytes, passphrase, header)
+	}
 	privKey, err = decryptPrivKey(saltBytes, encBytes, passphrase)
 	return privKey, err
 }
 
+// checkArmorPrivKeyEncrypted returns an error if the armored private key is
+// not encrypted
+func checkArmorPrivKeyEncrypted(armorStr string) error {
+	_, header, _, err := armor.DecodeArmor(armorStr)
+	if err != nil {
+		return err
+	}
+	if header["kdf"] == kdfNone {
+		return errUnencryptedPrivKey
+	}
+	return nil
+}
+
 func encryptPrivKey(privKey crypto.PrivKey, passphrase string) (saltBytes []byte, encBytes []byte) {
 	saltBytes = crypto.CRandBytes(16)
 	key, err := bcrypt.GenerateFromPassword(saltBytes, []byte(passphrase), BcryptSecurityParameter)
@@ -131,3 +210,38 @@ func decryptPrivKey(saltBytes []byte, encBytes []byte, passphrase string) (privK
 	privKey, err = cryptoAmino.PrivKeyFromBytes(privKeyBytes)
 	return privKey, err
 }
+
+func encryptPrivKeyScrypt(privKey crypto.PrivKey, passphrase string) (saltBytes []byte, encBytes []byte) {
+	saltBytes = crypto.CRandBytes(32)
+	key, err := scrypt.Key([]byte(passphrase), saltBytes, ScryptN, scryptR, scryptP, 32)
+	if err != nil {
+		cmn.Exit("Error generating scrypt key from passphrase: " + err.Error())
+	}
+	privKeyBytes := privKey.Bytes()
+	return saltBytes, xsalsa20symmetric.EncryptSymmetric(privKeyBytes, key)
+}
+
+// decrypt a private key with the scrypt parameters of the armor header, so
+// that keys encrypted with other parameters can be imported
+func decryptPrivKeyScrypt(saltBytes []byte, encBytes []byte, passphrase string, header map[string]string) (privKey crypto.PrivKey, err error) {
+	var params [3]int
+	for i, name := range []string{"n", "r", "p"} {
+		params[i], err = strconv.Atoi(header[name])
+		if err != nil {
+			return privKey, fmt.Errorf("Error decoding scrypt parameter %s: %v", name, err.Error())
+		}
+	}
+	if err = checkScryptParams(params[0], params[1], params[2]); err != nil {
+		return privKey, err
+	}
+	key, err := scrypt.Key([]byte(passphrase), saltBytes, params[0], params[1], params[2], 32)
+	if err != nil {
+		return privKey, err
+	}
+	privKeyBytes, err := xsalsa20symmetric.DecryptSymmetric(encBytes, key)
+	if err != nil {
+		return privKey, err
+	}
+	privKey, err = cryptoAmino.PrivKeyFromBytes(privKeyBytes)
+	return privKey, err
+}
@@ -0,0 +1,213 @@
+package keys
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/cosmos/cosmos-sdk/crypto"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
+)
+
+var _ Keybase = remoteKeybase{}
+
+// DefaultRemoteTimeout is the time allowed to a remote signer to respond to a
+// request, including the time to dial it.
+const DefaultRemoteTimeout = 5 * time.Second
+
+// ErrRemoteUnsupported is raised when the caller tries to manage the keys of a
+// remote signer, which only lists its keys and signs with them.
+var ErrRemoteUnsupported = errors.New("unsupported operation: the keys of a remote signer are managed by the signer")
+
+// remoteKeybase forwards the operations on the keys to a signer daemon, which
+// keeps the private keys. Each request is sent on a new connection to the
+// signer, on which the signer writes its response.
+type remoteKeybase struct {
+	protocol string
+	address  string
+	signerID string           // ID of the signer, unless on a unix socket
+	privKey  tmcrypto.PrivKey // securing the connections, unless on a unix socket
+	timeout  time.Duration
+}
+
+// NewRemote creates a new keybase instance forwarding to the signer daemon
+// listening at the given address, in the form unix://<path> or
+// tcp://<id>@<host>:<port>. The connections to a signer listening on TCP are
+// secured with privKey, and the signer must have the ID in the address.
+func NewRemote(addr string, privKey tmcrypto.PrivKey) (Keybase, error) {
+	protocol, address := cmn.ProtocolAndAddress(addr)
+	kb := remoteKeybase{
+		protocol: protocol,
+		address:  address,
+		timeout:  DefaultRemoteTimeout,
+	}
+	if protocol == "unix" {
+		return kb, nil
+	}
+
+	parts := strings.SplitN(address, "@", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return nil, fmt.Errorf("the address of the remote signer must hold its ID: %s://<id>@%s", protocol, address)
+	}
+	if privKey == nil {
+		return nil, errors.New("the remote keybase requires a private key to secure its connections")
+	}
+	kb.signerID, kb.address, kb.privKey = strings.ToLower(parts[0]), parts[1], privKey
+	return kb, nil
+}
+
+// List returns the keys of the signer.
+func (kb remoteKeybase) List() ([]Info, error) {
+	res, err := kb.call(&ListKeysRequest{})
+	if err != nil {
+		return nil, err
+	}
+	list, ok := res.(*ListKeysResponse)
+	if !ok {
+		return nil, unexpectedRemoteResponse(res)
+	}
+
+	infos := make([]Info, len(list.Keys))
+	for i, key := range list.Keys {
+		infos[i] = key
+	}
+	return infos, nil
+}
+
+// Get returns the public information about one key of the signer.
+func (kb remoteKeybase) Get(name string) (Info, error) {
+	res, err := kb.call(&GetKeyRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	key, ok := res.(*GetKeyResponse)
+	if !ok {
+		return nil, unexpectedRemoteResponse(res)
+	}
+	return key.Key, nil
+}
+
+// Sign has the signer sign the msg with the named key, which it decrypts with
+// the passphrase.
+func (kb remoteKeybase) Sign(name, passphrase string, msg []byte) ([]byte, tmcrypto.PubKey, error) {
+	res, err := kb.call(&SignRequest{Name: name, Passphrase: passphrase, Msg: msg})
+	if err != nil {
+		return nil, nil, err
+	}
+	signed, ok := res.(*SignResponse)
+	if !ok {
+		return nil, nil, unexpectedRemoteResponse(res)
+	}
+	if signed.PubKey == nil || !signed.PubKey.VerifyBytes(msg, signed.Signature) {
+		return nil, nil, errors.New("invalid signature returned by the remote signer")
+	}
+	return signed.Signature, signed.PubKey, nil
+}
+
+func (kb remoteKeybase) Delete(name, passphrase string) error {
+	return ErrRemoteUnsupported
+}
+
+func (kb remoteKeybase) CreateMnemonic(name string, language Language, passwd string, algo SigningAlgo) (Info, string, error) {
+	return nil, "", ErrRemoteUnsupported
+}
+
+func (kb remoteKeybase) CreateKey(name, mnemonic, passwd string) (Info, error) {
+	return nil, ErrRemoteUnsupported
+}
+
+func (kb remoteKeybase) CreateFundraiserKey(name, mnemonic, passwd string) (Info, error) {
+	return nil, ErrRemoteUnsupported
+}
+
+func (kb remoteKeybase) Derive(name, mnemonic, passwd string, params hd.BIP44Params, algo SigningAlgo) (Info, error) {
+	return nil, ErrRemoteUnsupported
+}
+
+func (kb remoteKeybase) CreateLedger(name string, path crypto.DerivationPath, algo SigningAlgo) (Info, error) {
+	return nil, ErrRemoteUnsupported
+}
+
+func (kb remoteKeybase) CreateOffline(name string, pubkey tmcrypto.PubKey) (Info, error) {
+	return nil, ErrRemoteUnsupported
+}
+
+func (kb remoteKeybase) Update(name, oldpass string, getNewpass func() (string, error)) error {
+	return ErrRemoteUnsupported
+}
+
+func (kb remoteKeybase) Import(name string, armor string) error {
+	return ErrRemoteUnsupported
+}
+
+func (kb remoteKeybase) ImportPubKey(name string, armor string) error {
+	return ErrRemoteUnsupported
+}
+
+func (kb remoteKeybase) Export(name string) (string, error) {
+	return "", ErrRemoteUnsupported
+}
+
+func (kb remoteKeybase) ExportPubKey(name string) (string, error) {
+	return "", ErrRemoteUnsupported
+}
+
+func (kb remoteKeybase) ExportWeb3(name, passphrase, exportPassphrase string) ([]byte, error) {
+	return nil, ErrRemoteUnsupported
+}
+
+func (kb remoteKeybase) ImportWeb3(name string, keyJSON []byte, importPassphrase, passphrase string) (Info, error) {
+	return nil, ErrRemoteUnsupported
+}
+
+func (kb remoteKeybase) ExportPrivateKeyObject(name string, passphrase string) (tmcrypto.PrivKey, error) {
+	return nil, ErrRemoteUnsupported
+}
+
+// send a request to the signer and read its response, returning the error
+// returned by the signer if any
+func (kb remoteKeybase) call(req RemoteSignerMsg) (RemoteSignerMsg, error) {
+	conn, err := net.DialTimeout(kb.protocol, kb.address, kb.timeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to the remote signer")
+	}
+	defer conn.Close()
+
+	if err = conn.SetDeadline(time.Now().Add(kb.timeout)); err != nil {
+		return nil, err
+	}
+	var rw io.ReadWriter = conn
+	if kb.protocol != "unix" {
+		sc, id, err := makeSecretConnection(conn, kb.privKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to secure the connection to the remote signer")
+		}
+		if id != kb.signerID {
+			return nil, fmt.Errorf("the remote signer has ID %s, expected %s", id, kb.signerID)
+		}
+		rw = sc
+	}
+
+	if err = writeRemoteSignerMsg(rw, req); err != nil {
+		return nil, err
+	}
+	res, err := readRemoteSignerMsg(rw)
+	if err != nil {
+		return nil, err
+	}
+
+	if remoteErr, ok := res.(*RemoteSignerError); ok {
+		return nil, remoteErr
+	}
+	return res, nil
+}
+
+func unexpectedRemoteResponse(res RemoteSignerMsg) error {
+	return fmt.Errorf("unexpected response from the remote signer: %T", res)
+}
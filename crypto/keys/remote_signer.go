@@ -0,0 +1,273 @@
+package keys
+
+import (
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/log"
+	p2pconn "github.com/tendermint/tendermint/p2p/conn"
+)
+
+// Wire protocol of the remote signer: the client writes a request on a
+// connection to the signer, which writes back the response or a
+// RemoteSignerError. Messages are Amino encoded and length prefixed.
+//
+// The requests carry the passphrases of the keys, and whoever can send them
+// can sign with the keys. On a unix socket, the connections are neither
+// encrypted nor authenticated: only the local users the permissions of the
+// socket allow can connect to the signer. On TCP, the connections are
+// encrypted and authenticated with a SecretConnection: the client checks the
+// pubkey of the signer against the ID in the address of the signer, and the
+// signer only serves the clients whose ID it allows. The ID of a party is the
+// hex address of its pubkey, see RemoteSignerID.
+
+// maximum size of a message of the remote signer protocol
+const maxRemoteSignerMsgSize = 1024 * 1024
+
+// RemoteSignerMsg is a request or response of the remote signer protocol.
+type RemoteSignerMsg interface{}
+
+// ListKeysRequest requests the keys of the signer.
+type ListKeysRequest struct{}
+
+// ListKeysResponse holds the keys of the signer.
+type ListKeysResponse struct {
+	Keys []remoteInfo
+}
+
+// GetKeyRequest requests a key of the signer.
+type GetKeyRequest struct {
+	Name string
+}
+
+// GetKeyResponse holds a key of the signer.
+type GetKeyResponse struct {
+	Key remoteInfo
+}
+
+// SignRequest requests a signature of Msg with a key of the signer.
+type SignRequest struct {
+	Name       string
+	Passphrase string
+	Msg        []byte
+}
+
+// SignResponse holds a signature and the public key to verify it with.
+type SignResponse struct {
+	Signature []byte
+	PubKey    tmcrypto.PubKey
+}
+
+// RemoteSignerError is returned by the signer when a request fails.
+type RemoteSignerError struct {
+	Description string
+}
+
+func (e *RemoteSignerError) Error() string {
+	return "remote signer error: " + e.Description
+}
+
+func readRemoteSignerMsg(r io.Reader) (msg RemoteSignerMsg, err error) {
+	_, err = cdc.UnmarshalBinaryReader(r, &msg, maxRemoteSignerMsgSize)
+	return
+}
+
+func writeRemoteSignerMsg(w io.Writer, msg RemoteSignerMsg) (err error) {
+	_, err = cdc.MarshalBinaryWriter(w, msg)
+	return
+}
+
+// RemoteSignerID returns the ID of a signer or client of the remote signer
+// protocol, the hex address of its pubkey.
+func RemoteSignerID(pubKey tmcrypto.PubKey) string {
+	return hex.EncodeToString(pubKey.Address())
+}
+
+// secure the connection to a party of the remote signer protocol, returning
+// the ID of the party
+func makeSecretConnection(conn net.Conn, privKey tmcrypto.PrivKey) (*p2pconn.SecretConnection, string, error) {
+	sc, err := p2pconn.MakeSecretConnection(conn, privKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return sc, RemoteSignerID(sc.RemotePubKey()), nil
+}
+
+//----------------------------------------
+// RemoteSigner
+
+// RemoteSigner is a signer daemon serving the keys of a Keybase to clients
+// using a keybase created with NewRemote. It never exposes the private keys.
+type RemoteSigner struct {
+	cmn.BaseService
+
+	kb             Keybase
+	listener       net.Listener
+	privKey        tmcrypto.PrivKey
+	allowedClients map[string]bool
+}
+
+const (
+	// time allowed to a client to secure its connection
+	remoteSignerHandshakeTimeout = 5 * time.Second
+	// time allowed to a client to send a request once connected or after the
+	// previous response, after which the connection is closed
+	remoteSignerReadTimeout = 10 * time.Second
+)
+
+// NewRemoteSigner returns a signer serving the keys of kb to the connections
+// accepted by the listener. Unless the listener is a unix socket listener,
+// the connections are secured with privKey and only the clients whose ID is
+// in allowedClients are served.
+func NewRemoteSigner(kb Keybase, listener net.Listener, privKey tmcrypto.PrivKey,
+	allowedClients []string, logger log.Logger) (*RemoteSigner, error) {
+
+	rs := &RemoteSigner{
+		kb:             kb,
+		listener:       listener,
+		privKey:        privKey,
+		allowedClients: make(map[string]bool),
+	}
+	if !rs.isUnix() {
+		if privKey == nil {
+			return nil, errors.New("the remote signer requires a private key to secure its connections")
+		}
+		if len(allowedClients) == 0 {
+			return nil, errors.New("the remote signer requires the IDs of the clients it serves")
+		}
+	}
+	for _, id := range allowedClients {
+		rs.allowedClients[strings.ToLower(id)] = true
+	}
+	rs.BaseService = *cmn.NewBaseService(logger, "RemoteSigner", rs)
+	return rs, nil
+}
+
+func (rs *RemoteSigner) isUnix() bool {
+	return rs.listener.Addr().Network() == "unix"
+}
+
+// OnStart implements cmn.Service.
+func (rs *RemoteSigner) OnStart() error {
+	go rs.acceptConnections()
+	return nil
+}
+
+// OnStop implements cmn.Service.
+func (rs *RemoteSigner) OnStop() {
+	if err := rs.listener.Close(); err != nil {
+		rs.Logger.Error("error closing the listener", "err", err)
+	}
+}
+
+func (rs *RemoteSigner) acceptConnections() {
+	for {
+		conn, err := rs.listener.Accept()
+		if err != nil {
+			if !rs.IsRunning() {
+				return
+			}
+			rs.Logger.Error("error accepting a connection", "err", err)
+			continue
+		}
+		go rs.handleConnection(conn)
+	}
+}
+
+// serve the requests written on a connection until it is closed
+func (rs *RemoteSigner) handleConnection(conn net.Conn) {
+	defer conn.Close()
+
+	var rw io.ReadWriter = conn
+	if !rs.isUnix() {
+		if err := conn.SetDeadline(time.Now().Add(remoteSignerHandshakeTimeout)); err != nil {
+			rs.Logger.Error("error setting the handshake deadline", "err", err)
+			return
+		}
+		sc, id, err := makeSecretConnection(conn, rs.privKey)
+		if err != nil {
+			rs.Logger.Error("error securing a connection", "err", err)
+			return
+		}
+		if !rs.allowedClients[id] {
+			rs.Logger.Error("rejected a client", "id", id, "addr", conn.RemoteAddr())
+			return
+		}
+		if err = conn.SetDeadline(time.Time{}); err != nil {
+			rs.Logger.Error("error clearing the handshake deadline", "err", err)
+			return
+		}
+		rw = sc
+	}
+
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(remoteSignerReadTimeout)); err != nil {
+			rs.Logger.Error("error setting the read deadline", "err", err)
+			return
+		}
+		req, err := readRemoteSignerMsg(rw)
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				rs.Logger.Debug("closing an idle connection", "addr", conn.RemoteAddr())
+			} else if err != io.EOF {
+				rs.Logger.Error("error reading a request", "err", err)
+			}
+			return
+		}
+
+		res := rs.handleRequest(req)
+		if err = writeRemoteSignerMsg(rw, res); err != nil {
+			rs.Logger.Error("error writing a response", "err", err)
+			return
+		}
+	}
+}
+
+func (rs *RemoteSigner) handleRequest(req RemoteSignerMsg) RemoteSignerMsg {
+	switch req := req.(type) {
+	case *ListKeysRequest:
+		infos, err := rs.kb.List()
+		if err != nil {
+			return &RemoteSignerError{Description: err.Error()}
+		}
+		keys := make([]remoteInfo, len(infos))
+		for i, info := range infos {
+			keys[i] = remoteInfo{Name: info.GetName(), PubKey: info.GetPubKey(), Algo: info.GetAlgo()}
+		}
+		return &ListKeysResponse{Keys: keys}
+
+	case *GetKeyRequest:
+		info, err := rs.kb.Get(req.Name)
+		if err != nil {
+			return &RemoteSignerError{Description: err.Error()}
+		}
+		return &GetKeyResponse{Key: remoteInfo{Name: info.GetName(), PubKey: info.GetPubKey(), Algo: info.GetAlgo()}}
+
+	case *SignRequest:
+		// signing with an offline key would wait for the signature on the
+		// standard input of the signer
+		info, err := rs.kb.Get(req.Name)
+		if err != nil {
+			return &RemoteSignerError{Description: err.Error()}
+		}
+		if info.GetType() == TypeOffline {
+			return &RemoteSignerError{Description: "private key not available"}
+		}
+
+		rs.Logger.Info("signing", "key", req.Name)
+		sig, pub, err := rs.kb.Sign(req.Name, req.Passphrase, req.Msg)
+		if err != nil {
+			return &RemoteSignerError{Description: err.Error()}
+		}
+		return &SignResponse{Signature: sig, PubKey: pub}
+
+	default:
+		return &RemoteSignerError{Description: "unknown request"}
+	}
+}
@@ -0,0 +1,128 @@
+package keys
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestRemoteKeybase(t *testing.T) {
+	local := New(dbm.NewMemDB())
+	n1, n2, o1 := "personal", "business", "offline"
+	p1, p2 := "1234", "really-secure!@#$"
+
+	i1, _, err := local.CreateMnemonic(n1, English, p1, Secp256k1)
+	require.NoError(t, err)
+	i2, _, err := local.CreateMnemonic(n2, English, p2, Secp256k1)
+	require.NoError(t, err)
+	_, err = local.CreateOffline(o1, ed25519.GenPrivKey().PubKey())
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	signerKey, clientKey := ed25519.GenPrivKey(), ed25519.GenPrivKey()
+	signerID, clientID := RemoteSignerID(signerKey.PubKey()), RemoteSignerID(clientKey.PubKey())
+
+	// on TCP, the signer secures its connections and only serves the clients allowed
+	_, err = NewRemoteSigner(local, listener, nil, []string{clientID}, log.NewNopLogger())
+	require.Error(t, err)
+	_, err = NewRemoteSigner(local, listener, signerKey, nil, log.NewNopLogger())
+	require.Error(t, err)
+	signer, err := NewRemoteSigner(local, listener, signerKey, []string{clientID}, log.NewNopLogger())
+	require.NoError(t, err)
+	require.NoError(t, signer.Start())
+	defer signer.Stop()
+
+	addr := "tcp://" + signerID + "@" + listener.Addr().String()
+	_, err = NewRemote("tcp://"+listener.Addr().String(), clientKey)
+	require.Error(t, err)
+	_, err = NewRemote(addr, nil)
+	require.Error(t, err)
+	remote, err := NewRemote(addr, clientKey)
+	require.NoError(t, err)
+
+	// the signer rejects the other clients
+	other, err := NewRemote(addr, ed25519.GenPrivKey())
+	require.NoError(t, err)
+	_, err = other.List()
+	require.Error(t, err)
+
+	// the client rejects a signer which hasn't the ID expected
+	other, err = NewRemote("tcp://"+clientID+"@"+listener.Addr().String(), clientKey)
+	require.NoError(t, err)
+	_, err = other.List()
+	require.Error(t, err)
+
+	// the keys of the signer are listed in order as remote keys
+	infos, err := remote.List()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(infos))
+	require.Equal(t, n2, infos[0].GetName())
+	require.Equal(t, i2.GetPubKey(), infos[0].GetPubKey())
+	require.Equal(t, TypeRemote, infos[0].GetType())
+
+	info, err := remote.Get(n1)
+	require.NoError(t, err)
+	require.Equal(t, n1, info.GetName())
+	require.Equal(t, i1.GetPubKey(), info.GetPubKey())
+	_, err = remote.Get("other")
+	require.Error(t, err)
+
+	// the signer signs with the private key decrypted with the passphrase
+	msg := []byte("hello world")
+	sig, pub, err := remote.Sign(n1, p1, msg)
+	require.NoError(t, err)
+	require.Equal(t, i1.GetPubKey(), pub)
+	require.True(t, pub.VerifyBytes(msg, sig))
+
+	_, _, err = remote.Sign(n1, p2, msg)
+	require.Error(t, err)
+	_, _, err = remote.Sign(o1, "", msg)
+	require.Error(t, err)
+
+	// the keys are managed by the signer only
+	_, _, err = remote.CreateMnemonic("new", English, p1, Secp256k1)
+	require.Equal(t, ErrRemoteUnsupported, err)
+	require.Equal(t, ErrRemoteUnsupported, remote.Delete(n1, p1))
+	_, err = remote.ExportPrivateKeyObject(n1, p1)
+	require.Equal(t, ErrRemoteUnsupported, err)
+
+	// the signer is unreachable once stopped
+	require.NoError(t, signer.Stop())
+	_, err = remote.List()
+	require.Error(t, err)
+}
+
+func TestRemoteKeybaseUnix(t *testing.T) {
+	local := New(dbm.NewMemDB())
+	i1, _, err := local.CreateMnemonic("personal", English, "1234", Secp256k1)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "remote-signer")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "signer.sock")
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+
+	// on a unix socket, the connections are left to the permissions of the socket
+	signer, err := NewRemoteSigner(local, listener, nil, nil, log.NewNopLogger())
+	require.NoError(t, err)
+	require.NoError(t, signer.Start())
+	defer signer.Stop()
+
+	remote, err := NewRemote("unix://"+path, nil)
+	require.NoError(t, err)
+	msg := []byte("hello world")
+	sig, pub, err := remote.Sign("personal", "1234", msg)
+	require.NoError(t, err)
+	require.Equal(t, i1.GetPubKey(), pub)
+	require.True(t, pub.VerifyBytes(msg, sig))
+}
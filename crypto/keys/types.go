@@ -3,6 +3,8 @@ package keys
 import (
 	ccrypto "github.com/cosmos/cosmos-sdk/crypto"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 
 	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
 )
@@ -25,8 +27,8 @@ type Keybase interface {
 	CreateKey(name, mnemonic, passwd string) (info Info, err error)
 	// CreateFundraiserKey takes a mnemonic and derives, a password
 	CreateFundraiserKey(name, mnemonic, passwd string) (info Info, err error)
-	// Derive derives a key from the passed mnemonic using a BIP44 path.
-	Derive(name, mnemonic, passwd string, params hd.BIP44Params) (Info, error)
+	// Derive derives a key of the algo from the passed mnemonic using a BIP44 path.
+	Derive(name, mnemonic, passwd string, params hd.BIP44Params, algo SigningAlgo) (Info, error)
 	// Create, store, and return a new Ledger key reference
 	CreateLedger(name string, path ccrypto.DerivationPath, algo SigningAlgo) (info Info, err error)
 
@@ -39,6 +41,11 @@ type Keybase interface {
 	ImportPubKey(name string, armor string) (err error)
 	Export(name string) (armor string, err error)
 	ExportPubKey(name string) (armor string, err error)
+	// Export a key in the JSON keystore format of the Web3 secret storage,
+	// encrypted with exportPassphrase, and import it from the keystore
+	// decrypted with importPassphrase
+	ExportWeb3(name, passphrase, exportPassphrase string) (keyJSON []byte, err error)
+	ImportWeb3(name string, keyJSON []byte, importPassphrase, passphrase string) (info Info, err error)
 
 	// *only* works on locally-stored keys. Temporary method until we redo the exporting API
 	ExportPrivateKeyObject(name string, passphrase string) (crypto.PrivKey, error)
@@ -52,12 +59,14 @@ const (
 	TypeLocal   KeyType = 0
 	TypeLedger  KeyType = 1
 	TypeOffline KeyType = 2
+	TypeRemote  KeyType = 3
 )
 
 var keyTypes = map[KeyType]string{
 	TypeLocal:   "local",
 	TypeLedger:  "ledger",
 	TypeOffline: "offline",
+	TypeRemote:  "remote",
 }
 
 // String implements the stringer interface for KeyType.
@@ -73,24 +82,29 @@ type Info interface {
 	GetName() string
 	// Public key
 	GetPubKey() crypto.PubKey
+	// Signing algorithm of the key
+	GetAlgo() SigningAlgo
 }
 
 var _ Info = &localInfo{}
 var _ Info = &ledgerInfo{}
 var _ Info = &offlineInfo{}
+var _ Info = &remoteInfo{}
 
 // localInfo is the public information about a locally stored key
 type localInfo struct {
 	Name         string        `json:"name"`
 	PubKey       crypto.PubKey `json:"pubkey"`
 	PrivKeyArmor string        `json:"privkey.armor"`
+	Algo         SigningAlgo   `json:"algo"`
 }
 
-func newLocalInfo(name string, pub crypto.PubKey, privArmor string) Info {
+func newLocalInfo(name string, pub crypto.PubKey, privArmor string, algo SigningAlgo) Info {
 	return &localInfo{
 		Name:         name,
 		PubKey:       pub,
 		PrivKeyArmor: privArmor,
+		Algo:         algo,
 	}
 }
 
@@ -106,18 +120,24 @@ func (i localInfo) GetPubKey() crypto.PubKey {
 	return i.PubKey
 }
 
+func (i localInfo) GetAlgo() SigningAlgo {
+	return infoAlgo(i.Algo, i.PubKey)
+}
+
 // ledgerInfo is the public information about a Ledger key
 type ledgerInfo struct {
 	Name   string                 `json:"name"`
 	PubKey crypto.PubKey          `json:"pubkey"`
 	Path   ccrypto.DerivationPath `json:"path"`
+	Algo   SigningAlgo            `json:"algo"`
 }
 
-func newLedgerInfo(name string, pub crypto.PubKey, path ccrypto.DerivationPath) Info {
+func newLedgerInfo(name string, pub crypto.PubKey, path ccrypto.DerivationPath, algo SigningAlgo) Info {
 	return &ledgerInfo{
 		Name:   name,
 		PubKey: pub,
 		Path:   path,
+		Algo:   algo,
 	}
 }
 
@@ -133,16 +153,22 @@ func (i ledgerInfo) GetPubKey() crypto.PubKey {
 	return i.PubKey
 }
 
+func (i ledgerInfo) GetAlgo() SigningAlgo {
+	return infoAlgo(i.Algo, i.PubKey)
+}
+
 // offlineInfo is the public information about an offline key
 type offlineInfo struct {
 	Name   string        `json:"name"`
 	PubKey crypto.PubKey `json:"pubkey"`
+	Algo   SigningAlgo   `json:"algo"`
 }
 
-func newOfflineInfo(name string, pub crypto.PubKey) Info {
+func newOfflineInfo(name string, pub crypto.PubKey, algo SigningAlgo) Info {
 	return &offlineInfo{
 		Name:   name,
 		PubKey: pub,
+		Algo:   algo,
 	}
 }
 
@@ -158,6 +184,54 @@ func (i offlineInfo) GetPubKey() crypto.PubKey {
 	return i.PubKey
 }
 
+func (i offlineInfo) GetAlgo() SigningAlgo {
+	return infoAlgo(i.Algo, i.PubKey)
+}
+
+// remoteInfo is the public information about a key of a remote signer
+type remoteInfo struct {
+	Name   string        `json:"name"`
+	PubKey crypto.PubKey `json:"pubkey"`
+	Algo   SigningAlgo   `json:"algo"`
+}
+
+func (i remoteInfo) GetType() KeyType {
+	return TypeRemote
+}
+
+func (i remoteInfo) GetName() string {
+	return i.Name
+}
+
+func (i remoteInfo) GetPubKey() crypto.PubKey {
+	return i.PubKey
+}
+
+func (i remoteInfo) GetAlgo() SigningAlgo {
+	return infoAlgo(i.Algo, i.PubKey)
+}
+
+// returns the recorded signing algorithm of a key, or the algorithm of its
+// public key for the keys stored before it was recorded
+func infoAlgo(algo SigningAlgo, pub crypto.PubKey) SigningAlgo {
+	if algo != "" {
+		return algo
+	}
+	return pubKeyAlgo(pub)
+}
+
+// returns the signing algorithm of a public key, empty if unknown
+func pubKeyAlgo(pub crypto.PubKey) SigningAlgo {
+	switch pub.(type) {
+	case secp256k1.PubKeySecp256k1:
+		return Secp256k1
+	case ed25519.PubKeyEd25519:
+		return Ed25519
+	default:
+		return ""
+	}
+}
+
 // encoding info
 func writeInfo(i Info) []byte {
 	return cdc.MustMarshalBinary(i)
@@ -168,3 +242,14 @@ func readInfo(bz []byte) (info Info, err error) {
 	err = cdc.UnmarshalBinary(bz, &info)
 	return
 }
+
+// encoding info in JSON, for the keys stored in files
+func writeInfoJSON(i Info) []byte {
+	return cdc.MustMarshalJSON(i)
+}
+
+// decoding info in JSON
+func readInfoJSON(bz []byte) (info Info, err error) {
+	err = cdc.UnmarshalJSON(bz, &info)
+	return
+}
@@ -0,0 +1,249 @@
+package keys
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+)
+
+// Keys are exported to and imported from the JSON keystores of the Web3
+// secret storage, version 3:
+//  https://github.com/ethereum/wiki/wiki/Web3-Secret-Storage-Definition
+// The private key is encrypted with AES-128-CTR by a key derived from the
+// passphrase with scrypt, or PBKDF2 for imported keys, and authenticated by
+// the Keccak-256 MAC of the ciphertext.
+
+const (
+	web3Version = 3
+	web3Cipher  = "aes-128-ctr"
+	web3KDFLen  = 32
+)
+
+// bounds of the kdf parameters of imported keystores, see checkScryptParams
+const (
+	web3MaxKDFLen     = 64
+	web3MaxPBKDF2Iter = 1 << 20
+)
+
+// ErrWeb3MACMismatch is returned when a Web3 keystore is decrypted with a
+// wrong passphrase, or has been altered.
+var ErrWeb3MACMismatch = errors.New("could not decrypt key with given passphrase")
+
+type web3Keystore struct {
+	Address string     `json:"address"`
+	Crypto  web3Crypto `json:"crypto"`
+	ID      string     `json:"id"`
+	Version int        `json:"version"`
+}
+
+type web3Crypto struct {
+	Cipher       string                 `json:"cipher"`
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams web3CipherParams       `json:"cipherparams"`
+	KDF          string                 `json:"kdf"`
+	KDFParams    map[string]interface{} `json:"kdfparams"`
+	MAC          string                 `json:"mac"`
+}
+
+type web3CipherParams struct {
+	IV string `json:"iv"`
+}
+
+// EncryptWeb3Keystore returns the JSON keystore of the Web3 secret storage
+// holding the private key encrypted with the passphrase. The address of the
+// keystore is the hex encoded address of the key.
+func EncryptWeb3Keystore(priv crypto.PrivKey, passphrase string) ([]byte, error) {
+	var keyBytes []byte
+	switch priv := priv.(type) {
+	case secp256k1.PrivKeySecp256k1:
+		keyBytes = priv[:]
+	case ed25519.PrivKeyEd25519:
+		keyBytes = priv[:]
+	default:
+		return nil, ErrUnsupportedSigningAlgo
+	}
+
+	salt := crypto.CRandBytes(32)
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, ScryptN, scryptR, scryptP, web3KDFLen)
+	if err != nil {
+		return nil, err
+	}
+	iv := crypto.CRandBytes(aes.BlockSize)
+	cipherText, err := web3AESCTR(derivedKey[:16], iv, keyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	keystore := web3Keystore{
+		Address: hex.EncodeToString(priv.PubKey().Address()),
+		Crypto: web3Crypto{
+			Cipher:       web3Cipher,
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: web3CipherParams{IV: hex.EncodeToString(iv)},
+			KDF:          kdfScrypt,
+			KDFParams: map[string]interface{}{
+				"n":     ScryptN,
+				"r":     scryptR,
+				"p":     scryptP,
+				"dklen": web3KDFLen,
+				"salt":  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(web3MAC(derivedKey, cipherText)),
+		},
+		ID:      newUUID(),
+		Version: web3Version,
+	}
+	return json.MarshalIndent(keystore, "", "  ")
+}
+
+// DecryptWeb3Keystore returns the private key of a JSON keystore of the Web3
+// secret storage, decrypted with the passphrase. The keystores hold a
+// secp256k1 key of 32 bytes, or an ed25519 key of 64 bytes.
+func DecryptWeb3Keystore(keyJSON []byte, passphrase string) (crypto.PrivKey, error) {
+	var keystore web3Keystore
+	if err := json.Unmarshal(keyJSON, &keystore); err != nil {
+		return nil, err
+	}
+	if keystore.Version != web3Version {
+		return nil, fmt.Errorf("unsupported Web3 keystore version %d", keystore.Version)
+	}
+	if keystore.Crypto.Cipher != web3Cipher {
+		return nil, fmt.Errorf("unsupported Web3 keystore cipher %q", keystore.Crypto.Cipher)
+	}
+	cipherText, err := hex.DecodeString(keystore.Crypto.CipherText)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid ciphertext")
+	}
+	iv, err := hex.DecodeString(keystore.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid iv")
+	}
+	mac, err := hex.DecodeString(keystore.Crypto.MAC)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid mac")
+	}
+
+	derivedKey, err := web3DeriveKey(keystore.Crypto, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(web3MAC(derivedKey, cipherText), mac) {
+		return nil, ErrWeb3MACMismatch
+	}
+	keyBytes, err := web3AESCTR(derivedKey[:16], iv, cipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(keyBytes) {
+	case 32:
+		var priv secp256k1.PrivKeySecp256k1
+		copy(priv[:], keyBytes)
+		return priv, nil
+	case 64:
+		// the second half of the key is the public key of the seed
+		var seed [32]byte
+		copy(seed[:], keyBytes)
+		priv := ed25519PrivKeyFromSeed(seed)
+		if !bytes.Equal(priv[:], keyBytes) {
+			return nil, errors.New("invalid ed25519 private key")
+		}
+		return priv, nil
+	default:
+		return nil, fmt.Errorf("invalid private key length %d", len(keyBytes))
+	}
+}
+
+// derive the key of the kdf parameters from the passphrase
+func web3DeriveKey(params web3Crypto, passphrase string) ([]byte, error) {
+	salt, err := hex.DecodeString(web3KDFString(params.KDFParams, "salt"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid salt")
+	}
+	dkLen := web3KDFInt(params.KDFParams, "dklen")
+	if dkLen < 32 || dkLen > web3MaxKDFLen {
+		return nil, fmt.Errorf("derived key length %d is out of range", dkLen)
+	}
+
+	switch params.KDF {
+	case kdfScrypt:
+		n := web3KDFInt(params.KDFParams, "n")
+		r := web3KDFInt(params.KDFParams, "r")
+		p := web3KDFInt(params.KDFParams, "p")
+		if err := checkScryptParams(n, r, p); err != nil {
+			return nil, err
+		}
+		return scrypt.Key([]byte(passphrase), salt, n, r, p, dkLen)
+	case "pbkdf2":
+		if prf := web3KDFString(params.KDFParams, "prf"); prf != "hmac-sha256" {
+			return nil, fmt.Errorf("unsupported PBKDF2 PRF %q", prf)
+		}
+		c := web3KDFInt(params.KDFParams, "c")
+		if c <= 0 || c > web3MaxPBKDF2Iter {
+			return nil, fmt.Errorf("PBKDF2 iteration count %d is out of range", c)
+		}
+		return pbkdf2.Key([]byte(passphrase), salt, c, dkLen, sha256.New), nil
+	default:
+		return nil, fmt.Errorf("unsupported Web3 keystore KDF %q", params.KDF)
+	}
+}
+
+// JSON numbers are decoded as float64, those which are not integers fitting
+// in an int32 are returned as zero
+func web3KDFInt(params map[string]interface{}, name string) int {
+	f, _ := params[name].(float64)
+	if f != math.Trunc(f) || f < math.MinInt32 || f > math.MaxInt32 {
+		return 0
+	}
+	return int(f)
+}
+
+func web3KDFString(params map[string]interface{}, name string) string {
+	s, _ := params[name].(string)
+	return s
+}
+
+// the MAC is the Keccak-256 hash of the second half of the derived key
+// followed by the ciphertext
+func web3MAC(derivedKey, cipherText []byte) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(derivedKey[16:32])
+	hasher.Write(cipherText)
+	return hasher.Sum(nil)
+}
+
+// AES-128-CTR both encrypts and decrypts
+func web3AESCTR(key, iv, in []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != aes.BlockSize {
+		return nil, fmt.Errorf("invalid iv length %d", len(iv))
+	}
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+	return out, nil
+}
+
+// newUUID returns a random (version 4) UUID identifying a keystore
+func newUUID() string {
+	b := crypto.CRandBytes(16)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
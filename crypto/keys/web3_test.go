@@ -0,0 +1,92 @@
+package keys
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// test vectors of the Web3 secret storage definition
+func TestDecryptWeb3Keystore(t *testing.T) {
+	keystores := []string{
+		`{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"6087dab2f9fdbbfaddc31a909735c1e6"},"ciphertext":"5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46","kdf":"pbkdf2","kdfparams":{"c":262144,"dklen":32,"prf":"hmac-sha256","salt":"ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},"mac":"517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`,
+		`{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"83dbcc02d8ccb40e466191a123791e0e"},"ciphertext":"d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c","kdf":"scrypt","kdfparams":{"dklen":32,"n":262144,"p":8,"r":1,"salt":"ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},"mac":"2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`,
+	}
+	for _, keystore := range keystores {
+		priv, err := DecryptWeb3Keystore([]byte(keystore), "testpassword")
+		require.NoError(t, err)
+		require.IsType(t, secp256k1.PrivKeySecp256k1{}, priv)
+		secp := priv.(secp256k1.PrivKeySecp256k1)
+		require.Equal(t, "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d", hex.EncodeToString(secp[:]))
+	}
+
+	_, err := DecryptWeb3Keystore([]byte(keystores[0]), "wrongpassword")
+	require.Equal(t, ErrWeb3MACMismatch, err)
+}
+
+func TestEncryptWeb3Keystore(t *testing.T) {
+	for _, priv := range []crypto.PrivKey{secp256k1.GenPrivKey(), ed25519.GenPrivKey()} {
+		keyJSON, err := EncryptWeb3Keystore(priv, "passphrase")
+		require.NoError(t, err)
+
+		// the keystore holds the address of the key
+		var keystore web3Keystore
+		require.NoError(t, json.Unmarshal(keyJSON, &keystore))
+		require.Equal(t, 3, keystore.Version)
+		require.Equal(t, hex.EncodeToString(priv.PubKey().Address()), keystore.Address)
+		require.Equal(t, "scrypt", keystore.Crypto.KDF)
+
+		decrypted, err := DecryptWeb3Keystore(keyJSON, "passphrase")
+		require.NoError(t, err)
+		require.Equal(t, priv, decrypted)
+		_, err = DecryptWeb3Keystore(keyJSON, "other")
+		require.Equal(t, ErrWeb3MACMismatch, err)
+	}
+}
+
+func TestDecryptWeb3KeystoreKDFBounds(t *testing.T) {
+	keyJSON, err := EncryptWeb3Keystore(secp256k1.GenPrivKey(), "passphrase")
+	require.NoError(t, err)
+
+	// keystores requiring too much work to derive their key are rejected
+	// before deriving it
+	cases := []struct {
+		kdf    string
+		params map[string]interface{}
+	}{
+		{"scrypt", map[string]interface{}{"n": 1 << 30, "r": 8, "p": 1}},
+		{"scrypt", map[string]interface{}{"n": 1 << 18, "r": 16, "p": 1}},
+		{"scrypt", map[string]interface{}{"n": 1 << 18, "r": 8, "p": 1 << 20}},
+		{"scrypt", map[string]interface{}{"n": 1e300, "r": 8, "p": 1}},
+		{"scrypt", map[string]interface{}{"n": 0, "r": 8, "p": 1}},
+		{"scrypt", map[string]interface{}{"n": 1 << 12, "r": 8, "p": 1, "dklen": 1 << 30}},
+		{"scrypt", map[string]interface{}{"n": 1 << 12, "r": 8, "p": 1, "dklen": 16}},
+		{"pbkdf2", map[string]interface{}{"c": 1 << 30, "prf": "hmac-sha256"}},
+		{"pbkdf2", map[string]interface{}{"c": -1, "prf": "hmac-sha256"}},
+	}
+	for i, tc := range cases {
+		var keystore map[string]interface{}
+		require.NoError(t, json.Unmarshal(keyJSON, &keystore))
+		cryptoParams := keystore["crypto"].(map[string]interface{})
+		kdfParams := map[string]interface{}{
+			"dklen": 32,
+			"salt":  cryptoParams["kdfparams"].(map[string]interface{})["salt"],
+		}
+		for name, value := range tc.params {
+			kdfParams[name] = value
+		}
+		cryptoParams["kdf"] = tc.kdf
+		cryptoParams["kdfparams"] = kdfParams
+		bz, err := json.Marshal(keystore)
+		require.NoError(t, err)
+
+		_, err = DecryptWeb3Keystore(bz, "passphrase")
+		require.Error(t, err, "case %d", i)
+		require.Contains(t, err.Error(), "out of range", "case %d", i)
+	}
+}
@@ -34,7 +34,7 @@ func NewApp3(logger log.Logger, db dbm.DB) *bapp.BaseApp {
 	bankKeeper := bank.NewBaseKeeper(accountMapper)
 	feeKeeper := auth.NewFeeCollectionKeeper(cdc, keyFees)
 
-	app.SetAnteHandler(auth.NewAnteHandler(accountMapper, feeKeeper))
+	app.SetAnteHandler(auth.NewAnteHandler(accountMapper, feeKeeper, "atom"))
 
 	// Register message routes.
 	// Note the handler gets access to
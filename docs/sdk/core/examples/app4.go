@@ -35,7 +35,7 @@ func NewApp4(logger log.Logger, db dbm.DB) *bapp.BaseApp {
 	keyFees := sdk.NewKVStoreKey("fee")
 	feeKeeper := auth.NewFeeCollectionKeeper(cdc, keyFees)
 
-	app.SetAnteHandler(auth.NewAnteHandler(accountMapper, feeKeeper))
+	app.SetAnteHandler(auth.NewAnteHandler(accountMapper, feeKeeper, "atom"))
 
 	// Set InitChainer
 	app.SetInitChainer(NewInitChainer(cdc, accountMapper))
@@ -69,6 +69,7 @@ func NewBasecoinApp(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.Ba
 	)
 	app.bankKeeper = bank.NewBaseKeeper(app.accountMapper)
 	app.ibcMapper = ibc.NewMapper(app.cdc, app.keyIBC, app.RegisterCodespace(ibc.DefaultCodespace))
+	app.ibcMapper.Router().AddRoute(ibc.TransferRoute, ibc.NewTransferCallbacks(app.ibcMapper, app.bankKeeper))
 
 	// register message routes
 	app.Router().
@@ -79,7 +80,7 @@ func NewBasecoinApp(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.Ba
 	app.SetInitChainer(app.initChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetEndBlocker(app.EndBlocker)
-	app.SetAnteHandler(auth.NewAnteHandler(app.accountMapper, app.feeCollectionKeeper))
+	app.SetAnteHandler(auth.NewAnteHandler(app.accountMapper, app.feeCollectionKeeper, "steak"))
 
 	// mount the multistore and load the latest state
 	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyIBC)
@@ -150,6 +151,8 @@ func (app *BasecoinApp) initChainer(ctx sdk.Context, req abci.RequestInitChain)
 		app.accountMapper.SetAccount(ctx, acc)
 	}
 
+	ibc.InitGenesis(ctx, app.ibcMapper, genesisState.IBCData)
+
 	return abci.ResponseInitChain{}
 }
 
@@ -172,7 +175,10 @@ func (app *BasecoinApp) ExportAppStateAndValidators() (appState json.RawMessage,
 
 	app.accountMapper.IterateAccounts(ctx, appendAccountsFn)
 
-	genState := types.GenesisState{Accounts: accounts}
+	genState := types.GenesisState{
+		Accounts: accounts,
+		IBCData:  ibc.WriteGenesis(ctx, app.ibcMapper),
+	}
 	appState, err = codec.MarshalJSONIndent(app.cdc, genState)
 	if err != nil {
 		return nil, nil, err
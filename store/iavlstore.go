@@ -16,6 +16,10 @@ import (
 
 const (
 	defaultIAVLCacheSize = 10000
+
+	// MaxRangeQueryLimit is the maximum number of key-value pairs returned by
+	// a "/range" query.
+	MaxRangeQueryLimit = 1000
 )
 
 // load the iavl store
@@ -190,6 +194,22 @@ func getHeight(tree *iavl.MutableTree, req abci.RequestQuery) int64 {
 	return height
 }
 
+// RangeQuery is the amino encoded data of a "/range" query.
+type RangeQuery struct {
+	Start []byte `json:"start"` // first key of the range, nil for no lower bound
+	End   []byte `json:"end"`   // key after the range, nil for no upper bound
+	Limit int    `json:"limit"` // MaxRangeQueryLimit if not positive
+}
+
+// RangeResult is the amino encoded value of the response to a "/range"
+// query.
+type RangeResult struct {
+	KVs []KVPair `json:"kvs"`
+
+	// start of the next page, nil if there is none
+	NextKey []byte `json:"next_key"`
+}
+
 // Query implements ABCI interface, allows queries
 //
 // by default we will return from (latest height -1),
@@ -234,6 +254,48 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 		} else {
 			_, res.Value = tree.GetVersioned(key, res.Height)
 		}
+	case "/range": // Get a page of the key-value pairs in a range
+		var query RangeQuery
+		err := cdc.UnmarshalBinary(req.Data, &query)
+		if err != nil {
+			msg := fmt.Sprintf("invalid range query: %v", err)
+			return sdk.ErrUnknownRequest(msg).QueryResult()
+		}
+		res.Key = req.Data
+		if !st.VersionExists(res.Height) {
+			res.Log = cmn.ErrorWrap(iavl.ErrVersionDoesNotExist, "").Error()
+			break
+		}
+
+		limit := query.Limit
+		if limit <= 0 || limit > MaxRangeQueryLimit {
+			limit = MaxRangeQueryLimit
+		}
+
+		// one more key than the limit is read, starting the next page
+		keys, values, proof, err := tree.GetVersionedRangeWithProof(query.Start, query.End, limit+1, res.Height)
+		if err != nil {
+			res.Log = err.Error()
+			break
+		}
+		result := RangeResult{KVs: []KVPair{}}
+		for i, key := range keys {
+			if i == limit {
+				result.NextKey = key
+				break
+			}
+			result.KVs = append(result.KVs, KVPair{key, values[i]})
+		}
+		res.Value = cdc.MustMarshalBinary(result)
+
+		if req.Prove && proof != nil {
+			p, err := cdc.MarshalBinary(proof)
+			if err != nil {
+				res.Log = err.Error()
+				break
+			}
+			res.Proof = p
+		}
 	case "/subspace":
 		subspace := req.Data
 		res.Key = subspace
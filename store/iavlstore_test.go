@@ -465,6 +465,64 @@ func TestIAVLStoreQuery(t *testing.T) {
 	require.Equal(t, v1, qres.Value)
 }
 
+func TestIAVLStoreRangeQuery(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewMutableTree(db, cacheSize)
+	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
+
+	for _, k := range []string{"a", "key1", "key2", "key3", "z"} {
+		iavlStore.Set([]byte(k), []byte("val-"+k))
+	}
+	cid := iavlStore.Commit()
+
+	queryRange := func(start, end []byte, limit int) RangeResult {
+		query := RangeQuery{Start: start, End: end, Limit: limit}
+		qres := iavlStore.Query(abci.RequestQuery{
+			Path: "/range", Data: cdc.MustMarshalBinary(query), Height: cid.Version, Prove: true,
+		})
+		require.Equal(t, uint32(sdk.CodeOK), qres.Code)
+		require.Equal(t, cid.Version, qres.Height)
+		require.NotEmpty(t, qres.Proof)
+
+		var proof iavl.RangeProof
+		cdc.MustUnmarshalBinary(qres.Proof, &proof)
+		var result RangeResult
+		cdc.MustUnmarshalBinary(qres.Value, &result)
+		require.Nil(t, VerifyRangeQueryProof(query, result, cid.Hash, &proof))
+		return result
+	}
+
+	ksub := []byte("key")
+	end := sdk.PrefixEndBytes(ksub)
+
+	// first page
+	result := queryRange(ksub, end, 2)
+	require.Equal(t, []KVPair{
+		{[]byte("key1"), []byte("val-key1")},
+		{[]byte("key2"), []byte("val-key2")},
+	}, result.KVs)
+	require.Equal(t, []byte("key3"), result.NextKey)
+
+	// last page
+	result = queryRange(result.NextKey, end, 2)
+	require.Equal(t, []KVPair{{[]byte("key3"), []byte("val-key3")}}, result.KVs)
+	require.Nil(t, result.NextKey)
+
+	// no upper bound, and the maximum limit
+	result = queryRange([]byte("key3"), nil, 0)
+	require.Equal(t, 2, len(result.KVs))
+	require.Nil(t, result.NextKey)
+
+	// empty range
+	result = queryRange([]byte("key4"), []byte("y"), 10)
+	require.Empty(t, result.KVs)
+	require.Nil(t, result.NextKey)
+
+	// invalid request data
+	qres := iavlStore.Query(abci.RequestQuery{Path: "/range", Data: []byte{0xff}})
+	require.Equal(t, uint32(sdk.CodeUnknownRequest), qres.Code)
+}
+
 func BenchmarkIAVLIteratorNext(b *testing.B) {
 	db := dbm.NewMemDB()
 	treeSize := 1000
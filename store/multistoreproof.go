@@ -80,11 +80,62 @@ func VerifyRangeProof(key, value []byte, substoreCommitHash []byte, rangeProof *
 	return nil
 }
 
+// VerifyRangeQueryProof verify iavl RangeProof of the result of a "/range"
+// query, ensuring every key of the range up to the next page is in the result
+func VerifyRangeQueryProof(query RangeQuery, result RangeResult, substoreCommitHash []byte, rangeProof *iavl.RangeProof) error {
+
+	// verify the proof to ensure data integrity.
+	err := rangeProof.Verify(substoreCommitHash)
+	if err != nil {
+		return errors.Wrap(err, "proof root hash doesn't equal to substore commit root hash")
+	}
+
+	resultKeys := make(map[string]bool, len(result.KVs))
+	for _, kv := range result.KVs {
+		err = rangeProof.VerifyItem(kv.Key, kv.Value)
+		if err != nil {
+			return errors.Wrap(err, "failed in existence verification")
+		}
+		resultKeys[string(kv.Key)] = true
+	}
+
+	// the proven keys are contiguous, so a key of the range missing from the
+	// result is among them
+	end := query.End
+	if result.NextKey != nil {
+		end = result.NextKey
+	}
+	nextKeyProven := result.NextKey == nil
+	for _, key := range rangeProof.Keys() {
+		if bytes.Equal(key, result.NextKey) {
+			nextKeyProven = true
+		}
+		if bytes.Compare(key, query.Start) < 0 || (end != nil && bytes.Compare(key, end) >= 0) {
+			continue
+		}
+		if !resultKeys[string(key)] {
+			return cmn.NewError("key %X of the range is missing from the result", key)
+		}
+	}
+	if !nextKeyProven {
+		return cmn.NewError("next key %X is not proven", result.NextKey)
+	}
+
+	if len(result.KVs) == 0 && query.Start != nil {
+		err = rangeProof.VerifyAbsence(query.Start)
+		if err != nil {
+			return errors.Wrap(err, "failed in absence verification")
+		}
+	}
+
+	return nil
+}
+
 // RequireProof return whether proof is require for the subpath
 func RequireProof(subpath string) bool {
-	// Currently, only when query subpath is "/store" or "/key", will proof be included in response.
-	// If there are some changes about proof building in iavlstore.go, we must change code here to keep consistency with iavlstore.go:212
-	if subpath == "/store" || subpath == "/key" {
+	// Currently, only when query subpath is "/store", "/key" or "/range", will proof be included in response.
+	// If there are some changes about proof building in iavlstore.go, we must change code here to keep consistency with iavlstore.go
+	if subpath == "/store" || subpath == "/key" || subpath == "/range" {
 		return true
 	}
 	return false
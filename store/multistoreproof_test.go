@@ -121,3 +121,40 @@ func TestVerifyRangeProof(t *testing.T) {
 	err = VerifyRangeProof(key, val, root, proof)
 	assert.Nil(t, err)
 }
+
+func TestVerifyRangeQueryProof(t *testing.T) {
+	tree := iavl.NewMutableTree(db.NewMemDB(), 0)
+	for _, ikey := range []byte{0x11, 0x32, 0x50, 0x72, 0x99} {
+		tree.Set([]byte{ikey}, []byte{ikey, ikey})
+	}
+	root := tree.WorkingHash()
+
+	query := RangeQuery{Start: []byte{0x20}, End: []byte{0x90}}
+	keys, values, proof, err := tree.GetRangeWithProof(query.Start, query.End, 3)
+	require.Nil(t, err)
+	require.Equal(t, 3, len(keys))
+
+	result := RangeResult{
+		KVs: []KVPair{
+			{keys[0], values[0]},
+			{keys[1], values[1]},
+		},
+		NextKey: keys[2],
+	}
+	require.Nil(t, VerifyRangeQueryProof(query, result, root, proof))
+
+	// a key of the range is missing
+	missing := RangeResult{KVs: result.KVs[:1], NextKey: result.NextKey}
+	require.NotNil(t, VerifyRangeQueryProof(query, missing, root, proof))
+
+	// a value is altered
+	altered := RangeResult{KVs: []KVPair{result.KVs[0], {keys[1], []byte{0x00}}}, NextKey: result.NextKey}
+	require.NotNil(t, VerifyRangeQueryProof(query, altered, root, proof))
+
+	// the next key is not proven
+	unproven := RangeResult{KVs: result.KVs, NextKey: []byte{0x60}}
+	require.NotNil(t, VerifyRangeQueryProof(query, unproven, root, proof))
+
+	// the proof is not of the root
+	require.NotNil(t, VerifyRangeQueryProof(query, result, []byte("root"), proof))
+}
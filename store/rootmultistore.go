@@ -291,7 +291,8 @@ func (rs *rootMultiStore) Query(req abci.RequestQuery) abci.ResponseQuery {
 	req.Path = subpath
 	res := queryable.Query(req)
 
-	if !req.Prove || !RequireProof(subpath) {
+	// there is no proof of a failed query or of a range of an empty tree
+	if !req.Prove || !RequireProof(subpath) || res.Proof == nil {
 		return res
 	}
 
@@ -277,8 +277,10 @@ func (coins Coins) Sort() Coins {
 // Parsing
 
 var (
-	// Denominations can be 3 ~ 16 characters long.
-	reDnm  = `[[:alpha:]][[:alnum:]]{2,15}`
+	// Denominations can be 3 ~ 16 characters long, optionally followed by
+	// slash separated path segments of alphanumeric characters, dots, dashes
+	// and underscores (ex. delshare/cosmosvaloper1..., ibc/gaia-7001/steak).
+	reDnm  = `[[:alpha:]][[:alnum:]]{2,15}(?:/[[:alnum:]._-]+)*`
 	reAmt  = `[[:digit:]]+`
 	reSpc  = `[[:space:]]*`
 	reCoin = regexp.MustCompile(fmt.Sprintf(`^(%s)%s(%s)$`, reAmt, reSpc, reDnm))
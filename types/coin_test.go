@@ -282,6 +282,9 @@ func TestParse(t *testing.T) {
 		{"11me coin, 12you coin", false, nil}, // no spaces in coin names
 		{"1.2btc", false, nil},                // amount must be integer
 		{"5foo-bar", false, nil},              // once more, only letters in coin name
+		{"5foo/bar", true, Coins{{"foo/bar", NewInt(5)}}},
+		{"5ibc/foo-bar/baz", true, Coins{{"ibc/foo-bar/baz", NewInt(5)}}},
+		{"5foo/", false, nil}, // no empty path segments
 	}
 
 	for tcIndex, tc := range cases {
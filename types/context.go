@@ -45,6 +45,8 @@ func NewContext(ms MultiStore, header abci.Header, isCheckTx bool, logger log.Lo
 	c = c.WithLogger(logger)
 	c = c.WithSigningValidators(nil)
 	c = c.WithGasMeter(NewInfiniteGasMeter())
+	c = c.WithIsReCheckTx(false)
+	c = c.WithBlockGasMeter(NewInfiniteGasMeter())
 	return c
 }
 
@@ -136,6 +138,8 @@ const (
 	contextKeyLogger
 	contextKeySigningValidators
 	contextKeyGasMeter
+	contextKeyIsReCheckTx
+	contextKeyBlockGasMeter
 )
 
 // NOTE: Do not expose MultiStore.
@@ -170,6 +174,12 @@ func (c Context) SigningValidators() []abci.SigningValidator {
 func (c Context) GasMeter() GasMeter {
 	return c.Value(contextKeyGasMeter).(GasMeter)
 }
+func (c Context) IsReCheckTx() bool {
+	return c.Value(contextKeyIsReCheckTx).(bool)
+}
+func (c Context) BlockGasMeter() GasMeter {
+	return c.Value(contextKeyBlockGasMeter).(GasMeter)
+}
 func (c Context) WithMultiStore(ms MultiStore) Context {
 	return c.withValue(contextKeyMultiStore, ms)
 }
@@ -202,6 +212,12 @@ func (c Context) WithSigningValidators(SigningValidators []abci.SigningValidator
 func (c Context) WithGasMeter(meter GasMeter) Context {
 	return c.withValue(contextKeyGasMeter, meter)
 }
+func (c Context) WithIsReCheckTx(isReCheckTx bool) Context {
+	return c.withValue(contextKeyIsReCheckTx, isReCheckTx)
+}
+func (c Context) WithBlockGasMeter(meter GasMeter) Context {
+	return c.withValue(contextKeyBlockGasMeter, meter)
+}
 
 // Cache the multistore and return a new cached context. The cached context is
 // written to the context when writeCache is called.
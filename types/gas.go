@@ -1,5 +1,7 @@
 package types
 
+import "math"
+
 // Gas measured by the SDK
 type Gas = int64
 
@@ -11,6 +13,8 @@ type ErrorOutOfGas struct {
 // GasMeter interface to track gas consumption
 type GasMeter interface {
 	GasConsumed() Gas
+	Limit() Gas
+	IsOutOfGas() bool
 	ConsumeGas(amount Gas, descriptor string)
 }
 
@@ -30,6 +34,14 @@ func (g *basicGasMeter) GasConsumed() Gas {
 	return g.consumed
 }
 
+func (g *basicGasMeter) Limit() Gas {
+	return g.limit
+}
+
+func (g *basicGasMeter) IsOutOfGas() bool {
+	return g.consumed >= g.limit
+}
+
 func (g *basicGasMeter) ConsumeGas(amount Gas, descriptor string) {
 	g.consumed += amount
 	if g.consumed > g.limit {
@@ -51,6 +63,15 @@ func (g *infiniteGasMeter) GasConsumed() Gas {
 	return g.consumed
 }
 
+// Limit returns the maximum Gas
+func (g *infiniteGasMeter) Limit() Gas {
+	return math.MaxInt64
+}
+
+func (g *infiniteGasMeter) IsOutOfGas() bool {
+	return false
+}
+
 func (g *infiniteGasMeter) ConsumeGas(amount Gas, descriptor string) {
 	g.consumed += amount
 }
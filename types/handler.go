@@ -3,6 +3,10 @@ package types
 // Handler defines the core of the state transition function of an application.
 type Handler func(ctx Context, msg Msg) Result
 
+// TxPriorityFunc returns the priority of a transaction in the mempool, higher
+// first, once authenticated by the AnteHandler.
+type TxPriorityFunc func(ctx Context, tx Tx) int64
+
 // AnteHandler authenticates transactions, before their internal messages are handled.
 // If newCtx.IsZero(), ctx is used instead.
 type AnteHandler func(ctx Context, tx Tx, simulate bool) (newCtx Context, result Result, abort bool)
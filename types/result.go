@@ -24,6 +24,10 @@ type Result struct {
 
 	// Tags are used for transaction indexing and pubsub.
 	Tags Tags
+
+	// Priority orders the tx in the mempool, higher first. Only set by
+	// CheckTx, which returns it as the TagPriority tag.
+	Priority int64
 }
 
 // TODO: In the future, more codes may be OK.
@@ -108,4 +108,18 @@ type DelegationSet interface {
 type ValidatorHooks interface {
 	OnValidatorBonded(ctx Context, address ConsAddress)         // Must be called when a validator is bonded
 	OnValidatorBeginUnbonding(ctx Context, address ConsAddress) // Must be called when a validator begins unbonding
+
+	// Must be called when a validator replaces its consensus pubkey
+	OnValidatorConsPubKeyRotated(ctx Context, oldAddress, newAddress ConsAddress)
+}
+
+// delegation event hooks
+// These can be utilized by a keeper paying out the rewards of delegations,
+// such as the distribution keeper, to let the staking keeper act on the
+// rewards withdrawn from a delegation. The staking keeper implements this
+// interface, which then the paying keeper can call.
+type DelegationHooks interface {
+	// Must be called once the rewards withdrawn from a delegation have been
+	// credited to the delegator's account
+	OnDelegationRewardsWithdrawn(ctx Context, delAddr AccAddress, valAddr ValAddress, rewards Coins) (Tags, Error)
 }
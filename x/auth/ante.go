@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"math"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/tendermint/tendermint/crypto"
@@ -17,13 +18,17 @@ const (
 	ed25519VerifyCost           = 59
 	secp256k1VerifyCost         = 100
 	maxMemoCharacters           = 100
+
+	// scales the fee per unit of gas of the priority of a transaction
+	feePriorityPrecision = 1000000
 )
 
 // NewAnteHandler returns an AnteHandler that checks
 // and increments sequence numbers, checks signatures & account numbers,
-// and deducts fees from the first signer.
+// and deducts fees from the first signer. The txs are prioritized by their
+// gas price in feeDenom.
 // nolint: gocyclo
-func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
+func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper, feeDenom string) sdk.AnteHandler {
 
 	return func(
 		ctx sdk.Context, tx sdk.Tx, simulate bool,
@@ -116,8 +121,29 @@ func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
 
 		// TODO: tx tags (?)
 
-		return newCtx, sdk.Result{GasWanted: stdTx.Fee.Gas}, false // continue...
+		res = sdk.Result{
+			GasWanted: stdTx.Fee.Gas,
+			Priority:  FeePriority(fee, feeDenom),
+		}
+		return newCtx, res, false // continue...
+	}
+}
+
+// FeePriority returns the priority of a transaction paying a fee: its gas
+// price in denom, the amount of denom of the fee per unit of gas, times
+// feePriorityPrecision. The amounts of the other denoms are ignored, as they
+// can't be compared with the amounts of denom.
+func FeePriority(fee StdFee, denom string) int64 {
+	if fee.Gas <= 0 {
+		return 0
+	}
+
+	amount := fee.Amount.AmountOf(denom)
+	priority := amount.MulRaw(feePriorityPrecision).DivRaw(fee.Gas)
+	if !priority.IsInt64() {
+		return math.MaxInt64
 	}
+	return priority.Int64()
 }
 
 // Validate the transaction based on things that don't depend on the context
@@ -183,8 +209,10 @@ func processSig(
 		return nil, sdk.ErrInternal("setting PubKey on signer's account").Result()
 	}
 
+	// the signature of a transaction rechecked after a block was verified
+	// when it was first checked
 	consumeSignatureVerificationGas(ctx.GasMeter(), pubKey)
-	if !simulate && !pubKey.VerifyBytes(signBytes, sig.Signature) {
+	if !simulate && !ctx.IsReCheckTx() && !pubKey.VerifyBytes(signBytes, sig.Signature) {
 		return nil, sdk.ErrUnauthorized("signature verification failed").Result()
 	}
 
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	codec "github.com/cosmos/cosmos-sdk/codec"
@@ -114,7 +115,7 @@ func TestAnteHandlerSigErrors(t *testing.T) {
 	RegisterBaseAccount(cdc)
 	mapper := NewAccountMapper(cdc, capKey, ProtoBaseAccount)
 	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
-	anteHandler := NewAnteHandler(mapper, feeCollector)
+	anteHandler := NewAnteHandler(mapper, feeCollector, "atom")
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
 
 	// keys and addresses
@@ -167,7 +168,7 @@ func TestAnteHandlerAccountNumbers(t *testing.T) {
 	RegisterBaseAccount(cdc)
 	mapper := NewAccountMapper(cdc, capKey, ProtoBaseAccount)
 	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
-	anteHandler := NewAnteHandler(mapper, feeCollector)
+	anteHandler := NewAnteHandler(mapper, feeCollector, "atom")
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
 
 	// keys and addresses
@@ -226,7 +227,7 @@ func TestAnteHandlerSequences(t *testing.T) {
 	RegisterBaseAccount(cdc)
 	mapper := NewAccountMapper(cdc, capKey, ProtoBaseAccount)
 	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
-	anteHandler := NewAnteHandler(mapper, feeCollector)
+	anteHandler := NewAnteHandler(mapper, feeCollector, "atom")
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
 
 	// keys and addresses
@@ -304,7 +305,7 @@ func TestAnteHandlerFees(t *testing.T) {
 	RegisterBaseAccount(cdc)
 	mapper := NewAccountMapper(cdc, capKey, ProtoBaseAccount)
 	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
-	anteHandler := NewAnteHandler(mapper, feeCollector)
+	anteHandler := NewAnteHandler(mapper, feeCollector, "atom")
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
 
 	// keys and addresses
@@ -339,6 +340,29 @@ func TestAnteHandlerFees(t *testing.T) {
 }
 
 // Test logic around memo gas consumption.
+func TestFeePriority(t *testing.T) {
+	// no gas
+	require.Equal(t, int64(0), FeePriority(NewStdFee(0, sdk.NewInt64Coin("atom", 10)), "atom"))
+
+	// no fee
+	require.Equal(t, int64(0), FeePriority(NewStdFee(1000), "atom"))
+
+	// fee per gas
+	low := FeePriority(NewStdFee(1000, sdk.NewInt64Coin("atom", 1)), "atom")
+	high := FeePriority(NewStdFee(1000, sdk.NewInt64Coin("atom", 10)), "atom")
+	require.Equal(t, int64(1000), low)
+	require.Equal(t, 10*low, high)
+	require.Equal(t, high, FeePriority(NewStdFee(100, sdk.NewInt64Coin("atom", 1)), "atom"))
+
+	// only the fee denom counts
+	require.Equal(t, int64(0), FeePriority(NewStdFee(1000, sdk.NewInt64Coin("btc", 10)), "atom"))
+	require.Equal(t, low, FeePriority(NewStdFee(1000, sdk.NewInt64Coin("atom", 1), sdk.NewInt64Coin("btc", 1000)), "atom"))
+
+	// bounded
+	fee := NewStdFee(1, sdk.NewCoin("atom", sdk.NewInt(math.MaxInt64)))
+	require.Equal(t, int64(math.MaxInt64), FeePriority(fee, "atom"))
+}
+
 func TestAnteHandlerMemoGas(t *testing.T) {
 	// setup
 	ms, capKey, capKey2 := setupMultiStore()
@@ -346,7 +370,7 @@ func TestAnteHandlerMemoGas(t *testing.T) {
 	RegisterBaseAccount(cdc)
 	mapper := NewAccountMapper(cdc, capKey, ProtoBaseAccount)
 	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
-	anteHandler := NewAnteHandler(mapper, feeCollector)
+	anteHandler := NewAnteHandler(mapper, feeCollector, "atom")
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
 
 	// keys and addresses
@@ -389,7 +413,7 @@ func TestAnteHandlerMultiSigner(t *testing.T) {
 	RegisterBaseAccount(cdc)
 	mapper := NewAccountMapper(cdc, capKey, ProtoBaseAccount)
 	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
-	anteHandler := NewAnteHandler(mapper, feeCollector)
+	anteHandler := NewAnteHandler(mapper, feeCollector, "atom")
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
 
 	// keys and addresses
@@ -440,7 +464,7 @@ func TestAnteHandlerBadSignBytes(t *testing.T) {
 	RegisterBaseAccount(cdc)
 	mapper := NewAccountMapper(cdc, capKey, ProtoBaseAccount)
 	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
-	anteHandler := NewAnteHandler(mapper, feeCollector)
+	anteHandler := NewAnteHandler(mapper, feeCollector, "atom")
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
 
 	// keys and addresses
@@ -514,6 +538,49 @@ func TestAnteHandlerBadSignBytes(t *testing.T) {
 
 }
 
+// Test that a recheck only checks sequences and fees, not signatures
+func TestAnteHandlerReCheck(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, ProtoBaseAccount)
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	anteHandler := NewAnteHandler(mapper, feeCollector, "atom")
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, true, log.NewNopLogger())
+	reCheckCtx := ctx.WithIsReCheckTx(true)
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+
+	// set the accounts
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc1)
+
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	fee := newStdFee()
+	privs, accnums := []crypto.PrivKey{priv1}, []int64{0}
+
+	// a bad signature is only verified on the first check
+	badSigTx := newTestTxWithSignBytes(msgs, privs, accnums, []int64{0}, fee, []byte("bad sign bytes"), "")
+	checkInvalidTx(t, anteHandler, ctx, badSigTx, false, sdk.CodeUnauthorized)
+	checkValidTx(t, anteHandler, reCheckCtx, badSigTx, false)
+
+	// the sequence is rechecked
+	tx := newTestTx(ctx, msgs, privs, accnums, []int64{0}, fee)
+	checkInvalidTx(t, anteHandler, reCheckCtx, tx, false, sdk.CodeInvalidSequence)
+	tx = newTestTx(ctx, msgs, privs, accnums, []int64{2}, fee)
+	checkInvalidTx(t, anteHandler, reCheckCtx, tx, false, sdk.CodeInvalidSequence)
+
+	// the fee balance is rechecked
+	acc1 = mapper.GetAccount(ctx, addr1)
+	acc1.SetCoins(sdk.Coins{})
+	mapper.SetAccount(ctx, acc1)
+	tx = newTestTx(ctx, msgs, privs, accnums, []int64{1}, fee)
+	checkInvalidTx(t, anteHandler, reCheckCtx, tx, false, sdk.CodeInsufficientFunds)
+}
+
 func TestAnteHandlerSetPubKey(t *testing.T) {
 	// setup
 	ms, capKey, capKey2 := setupMultiStore()
@@ -521,7 +588,7 @@ func TestAnteHandlerSetPubKey(t *testing.T) {
 	RegisterBaseAccount(cdc)
 	mapper := NewAccountMapper(cdc, capKey, ProtoBaseAccount)
 	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
-	anteHandler := NewAnteHandler(mapper, feeCollector)
+	anteHandler := NewAnteHandler(mapper, feeCollector, "atom")
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
 
 	// keys and addresses
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -11,6 +12,11 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 )
 
+const (
+	flagStart = "start"
+	flagLimit = "limit"
+)
+
 // GetAccountCmdDefault invokes the GetAccountCmd for the auth.BaseAccount type.
 func GetAccountCmdDefault(storeName string, cdc *codec.Codec) *cobra.Command {
 	return GetAccountCmd(storeName, cdc, GetAccountDecoder(cdc))
@@ -68,3 +74,61 @@ func GetAccountCmd(storeName string, cdc *codec.Codec, decoder auth.AccountDecod
 		},
 	}
 }
+
+// accounts of a page listed by the accounts command
+type accountsPage struct {
+	Accounts []auth.Account `json:"accounts"`
+	Next     string         `json:"next"` // address starting the next page
+}
+
+// GetAccountsCmd returns a query command that will display the accounts one
+// page at a time, in the order of their address.
+func GetAccountsCmd(storeName string, cdc *codec.Codec, decoder auth.AccountDecoder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "accounts",
+		Short: "Query the accounts from an address",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var startKey []byte
+			if start := viper.GetString(flagStart); start != "" {
+				addr, err := sdk.AccAddressFromBech32(start)
+				if err != nil {
+					return err
+				}
+				startKey = auth.AddressStoreKey(addr)
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			resKVs, nextKey, err := cliCtx.QuerySubspacePaginated(
+				auth.AddressStoreKeyPrefix, storeName, startKey, viper.GetInt(flagLimit))
+			if err != nil {
+				return err
+			}
+
+			page := accountsPage{Accounts: []auth.Account{}}
+			for _, kv := range resKVs {
+				acc, err := decoder(kv.Value)
+				if err != nil {
+					return err
+				}
+				page.Accounts = append(page.Accounts, acc)
+			}
+			if nextKey != nil {
+				page.Next = sdk.AccAddress(nextKey[len(auth.AddressStoreKeyPrefix):]).String()
+			}
+
+			output, err := codec.MarshalJSONIndent(cdc, page)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagStart, "", "Address of the first account, omit to start from the lowest address")
+	cmd.Flags().Int(flagLimit, context.DefaultPageLimit, "Maximum number of accounts")
+
+	return cmd
+}
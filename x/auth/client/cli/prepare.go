@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	amino "github.com/tendermint/go-amino"
+)
+
+// GetPrepareCommand returns the prepare command
+func GetPrepareCommand(codec *amino.Codec, decoder auth.AccountDecoder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prepare <file>",
+		Short: "Prepare transactions for offline signing",
+		Long: `Prepare transactions created with the --generate-only flag for offline signing.
+Read a transaction from <file>, query the account number and sequence of its signers,
+and print a bundle holding the transaction, the chain ID, the account numbers and
+sequences. The bundle can be signed without a node with 'sign --offline', which prints
+a summary of the transaction before signing it. The chain ID of the node is used if --chain-id is not set.`,
+		RunE: makePrepareCmd(codec, decoder),
+		Args: cobra.ExactArgs(1),
+	}
+	return cmd
+}
+
+func makePrepareCmd(cdc *amino.Codec, decoder auth.AccountDecoder) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		stdTx, err := readAndUnmarshalStdTx(cdc, args[0])
+		if err != nil {
+			return err
+		}
+
+		cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(decoder)
+		chainID := viper.GetString(client.FlagChainID)
+		if chainID == "" {
+			node, err := cliCtx.GetNode()
+			if err != nil {
+				return err
+			}
+			status, err := node.Status()
+			if err != nil {
+				return err
+			}
+			chainID = status.NodeInfo.Network
+		}
+
+		bundle, err := utils.PrepareTxBundle(cliCtx, chainID, stdTx)
+		if err != nil {
+			return err
+		}
+		json, err := codec.MarshalJSONIndent(cdc, bundle)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", json)
+		return nil
+	}
+}
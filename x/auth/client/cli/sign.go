@@ -9,6 +9,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
@@ -19,6 +20,7 @@ import (
 const (
 	flagAppend    = "append"
 	flagPrintSigs = "print-sigs"
+	flagOffline   = "offline"
 )
 
 // GetSignCommand returns the sign command
@@ -27,18 +29,31 @@ func GetSignCommand(codec *amino.Codec, decoder auth.AccountDecoder) *cobra.Comm
 		Use:   "sign <file>",
 		Short: "Sign transactions",
 		Long: `Sign transactions created with the --generate-only flag.
-Read a transaction from <file>, sign it, and print its JSON encoding.`,
+Read a transaction from <file>, sign it, and print its JSON encoding.
+
+With --offline, read a bundle created with the prepare command from <file>, sign its
+transaction with the chain ID, account number and sequence of the bundle without
+querying a node, and print the bundle with the signature appended. The signers
+must sign the bundle in the order of its signers.
+
+A summary of the transaction is printed to the standard error before asking for the
+passphrase.`,
 		RunE: makeSignCmd(codec, decoder),
 		Args: cobra.ExactArgs(1),
 	}
 	cmd.Flags().String(client.FlagName, "", "Name of private key with which to sign")
 	cmd.Flags().Bool(flagAppend, true, "Append the signature to the existing ones. If disabled, old signatures would be overwritten")
 	cmd.Flags().Bool(flagPrintSigs, false, "Print the addresses that must sign the transaction and those who have already signed it, then exit")
+	cmd.Flags().Bool(flagOffline, false, "Sign a bundle created with the prepare command without querying a node")
 	return cmd
 }
 
 func makeSignCmd(cdc *amino.Codec, decoder auth.AccountDecoder) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) (err error) {
+		if viper.GetBool(flagOffline) {
+			return signBundleOffline(cdc, args[0])
+		}
+
 		stdTx, err := readAndUnmarshalStdTx(cdc, args[0])
 		if err != nil {
 			return
@@ -66,6 +81,30 @@ func makeSignCmd(cdc *amino.Codec, decoder auth.AccountDecoder) func(cmd *cobra.
 	}
 }
 
+func signBundleOffline(cdc *amino.Codec, filename string) error {
+	bundle, err := utils.ReadTxBundle(cdc, filename)
+	if err != nil {
+		return err
+	}
+
+	if viper.GetBool(flagPrintSigs) {
+		fmt.Println(bundle.Summary())
+		printSignatures(bundle.Tx)
+		return nil
+	}
+
+	bundle, err = utils.SignBundleOffline(viper.GetString(client.FlagName), bundle)
+	if err != nil {
+		return err
+	}
+	json, err := codec.MarshalJSONIndent(cdc, bundle)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s\n", json)
+	return nil
+}
+
 func printSignatures(stdTx auth.StdTx) {
 	fmt.Println("Signers:")
 	for i, signer := range stdTx.GetSigners() {
@@ -59,9 +59,13 @@ func (am AccountMapper) NewAccount(ctx sdk.Context, acc Account) Account {
 	return acc
 }
 
+// AddressStoreKeyPrefix prefixes the keys of the accounts in the account
+// store.
+var AddressStoreKeyPrefix = []byte("account:")
+
 // Turn an address to key used to get it from the account store
 func AddressStoreKey(addr sdk.AccAddress) []byte {
-	return append([]byte("account:"), addr.Bytes()...)
+	return append(append([]byte{}, AddressStoreKeyPrefix...), addr.Bytes()...)
 }
 
 // Implements sdk.AccountMapper.
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"bytes"
+	"errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// StdSignMessageDomain separates the signatures of arbitrary messages from the
+// signatures of transactions.
+const StdSignMessageDomain = "cosmos-sdk/StdSignMessage"
+
+// StdSignMessageDoc is the canonical structure signed to sign an arbitrary
+// message with the key of an account. Its fields have no name in common with
+// the fields of StdSignDoc, so the sign bytes of a message always differ from
+// the sign bytes of a transaction and cannot be replayed as a transaction.
+type StdSignMessageDoc struct {
+	Domain string         `json:"domain"`
+	Signer sdk.AccAddress `json:"signer"`
+	Data   []byte         `json:"data"`
+}
+
+// StdSignMessageBytes returns the bytes to sign to sign an arbitrary message
+// with the key of the signer.
+func StdSignMessageBytes(signer sdk.AccAddress, data []byte) []byte {
+	bz, err := msgCdc.MarshalJSON(StdSignMessageDoc{
+		Domain: StdSignMessageDomain,
+		Signer: signer,
+		Data:   data,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// StdSignedMessage is an arbitrary message signed by the key of an account.
+type StdSignedMessage struct {
+	Signer    sdk.AccAddress `json:"signer"`
+	Data      []byte         `json:"data"`
+	PubKey    crypto.PubKey  `json:"pub_key"`
+	Signature []byte         `json:"signature"`
+}
+
+// Verify checks that the message is signed by the key of its signer.
+func (msg StdSignedMessage) Verify() error {
+	if msg.PubKey == nil {
+		return errors.New("missing public key")
+	}
+	if !bytes.Equal(msg.PubKey.Address(), msg.Signer) {
+		return errors.New("the public key is not the key of the signer")
+	}
+	if !msg.PubKey.VerifyBytes(StdSignMessageBytes(msg.Signer, msg.Data), msg.Signature) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
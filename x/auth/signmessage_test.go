@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestStdSignMessageBytes(t *testing.T) {
+	priv := ed25519.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	require.Equal(t, fmt.Sprintf("{\"data\":\"aGVsbG8=\",\"domain\":\"cosmos-sdk/StdSignMessage\",\"signer\":\"%s\"}", addr),
+		string(StdSignMessageBytes(addr, []byte("hello"))))
+}
+
+func TestStdSignedMessageVerify(t *testing.T) {
+	priv := secp256k1.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	data := []byte("I own this address")
+	sig, err := priv.Sign(StdSignMessageBytes(addr, data))
+	require.NoError(t, err)
+
+	msg := StdSignedMessage{addr, data, priv.PubKey(), sig}
+	require.NoError(t, msg.Verify())
+
+	// the signed message round trips through its JSON encoding
+	bz, err := msgCdc.MarshalJSON(msg)
+	require.NoError(t, err)
+	var decoded StdSignedMessage
+	require.NoError(t, msgCdc.UnmarshalJSON(bz, &decoded))
+	require.NoError(t, decoded.Verify())
+
+	tampered := msg
+	tampered.Data = []byte("I own that address")
+	require.Error(t, tampered.Verify())
+
+	other := secp256k1.GenPrivKey()
+	tampered = msg
+	tampered.Signer = sdk.AccAddress(other.PubKey().Address())
+	require.Error(t, tampered.Verify())
+
+	tampered = msg
+	tampered.PubKey = other.PubKey()
+	require.Error(t, tampered.Verify())
+
+	tampered = msg
+	tampered.PubKey = nil
+	require.Error(t, tampered.Verify())
+
+	// the signature of a message is not the signature of a transaction
+	signBytes := StdSignBytes("", 0, 0, StdFee{}, []sdk.Msg{sdk.NewTestMsg(addr)}, string(data))
+	require.False(t, priv.PubKey().VerifyBytes(signBytes, sig))
+}
@@ -5,23 +5,39 @@ import (
 	"os"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	amino "github.com/tendermint/go-amino"
 )
 
-// GetSignCommand returns the sign command
+const flagBundle = "bundle"
+
+// GetBroadcastCommand returns the broadcast command
 func GetBroadcastCommand(codec *amino.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "broadcast <file>",
 		Short: "Broadcast transactions generated offline",
 		Long: `Broadcast transactions created with the --generate-only flag and signed with the sign command.
 Read a transaction from <file> and broadcast it to a node. If you supply a dash (-) argument
-in place of an input filename, the command reads from standard input.`,
+in place of an input filename, the command reads from standard input.
+
+With --bundle, read a bundle created with the prepare command and signed with 'sign --offline'.
+The bundle is validated before it is broadcast: all its signers must have signed the transaction
+with the account numbers and sequences of the bundle, its chain ID must be the one of the node and
+the sequences must be the current ones.`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
-			cliCtx := context.NewCLIContext().WithCodec(codec).WithLogger(os.Stdout)
-			stdTx, err := readAndUnmarshalStdTx(cliCtx.Codec, args[0])
+			cliCtx := context.NewCLIContext().WithCodec(codec).WithLogger(os.Stdout).
+				WithAccountDecoder(authcmd.GetAccountDecoder(codec))
+			var stdTx auth.StdTx
+			if viper.GetBool(flagBundle) {
+				stdTx, err = readAndValidateBundle(cliCtx, args[0])
+			} else {
+				stdTx, err = readAndUnmarshalStdTx(cliCtx.Codec, args[0])
+			}
 			if err != nil {
 				return
 			}
@@ -32,9 +48,24 @@ in place of an input filename, the command reads from standard input.`,
 			return cliCtx.EnsureBroadcastTx(txBytes)
 		},
 	}
+	cmd.Flags().Bool(flagBundle, false, "Broadcast the transaction of a signed bundle, after validating the bundle")
 	return cmd
 }
 
+func readAndValidateBundle(cliCtx context.CLIContext, filename string) (stdTx auth.StdTx, err error) {
+	bundle, err := utils.ReadTxBundle(cliCtx.Codec, filename)
+	if err != nil {
+		return
+	}
+	if err = bundle.ValidateSigned(); err != nil {
+		return
+	}
+	if err = bundle.ValidateAgainstNode(cliCtx); err != nil {
+		return
+	}
+	return bundle.Tx, nil
+}
+
 func readAndUnmarshalStdTx(cdc *amino.Codec, filename string) (stdTx auth.StdTx, err error) {
 	var bytes []byte
 	if filename == "-" {
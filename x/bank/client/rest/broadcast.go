@@ -10,14 +10,15 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 )
 
-type broadcastBody struct {
+// BroadcastBody is the REST body broadcasting a signed tx
+type BroadcastBody struct {
 	Tx auth.StdTx `json:"tx"`
 }
 
 // BroadcastTxRequestHandlerFn returns the broadcast tx REST handler
 func BroadcastTxRequestHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var m broadcastBody
+		var m BroadcastBody
 		if ok := unmarshalBodyOrReturnBadRequest(cliCtx, w, r, &m); !ok {
 			return
 		}
@@ -42,7 +43,7 @@ func BroadcastTxRequestHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) ht
 	}
 }
 
-func unmarshalBodyOrReturnBadRequest(cliCtx context.CLIContext, w http.ResponseWriter, r *http.Request, m *broadcastBody) bool {
+func unmarshalBodyOrReturnBadRequest(cliCtx context.CLIContext, w http.ResponseWriter, r *http.Request, m *BroadcastBody) bool {
 	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -5,6 +5,7 @@ package stake
 //storeKey   sdk.StoreKey
 //cdc        *codec.Codec
 //bankKeeper bank.Keeper
+//hooks      sdk.DelegationHooks // set to the staking keeper's DelegationHooks()
 
 //// codespace
 //codespace sdk.CodespaceType
@@ -89,3 +90,16 @@ package stake
 //b := k.cdc.MustMarshalBinary(pc)
 //store.Set(GetPowerChangeKey(pc.Height), b)
 //}
+
+////_______________________________________________________________________
+
+//// withdraw the rewards of a delegation to the delegator's account, letting
+//// the staking keeper restake them if the delegation has AutoRestake set
+//func (k Keeper) WithdrawDelegationRewards(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (sdk.Tags, sdk.Error) {
+//rewards := k.takeDelegationRewards(ctx, delAddr, valAddr)
+//_, _, err := k.bankKeeper.AddCoins(ctx, delAddr, rewards)
+//if err != nil {
+//return nil, err
+//}
+//return k.hooks.OnDelegationRewardsWithdrawn(ctx, delAddr, valAddr, rewards)
+//}
@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"bytes"
+	"encoding/hex"
 	"fmt"
 	"os"
 
@@ -32,6 +34,8 @@ const (
 	flagStatus            = "status"
 	flagLatestProposalIDs = "latest"
 	flagProposal          = "proposal"
+	flagStart             = "start"
+	flagLimit             = "limit"
 )
 
 type proposal struct {
@@ -405,33 +409,42 @@ func GetCmdQueryVote(queryRoute string, cdc *codec.Codec) *cobra.Command {
 }
 
 // GetCmdQueryVotes implements the command to query for proposal votes.
-func GetCmdQueryVotes(queryRoute string, cdc *codec.Codec) *cobra.Command {
+func GetCmdQueryVotes(storeName string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "query-votes",
-		Short: "query votes on a proposal",
+		Short: "query votes on a proposal, one page at a time",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			proposalID := viper.GetInt64(flagProposalID)
 
-			params := gov.QueryVotesParams{
-				ProposalID: proposalID,
-			}
-			bz, err := cdc.MarshalJSON(params)
+			resKVs, err := queryPage(cliCtx, gov.KeyVotesSubspace(proposalID), storeName)
 			if err != nil {
 				return err
 			}
 
-			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/votes", queryRoute), bz)
+			votes := []gov.Vote{}
+			for _, kv := range resKVs {
+				var vote gov.Vote
+				err = cdc.UnmarshalBinary(kv.Value, &vote)
+				if err != nil {
+					return err
+				}
+				votes = append(votes, vote)
+			}
+
+			output, err := codec.MarshalJSONIndent(cdc, votes)
 			if err != nil {
 				return err
 			}
 
-			fmt.Println(string(res))
+			fmt.Println(string(output))
 			return nil
 		},
 	}
 
 	cmd.Flags().String(flagProposalID, "", "proposalID of which proposal's votes are being queried")
+	cmd.Flags().String(flagStart, "", "Hex-encoded store key of the first vote, as printed after the previous page; omit to start from the first vote")
+	cmd.Flags().Int(flagLimit, context.DefaultPageLimit, "Maximum number of votes")
 
 	return cmd
 }
@@ -477,33 +490,42 @@ func GetCmdQueryDeposit(queryRoute string, cdc *codec.Codec) *cobra.Command {
 }
 
 // GetCmdQueryDeposits implements the command to query for proposal deposits.
-func GetCmdQueryDeposits(queryRoute string, cdc *codec.Codec) *cobra.Command {
+func GetCmdQueryDeposits(storeName string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "query-deposits",
-		Short: "query deposits on a proposal",
+		Short: "query deposits on a proposal, one page at a time",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			proposalID := viper.GetInt64(flagProposalID)
 
-			params := gov.QueryDepositsParams{
-				ProposalID: proposalID,
-			}
-			bz, err := cdc.MarshalJSON(params)
+			resKVs, err := queryPage(cliCtx, gov.KeyDepositsSubspace(proposalID), storeName)
 			if err != nil {
 				return err
 			}
 
-			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/deposits", queryRoute), bz)
+			deposits := []gov.Deposit{}
+			for _, kv := range resKVs {
+				var deposit gov.Deposit
+				err = cdc.UnmarshalBinary(kv.Value, &deposit)
+				if err != nil {
+					return err
+				}
+				deposits = append(deposits, deposit)
+			}
+
+			output, err := codec.MarshalJSONIndent(cdc, deposits)
 			if err != nil {
 				return err
 			}
 
-			fmt.Println(string(res))
+			fmt.Println(string(output))
 			return nil
 		},
 	}
 
 	cmd.Flags().String(flagProposalID, "", "proposalID of which proposal's deposits are being queried")
+	cmd.Flags().String(flagStart, "", "Hex-encoded store key of the first deposit, as printed after the previous page; omit to start from the first deposit")
+	cmd.Flags().Int(flagLimit, context.DefaultPageLimit, "Maximum number of deposits")
 
 	return cmd
 }
@@ -539,3 +561,31 @@ func GetCmdQueryTally(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 	return cmd
 }
+
+// queryPage queries a page of the records of the store under the prefix, in
+// the order of their key, starting from the hex-encoded key of the --start
+// flag. The flag starting the next page, if any, is printed to the standard
+// error.
+func queryPage(cliCtx context.CLIContext, prefix []byte, storeName string) ([]sdk.KVPair, error) {
+	var startKey []byte
+	if start := viper.GetString(flagStart); start != "" {
+		key, err := hex.DecodeString(start)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.HasPrefix(key, prefix) {
+			return nil, fmt.Errorf("--%s=%s does not start a page of the queried records", flagStart, start)
+		}
+		startKey = key
+	}
+
+	resKVs, nextKey, err := cliCtx.QuerySubspacePaginated(prefix, storeName, startKey, viper.GetInt(flagLimit))
+	if err != nil {
+		return nil, err
+	}
+
+	if nextKey != nil {
+		fmt.Fprintf(os.Stderr, "More records follow, query them with --%s=%s\n", flagStart, hex.EncodeToString(nextKey))
+	}
+	return resKVs, nil
+}
@@ -40,8 +40,9 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec)
 	r.HandleFunc("/gov/proposals", queryProposalsWithParameterFn(cdc)).Methods("GET")
 }
 
-type postProposalReq struct {
-	BaseReq        baseReq          `json:"base_req"`
+// PostProposalReq is the REST body submitting a proposal
+type PostProposalReq struct {
+	BaseReq        BaseReq          `json:"base_req"`
 	Title          string           `json:"title"`           //  Title of the proposal
 	Description    string           `json:"description"`     //  Description of the proposal
 	ProposalType   gov.ProposalKind `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
@@ -49,21 +50,23 @@ type postProposalReq struct {
 	InitialDeposit sdk.Coins        `json:"initial_deposit"` // Coins to add to the proposal's deposit
 }
 
-type depositReq struct {
-	BaseReq   baseReq        `json:"base_req"`
+// DepositReq is the REST body depositing on a proposal
+type DepositReq struct {
+	BaseReq   BaseReq        `json:"base_req"`
 	Depositer sdk.AccAddress `json:"depositer"` // Address of the depositer
 	Amount    sdk.Coins      `json:"amount"`    // Coins to add to the proposal's deposit
 }
 
-type voteReq struct {
-	BaseReq baseReq        `json:"base_req"`
+// VoteReq is the REST body voting on a proposal
+type VoteReq struct {
+	BaseReq BaseReq        `json:"base_req"`
 	Voter   sdk.AccAddress `json:"voter"`  //  address of the voter
 	Option  gov.VoteOption `json:"option"` //  option from OptionSet chosen by the voter
 }
 
 func postProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var req postProposalReq
+		var req PostProposalReq
 		err := buildReq(w, r, cdc, &req)
 		if err != nil {
 			return
@@ -101,7 +104,7 @@ func depositHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerF
 			return
 		}
 
-		var req depositReq
+		var req DepositReq
 		err := buildReq(w, r, cdc, &req)
 		if err != nil {
 			return
@@ -138,7 +141,7 @@ func voteHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc
 			return
 		}
 
-		var req voteReq
+		var req VoteReq
 		err := buildReq(w, r, cdc, &req)
 		if err != nil {
 			return
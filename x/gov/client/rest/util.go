@@ -14,7 +14,8 @@ import (
 	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
 )
 
-type baseReq struct {
+// BaseReq holds the key and the signing parameters of the gov REST requests
+type BaseReq struct {
 	Name          string `json:"name"`
 	Password      string `json:"password"`
 	ChainID       string `json:"chain_id"`
@@ -38,7 +39,7 @@ func buildReq(w http.ResponseWriter, r *http.Request, cdc *codec.Codec, req inte
 	return nil
 }
 
-func (req baseReq) baseReqValidate(w http.ResponseWriter) bool {
+func (req BaseReq) baseReqValidate(w http.ResponseWriter) bool {
 	if len(req.Name) == 0 {
 		utils.WriteErrorResponse(w, http.StatusUnauthorized, "Name required but not specified")
 		return false
@@ -68,7 +69,7 @@ func (req baseReq) baseReqValidate(w http.ResponseWriter) bool {
 
 // TODO: Build this function out into a more generic base-request
 // (probably should live in client/lcd).
-func signAndBuild(w http.ResponseWriter, r *http.Request, cliCtx context.CLIContext, baseReq baseReq, msg sdk.Msg, cdc *codec.Codec) {
+func signAndBuild(w http.ResponseWriter, r *http.Request, cliCtx context.CLIContext, baseReq BaseReq, msg sdk.Msg, cdc *codec.Codec) {
 	simulateGas, gas, err := client.ReadGasFlag(baseReq.Gas)
 	if err != nil {
 		utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
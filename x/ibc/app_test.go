@@ -22,6 +22,7 @@ func getMockApp(t *testing.T) *mock.App {
 	keyIBC := sdk.NewKVStoreKey("ibc")
 	ibcMapper := NewMapper(mapp.Cdc, keyIBC, mapp.RegisterCodespace(DefaultCodespace))
 	bankKeeper := bank.NewBaseKeeper(mapp.AccountMapper)
+	ibcMapper.Router().AddRoute(TransferRoute, NewTransferCallbacks(ibcMapper, bankKeeper))
 	mapp.Router().AddRoute("ibc", NewHandler(ibcMapper, bankKeeper))
 
 	require.NoError(t, mapp.CompleteSetup(keyIBC))
@@ -31,7 +32,8 @@ func getMockApp(t *testing.T) *mock.App {
 func TestIBCMsgs(t *testing.T) {
 	mapp := getMockApp(t)
 
-	sourceChain := "source-chain"
+	// packets are sent from the chain ID of the mock app
+	sourceChain := ""
 	destChain := "dest-chain"
 
 	priv1 := ed25519.GenPrivKey()
@@ -52,20 +54,10 @@ func TestIBCMsgs(t *testing.T) {
 	res1 := mapp.AccountMapper.GetAccount(ctxCheck, addr1)
 	require.Equal(t, acc, res1)
 
-	packet := IBCPacket{
-		SrcAddr:   addr1,
-		DestAddr:  addr1,
-		Coins:     coins,
-		SrcChain:  sourceChain,
-		DestChain: destChain,
-	}
-
-	transferMsg := IBCTransferMsg{
-		IBCPacket: packet,
-	}
+	transferMsg := NewIBCTransferMsg(addr1, addr1, coins, sourceChain, destChain, 0, 0)
 
 	receiveMsg := IBCReceiveMsg{
-		IBCPacket: packet,
+		IBCPacket: transferMsg.Packet(),
 		Relayer:   addr1,
 		Sequence:  0,
 	}
@@ -73,7 +65,8 @@ func TestIBCMsgs(t *testing.T) {
 	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{transferMsg}, []int64{0}, []int64{0}, true, true, priv1)
 	mock.CheckBalance(t, mapp, addr1, emptyCoins)
 	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{transferMsg}, []int64{0}, []int64{1}, false, false, priv1)
-	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{receiveMsg}, []int64{0}, []int64{2}, true, true, priv1)
-	mock.CheckBalance(t, mapp, addr1, coins)
+	// packets cannot be received without a proof against a verified header
+	// of the source chain
 	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{receiveMsg}, []int64{0}, []int64{2}, false, false, priv1)
+	mock.CheckBalance(t, mapp, addr1, emptyCoins)
 }
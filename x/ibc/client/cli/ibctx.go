@@ -18,9 +18,11 @@ import (
 )
 
 const (
-	flagTo     = "to"
-	flagAmount = "amount"
-	flagChain  = "chain"
+	flagTo               = "to"
+	flagAmount           = "amount"
+	flagChain            = "chain"
+	flagTimeoutHeight    = "timeout-height"
+	flagTimeoutTimestamp = "timeout-timestamp"
 )
 
 // IBCTransferCmd implements the IBC transfer command.
@@ -54,6 +56,8 @@ func IBCTransferCmd(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().String(flagTo, "", "Address to send coins")
 	cmd.Flags().String(flagAmount, "", "Amount of coins to send")
 	cmd.Flags().String(flagChain, "", "Destination chain to send coins")
+	cmd.Flags().Int64(flagTimeoutHeight, 0, "Destination chain height from which the transfer is refunded instead of delivered, 0 for none")
+	cmd.Flags().Int64(flagTimeoutTimestamp, 0, "Destination chain block time, in unix seconds, from which the transfer is refunded instead of delivered, 0 for none")
 
 	return cmd
 }
@@ -72,12 +76,8 @@ func buildMsg(from sdk.AccAddress) (sdk.Msg, error) {
 	}
 	to := sdk.AccAddress(bz)
 
-	packet := ibc.NewIBCPacket(from, to, coins, viper.GetString(client.FlagChainID),
-		viper.GetString(flagChain))
-
-	msg := ibc.IBCTransferMsg{
-		IBCPacket: packet,
-	}
+	msg := ibc.NewIBCTransferMsg(from, to, coins, viper.GetString(client.FlagChainID),
+		viper.GetString(flagChain), viper.GetInt64(flagTimeoutHeight), viper.GetInt64(flagTimeoutTimestamp))
 
 	return msg, nil
 }
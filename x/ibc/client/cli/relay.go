@@ -2,20 +2,19 @@ package cli
 
 import (
 	"os"
-	"time"
+	"path/filepath"
 
-	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/keys"
 	codec "github.com/cosmos/cosmos-sdk/codec"
-	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/cosmos-sdk/x/auth"
-	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
-	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
-	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/ibc/client/relayer"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/tendermint/tendermint/libs/cli"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 )
 
@@ -25,187 +24,132 @@ const (
 	FlagFromChainNode = "from-chain-node"
 	FlagToChainID     = "to-chain-id"
 	FlagToChainNode   = "to-chain-node"
+	FlagConfig        = "config"
+	FlagDBDir         = "db-dir"
+	FlagListenAddr    = "laddr"
 )
 
-type relayCommander struct {
-	cdc       *codec.Codec
-	address   sdk.AccAddress
-	decoder   auth.AccountDecoder
-	mainStore string
-	ibcStore  string
-	accStore  string
+// IBCRelayCmd implements the IBC relay command. The relayer either reads its
+// chains and paths from a configuration file, or relays between the two
+// chains given by flags with the key given by --from.
+func IBCRelayCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "relay",
+		Short: "Relay IBC packets between chains",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := relayConfig()
+			if err != nil {
+				return err
+			}
 
-	logger log.Logger
-}
+			chains, err := relayChains(cdc, config)
+			if err != nil {
+				return err
+			}
 
-// IBCRelayCmd implements the IBC relay command.
-func IBCRelayCmd(cdc *codec.Codec) *cobra.Command {
-	cmdr := relayCommander{
-		cdc:       cdc,
-		decoder:   authcmd.GetAccountDecoder(cdc),
-		ibcStore:  "ibc",
-		mainStore: "main",
-		accStore:  "acc",
-
-		logger: log.NewTMLogger(log.NewSyncWriter(os.Stdout)),
-	}
+			dbDir := config.DBDir
+			if !filepath.IsAbs(dbDir) {
+				dbDir = filepath.Join(viper.GetString(cli.HomeFlag), dbDir)
+			}
+			db, err := dbm.NewGoLevelDB("relayer", dbDir)
+			if err != nil {
+				return err
+			}
 
-	cmd := &cobra.Command{
-		Use: "relay",
-		Run: cmdr.runIBCRelay,
+			logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "relayer")
+			rlr, err := relayer.NewRelayer(cdc, config, chains, db, logger)
+			if err != nil {
+				return err
+			}
+			if err = rlr.Start(); err != nil {
+				return err
+			}
+
+			// wait forever and cleanup
+			cmn.TrapSignal(func() {
+				err := rlr.Stop()
+				if err != nil {
+					logger.Error("error stopping relayer", "err", err)
+				}
+				db.Close()
+			})
+
+			return nil
+		},
 	}
 
+	defaults := relayer.DefaultConfig()
+	cmd.Flags().String(FlagConfig, "", "Configuration file of the chains and paths to relay, overriding the other flags")
 	cmd.Flags().String(FlagFromChainID, "", "Chain ID for ibc node to check outgoing packets")
 	cmd.Flags().String(FlagFromChainNode, "tcp://localhost:26657", "<host>:<port> to tendermint rpc interface for this chain")
 	cmd.Flags().String(FlagToChainID, "", "Chain ID for ibc node to broadcast incoming packets")
 	cmd.Flags().String(FlagToChainNode, "tcp://localhost:36657", "<host>:<port> to tendermint rpc interface for this chain")
+	cmd.Flags().String(FlagDBDir, defaults.DBDir, "Directory of the database of the relayed sequences, relative to the home directory")
+	cmd.Flags().String(FlagListenAddr, defaults.ListenAddr, "The address for the status endpoint to listen on, disabled if empty")
 
-	cmd.MarkFlagRequired(FlagFromChainID)
-	cmd.MarkFlagRequired(FlagFromChainNode)
-	cmd.MarkFlagRequired(FlagToChainID)
-	cmd.MarkFlagRequired(FlagToChainNode)
-
+	viper.BindPFlag(FlagConfig, cmd.Flags().Lookup(FlagConfig))
 	viper.BindPFlag(FlagFromChainID, cmd.Flags().Lookup(FlagFromChainID))
 	viper.BindPFlag(FlagFromChainNode, cmd.Flags().Lookup(FlagFromChainNode))
 	viper.BindPFlag(FlagToChainID, cmd.Flags().Lookup(FlagToChainID))
 	viper.BindPFlag(FlagToChainNode, cmd.Flags().Lookup(FlagToChainNode))
+	viper.BindPFlag(FlagDBDir, cmd.Flags().Lookup(FlagDBDir))
+	viper.BindPFlag(FlagListenAddr, cmd.Flags().Lookup(FlagListenAddr))
 
 	return cmd
 }
 
-// nolint: unparam
-func (c relayCommander) runIBCRelay(cmd *cobra.Command, args []string) {
-	fromChainID := viper.GetString(FlagFromChainID)
-	fromChainNode := viper.GetString(FlagFromChainNode)
-	toChainID := viper.GetString(FlagToChainID)
-	toChainNode := viper.GetString(FlagToChainNode)
-
-	address, err := context.NewCLIContext().GetFromAddress()
-	if err != nil {
-		panic(err)
+// read the relayer configuration from the configuration file if any, from
+// the flags otherwise
+func relayConfig() (relayer.Config, error) {
+	if file := viper.GetString(FlagConfig); file != "" {
+		return relayer.LoadConfig(file)
 	}
 
-	c.address = address
-
-	c.loop(fromChainID, fromChainNode, toChainID, toChainNode)
-}
-
-// This is nolinted as someone is in the process of refactoring this to remove the goto
-// nolint: gocyclo
-func (c relayCommander) loop(fromChainID, fromChainNode, toChainID, toChainNode string) {
-	cliCtx := context.NewCLIContext()
-
-	passphrase, err := keys.ReadPassphraseFromStdin(cliCtx.FromAddressName)
-	if err != nil {
-		panic(err)
+	chain := relayer.ChainConfig{
+		Key:           viper.GetString(client.FlagFrom),
+		Gas:           client.GasFlagVar.Gas,
+		GasAdjustment: viper.GetFloat64(client.FlagGasAdjustment),
+		Fee:           viper.GetString(client.FlagFee),
 	}
+	if client.GasFlagVar.Simulate {
+		chain.Gas = 0
+	}
+	fromChain, toChain := chain, chain
+	fromChain.ChainID = viper.GetString(FlagFromChainID)
+	fromChain.Node = viper.GetString(FlagFromChainNode)
+	toChain.ChainID = viper.GetString(FlagToChainID)
+	toChain.Node = viper.GetString(FlagToChainNode)
+
+	config := relayer.DefaultConfig()
+	config.DBDir = viper.GetString(FlagDBDir)
+	config.ListenAddr = viper.GetString(FlagListenAddr)
+	config.Chains = []relayer.ChainConfig{fromChain, toChain}
+	config.Paths = []relayer.PathConfig{{ChainA: fromChain.ChainID, ChainB: toChain.ChainID}}
+	return config, config.ValidateBasic()
+}
 
-	ingressKey := ibc.IngressSequenceKey(fromChainID)
-
-OUTER:
-	for {
-		time.Sleep(5 * time.Second)
-
-		processedbz, err := query(toChainNode, ingressKey, c.ibcStore)
-		if err != nil {
-			panic(err)
-		}
-
-		var processed int64
-		if processedbz == nil {
-			processed = 0
-		} else if err = c.cdc.UnmarshalBinary(processedbz, &processed); err != nil {
-			panic(err)
-		}
-
-		lengthKey := ibc.EgressLengthKey(toChainID)
-		egressLengthbz, err := query(fromChainNode, lengthKey, c.ibcStore)
-		if err != nil {
-			c.logger.Error("error querying outgoing packet list length", "err", err)
-			continue OUTER //TODO replace with continue (I think it should just to the correct place where OUTER is now)
-		}
-
-		var egressLength int64
-		if egressLengthbz == nil {
-			egressLength = 0
-		} else if err = c.cdc.UnmarshalBinary(egressLengthbz, &egressLength); err != nil {
-			panic(err)
-		}
-
-		if egressLength > processed {
-			c.logger.Info("Detected IBC packet", "number", egressLength-1)
-		}
-
-		seq := c.getSequence(toChainNode)
-
-		for i := processed; i < egressLength; i++ {
-			egressbz, err := query(fromChainNode, ibc.EgressKey(toChainID, i), c.ibcStore)
-			if err != nil {
-				c.logger.Error("error querying egress packet", "err", err)
-				continue OUTER // TODO replace to break, will break first loop then send back to the beginning (aka OUTER)
-			}
-
-			err = c.broadcastTx(seq, toChainNode, c.refine(egressbz, i, passphrase))
-
-			seq++
-
+// connect to the chains of the configuration, reading the passphrase of each
+// key once
+func relayChains(cdc *codec.Codec, config relayer.Config) ([]relayer.Chain, error) {
+	passphrases := make(map[string]string)
+	chains := make([]relayer.Chain, 0, len(config.Chains))
+
+	for _, chainConfig := range config.Chains {
+		passphrase, ok := passphrases[chainConfig.Key]
+		if !ok {
+			var err error
+			passphrase, err = keys.GetPassphrase(chainConfig.Key)
 			if err != nil {
-				c.logger.Error("error broadcasting ingress packet", "err", err)
-				continue OUTER // TODO replace to break, will break first loop then send back to the beginning (aka OUTER)
+				return nil, err
 			}
-
-			c.logger.Info("Relayed IBC packet", "number", i)
+			passphrases[chainConfig.Key] = passphrase
 		}
-	}
-}
-
-func query(node string, key []byte, storeName string) (res []byte, err error) {
-	return context.NewCLIContext().WithNodeURI(node).QueryStore(key, storeName)
-}
-
-// nolint: unparam
-func (c relayCommander) broadcastTx(seq int64, node string, tx []byte) error {
-	_, err := context.NewCLIContext().WithNodeURI(node).BroadcastTx(tx)
-	return err
-}
-
-func (c relayCommander) getSequence(node string) int64 {
-	res, err := query(node, c.address, c.accStore)
-	if err != nil {
-		panic(err)
-	}
 
-	if nil != res {
-		account, err := c.decoder(res)
+		chain, err := relayer.NewNodeChain(cdc, chainConfig, passphrase)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
-
-		return account.GetSequence()
-	}
-
-	return 0
-}
-
-func (c relayCommander) refine(bz []byte, sequence int64, passphrase string) []byte {
-	var packet ibc.IBCPacket
-	if err := c.cdc.UnmarshalBinary(bz, &packet); err != nil {
-		panic(err)
-	}
-
-	msg := ibc.IBCReceiveMsg{
-		IBCPacket: packet,
-		Relayer:   c.address,
-		Sequence:  sequence,
+		chains = append(chains, chain)
 	}
-
-	txBldr := authtxb.NewTxBuilderFromCLI().WithSequence(sequence).WithCodec(c.cdc)
-	cliCtx := context.NewCLIContext()
-
-	res, err := txBldr.BuildAndSign(cliCtx.FromAddressName, passphrase, []sdk.Msg{msg})
-	if err != nil {
-		panic(err)
-	}
-
-	return res
+	return chains, nil
 }
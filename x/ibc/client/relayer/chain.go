@@ -0,0 +1,182 @@
+package relayer
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// Chain is a chain the relayer reads packets from and sends messages to.
+type Chain interface {
+	ChainID() string
+
+	// address signing the messages sent to the chain
+	Address() sdk.AccAddress
+
+	// QueryStore returns the value of a key of a store in the latest state.
+	QueryStore(key []byte, storeName string) ([]byte, error)
+
+	// QueryStoreWithProof returns the value of a key of a store at a height
+	// along with its multistore proof, which is an absence proof if the value
+	// is nil.
+	QueryStoreWithProof(key []byte, storeName string, height int64) (res, proof []byte, err error)
+
+	// QuerySubspace returns the key-value pairs of a store under a prefix in
+	// the latest state.
+	QuerySubspace(prefix []byte, storeName string) ([]sdk.KVPair, error)
+
+	// Commit returns the signed header at a height, or the latest one if the
+	// height is 0.
+	Commit(height int64) (tmtypes.SignedHeader, error)
+
+	// Validators returns the validator set which signed the header at a height.
+	Validators(height int64) (*tmtypes.ValidatorSet, error)
+
+	// SendMsgs signs the messages in a single transaction and returns once it
+	// is committed, or with an error if it failed.
+	SendMsgs(msgs []sdk.Msg) error
+}
+
+// node is a chain reached through the RPC interface of one of its nodes
+type node struct {
+	cdc        *codec.Codec
+	config     ChainConfig
+	address    sdk.AccAddress
+	passphrase string
+	cliCtx     context.CLIContext
+}
+
+var _ Chain = node{}
+
+// NewNodeChain returns a chain reached through a Tendermint node, signing
+// messages with a key of the local keybase.
+func NewNodeChain(cdc *codec.Codec, config ChainConfig, passphrase string) (Chain, error) {
+	info, err := keys.GetKeyInfo(config.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	cliCtx := context.NewCLIContext().
+		WithCodec(cdc).
+		WithAccountDecoder(authcmd.GetAccountDecoder(cdc)).
+		WithNodeURI(config.Node).
+		WithFromAddressName(config.Key)
+
+	return node{
+		cdc:        cdc,
+		config:     config,
+		address:    sdk.AccAddress(info.GetPubKey().Address()),
+		passphrase: passphrase,
+		cliCtx:     cliCtx,
+	}, nil
+}
+
+// nolint
+func (n node) ChainID() string         { return n.config.ChainID }
+func (n node) Address() sdk.AccAddress { return n.address }
+
+// implements Chain
+func (n node) QueryStore(key []byte, storeName string) ([]byte, error) {
+	return n.cliCtx.QueryStore(key, storeName)
+}
+
+// implements Chain
+func (n node) QueryStoreWithProof(key []byte, storeName string, height int64) (res, proof []byte, err error) {
+	client, err := n.cliCtx.GetNode()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/store/%s/key", storeName)
+	opts := rpcclient.ABCIQueryOptions{Height: height, Trusted: false}
+	result, err := client.ABCIQueryWithOptions(path, key, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp := result.Response
+	if !resp.IsOK() {
+		return nil, nil, fmt.Errorf("query failed: (%d) %s", resp.Code, resp.Log)
+	}
+	return resp.Value, resp.Proof, nil
+}
+
+// implements Chain
+func (n node) QuerySubspace(prefix []byte, storeName string) ([]sdk.KVPair, error) {
+	return n.cliCtx.QuerySubspaceAll(prefix, storeName)
+}
+
+// implements Chain
+func (n node) Commit(height int64) (tmtypes.SignedHeader, error) {
+	client, err := n.cliCtx.GetNode()
+	if err != nil {
+		return tmtypes.SignedHeader{}, err
+	}
+
+	var h *int64
+	if height != 0 {
+		h = &height
+	}
+	commit, err := client.Commit(h)
+	if err != nil {
+		return tmtypes.SignedHeader{}, err
+	}
+	return commit.SignedHeader, nil
+}
+
+// implements Chain
+func (n node) Validators(height int64) (*tmtypes.ValidatorSet, error) {
+	client, err := n.cliCtx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+
+	validators, err := client.Validators(&height)
+	if err != nil {
+		return nil, err
+	}
+	return tmtypes.NewValidatorSet(validators.Validators), nil
+}
+
+// implements Chain
+func (n node) SendMsgs(msgs []sdk.Msg) error {
+	account, err := n.cliCtx.GetAccount(n.address)
+	if err != nil {
+		return err
+	}
+
+	txBldr := authtxb.TxBuilder{
+		Codec:         n.cdc,
+		ChainID:       n.config.ChainID,
+		AccountNumber: account.GetAccountNumber(),
+		Sequence:      account.GetSequence(),
+		Gas:           n.config.Gas,
+		GasAdjustment: n.config.GasAdjustment,
+		SimulateGas:   n.config.Gas == 0,
+		Fee:           n.config.Fee,
+	}
+
+	if txBldr.SimulateGas {
+		txBldr, err = utils.EnrichCtxWithGas(txBldr, n.cliCtx, n.config.Key, msgs)
+		if err != nil {
+			return err
+		}
+	}
+
+	txBytes, err := txBldr.BuildAndSign(n.config.Key, n.passphrase, msgs)
+	if err != nil {
+		return err
+	}
+
+	_, err = n.cliCtx.BroadcastTx(txBytes)
+	return err
+}
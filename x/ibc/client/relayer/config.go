@@ -0,0 +1,111 @@
+package relayer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// ChainConfig is the connection of the relayer to a chain and the key signing
+// its transactions.
+type ChainConfig struct {
+	ChainID string `mapstructure:"chain_id"`
+	Node    string `mapstructure:"node"`
+	Key     string `mapstructure:"key"`
+
+	// gas limit of the relayed transactions, estimated by simulating them if 0
+	Gas           int64   `mapstructure:"gas"`
+	GasAdjustment float64 `mapstructure:"gas_adjustment"`
+	Fee           string  `mapstructure:"fee"`
+}
+
+// PathConfig is a pair of chains between which packets are relayed, in both
+// directions.
+type PathConfig struct {
+	ChainA string `mapstructure:"chain_a"`
+	ChainB string `mapstructure:"chain_b"`
+}
+
+// Config is the configuration of the relayer daemon.
+type Config struct {
+	// directory of the database persisting the relayed sequences
+	DBDir string `mapstructure:"db_dir"`
+	// address of the status endpoint, disabled if empty
+	ListenAddr string `mapstructure:"listen_addr"`
+
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	MaxMsgsPerTx int           `mapstructure:"max_msgs_per_tx"`
+	// bounds of the delay before retrying a path after an error, doubled
+	// after each consecutive error
+	MinBackoff time.Duration `mapstructure:"min_backoff"`
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+
+	Chains []ChainConfig `mapstructure:"chains"`
+	Paths  []PathConfig  `mapstructure:"paths"`
+}
+
+// DefaultConfig returns the default configuration, without any chain.
+func DefaultConfig() Config {
+	return Config{
+		DBDir:        "relayer",
+		ListenAddr:   "localhost:26670",
+		PollInterval: 5 * time.Second,
+		MaxMsgsPerTx: 10,
+		MinBackoff:   1 * time.Second,
+		MaxBackoff:   1 * time.Minute,
+	}
+}
+
+// LoadConfig reads a configuration file, in any format supported by viper,
+// on top of the default configuration.
+func LoadConfig(file string) (Config, error) {
+	cfg := DefaultConfig()
+
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		return cfg, err
+	}
+	if err := v.Unmarshal(&cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.ValidateBasic()
+}
+
+// ValidateBasic checks the configuration is complete and consistent.
+func (cfg Config) ValidateBasic() error {
+	if cfg.PollInterval <= 0 {
+		return fmt.Errorf("poll_interval must be positive")
+	}
+	if cfg.MaxMsgsPerTx <= 0 {
+		return fmt.Errorf("max_msgs_per_tx must be positive")
+	}
+	if cfg.MinBackoff <= 0 || cfg.MaxBackoff < cfg.MinBackoff {
+		return fmt.Errorf("min_backoff must be positive and at most max_backoff")
+	}
+
+	chains := make(map[string]bool)
+	for _, chain := range cfg.Chains {
+		if chain.ChainID == "" || chain.Node == "" || chain.Key == "" {
+			return fmt.Errorf("chain_id, node and key must be set for each chain")
+		}
+		if chains[chain.ChainID] {
+			return fmt.Errorf("chain %s is configured twice", chain.ChainID)
+		}
+		chains[chain.ChainID] = true
+	}
+
+	if len(cfg.Paths) == 0 {
+		return fmt.Errorf("no path to relay")
+	}
+	for _, path := range cfg.Paths {
+		if !chains[path.ChainA] || !chains[path.ChainB] {
+			return fmt.Errorf("path %s/%s refers to an unknown chain", path.ChainA, path.ChainB)
+		}
+		if path.ChainA == path.ChainB {
+			return fmt.Errorf("path %s/%s must be between two distinct chains", path.ChainA, path.ChainB)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,515 @@
+package relayer
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// name of the store of the IBC module on the relayed chains
+const ibcStoreName = "ibc"
+
+// PathStatus is the progress of the relayer on the packets sent by a chain to
+// another.
+type PathStatus struct {
+	SrcChain  string `json:"src_chain"`
+	DestChain string `json:"dest_chain"`
+
+	// sequence of the latest packet relayed, -1 if none
+	LastRelayedSequence int64 `json:"last_relayed_sequence"`
+	// packets sent by the source chain not received yet by the destination chain
+	PendingPackets int64 `json:"pending_packets"`
+
+	LastRelayTime       time.Time `json:"last_relay_time"`
+	LastError           string    `json:"last_error"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+}
+
+// Relayer relays the packets between the chains of each configured path, in
+// both directions. The sequence of the latest packet relayed on each
+// direction is persisted in a database.
+type Relayer struct {
+	cdc    *codec.Codec
+	config Config
+	paths  []path
+	db     dbm.DB
+	logger log.Logger
+
+	mtx    sync.Mutex
+	status map[string]*PathStatus
+
+	quit   chan struct{}
+	wg     sync.WaitGroup
+	server *http.Server
+}
+
+// direction in which packets are relayed between the chains of a path
+type path struct {
+	src  Chain
+	dest Chain
+}
+
+func (p path) String() string {
+	return fmt.Sprintf("%s/%s", p.src.ChainID(), p.dest.ChainID())
+}
+
+// NewRelayer returns a relayer for the paths of the configuration between the
+// given chains.
+func NewRelayer(cdc *codec.Codec, config Config, chains []Chain, db dbm.DB, logger log.Logger) (*Relayer, error) {
+	if err := config.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]Chain)
+	for _, chain := range chains {
+		byID[chain.ChainID()] = chain
+	}
+
+	r := &Relayer{
+		cdc:    cdc,
+		config: config,
+		db:     db,
+		logger: logger,
+		status: make(map[string]*PathStatus),
+		quit:   make(chan struct{}),
+	}
+
+	for _, pc := range config.Paths {
+		a, okA := byID[pc.ChainA]
+		b, okB := byID[pc.ChainB]
+		if !okA || !okB {
+			return nil, fmt.Errorf("no chain given for path %s/%s", pc.ChainA, pc.ChainB)
+		}
+
+		for _, p := range []path{{a, b}, {b, a}} {
+			r.paths = append(r.paths, p)
+			r.status[p.String()] = &PathStatus{
+				SrcChain:            p.src.ChainID(),
+				DestChain:           p.dest.ChainID(),
+				LastRelayedSequence: r.getLastRelayedSequence(p),
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// Start relays the packets of every path in the background until the relayer
+// is stopped, and serves the status endpoint if an address is configured.
+func (r *Relayer) Start() error {
+	if r.config.ListenAddr != "" {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/status", r.StatusHandler())
+		r.server = &http.Server{Addr: r.config.ListenAddr, Handler: mux}
+
+		go func() {
+			err := r.server.ListenAndServe()
+			if err != nil && err != http.ErrServerClosed {
+				r.logger.Error("status endpoint stopped", "err", err)
+			}
+		}()
+	}
+
+	for _, p := range r.paths {
+		r.wg.Add(1)
+		go r.run(p)
+	}
+	return nil
+}
+
+// Stop waits for the paths being relayed to complete their current round.
+func (r *Relayer) Stop() error {
+	close(r.quit)
+	r.wg.Wait()
+
+	if r.server != nil {
+		return r.server.Close()
+	}
+	return nil
+}
+
+// relay a path every poll interval, backing off after errors
+func (r *Relayer) run(p path) {
+	defer r.wg.Done()
+
+	backoff := r.config.MinBackoff
+	for {
+		wait := r.config.PollInterval
+		if err := r.RelayOnce(p.src.ChainID(), p.dest.ChainID()); err != nil {
+			r.logger.Error("error relaying packets", "path", p, "err", err, "retry", backoff)
+			wait = backoff
+			backoff = nextBackoff(backoff, r.config.MaxBackoff)
+		} else {
+			backoff = r.config.MinBackoff
+		}
+
+		select {
+		case <-r.quit:
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// double the backoff up to its maximum
+func nextBackoff(backoff, max time.Duration) time.Duration {
+	backoff *= 2
+	if backoff > max {
+		return max
+	}
+	return backoff
+}
+
+// RelayOnce relays the packets sent by a chain not yet received by another,
+// and resolves the packets sent by the latter which have been acknowledged or
+// timed out.
+func (r *Relayer) RelayOnce(srcChain, destChain string) error {
+	for _, p := range r.paths {
+		if p.src.ChainID() == srcChain && p.dest.ChainID() == destChain {
+			err := r.relay(p)
+			r.recordResult(p, err)
+			return err
+		}
+	}
+	return fmt.Errorf("no path from %s to %s", srcChain, destChain)
+}
+
+func (r *Relayer) relay(p path) error {
+	processed, err := r.queryInt64(p.dest, ibc.IngressSequenceKey(p.src.ChainID()))
+	if err != nil {
+		return err
+	}
+
+	// a destination node lagging behind would have the packets already
+	// relayed received again
+	last := r.getLastRelayedSequence(p)
+	if processed <= last {
+		r.logger.Info("Waiting for the destination chain to catch up", "path", p,
+			"processed", processed, "relayed", last+1)
+		return nil
+	}
+
+	egressLength, err := r.queryInt64(p.src, ibc.EgressLengthKey(p.dest.ChainID()))
+	if err != nil {
+		return err
+	}
+	r.setPendingPackets(p, egressLength-processed)
+
+	// the proofs are queried at the height before the header they are
+	// verified against, as the app hash of header H commits to the state
+	// after block H-1
+	clientMsg, header, err := r.getClientMsg(p)
+	if err != nil {
+		return err
+	}
+	proofHeight := header.Height
+
+	var msgs []sdk.Msg
+	for i := processed; i < egressLength; i++ {
+		msg, err := r.getReceiveMsg(p, i, proofHeight)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+
+	// resolve the packets sent by the destination chain which have been
+	// acknowledged or have timed out on the source chain
+	pending, err := p.dest.QuerySubspace(ibc.PendingPacketPrefix(p.src.ChainID()), ibcStoreName)
+	if err != nil {
+		return err
+	}
+	for _, kv := range pending {
+		var index int64
+		if err = r.cdc.UnmarshalBinary(kv.Value, &index); err != nil {
+			return err
+		}
+
+		msg, err := r.getResolveMsg(p, index, header.Height, header.Time)
+		if err != nil {
+			return err
+		}
+		if msg != nil {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	for _, batch := range batchMsgs(msgs, r.config.MaxMsgsPerTx) {
+		if clientMsg != nil {
+			// the client message is only sent with the first batch
+			batch = append([]sdk.Msg{clientMsg}, batch...)
+			clientMsg = nil
+		}
+
+		if err = p.dest.SendMsgs(batch); err != nil {
+			return err
+		}
+
+		for _, msg := range batch {
+			if msg, ok := msg.(ibc.IBCReceiveMsg); ok {
+				r.setLastRelayedSequence(p, msg.Sequence)
+				r.setPendingPackets(p, egressLength-msg.Sequence-1)
+			}
+		}
+		r.logger.Info("Relayed IBC messages", "path", p, "msgs", len(batch))
+	}
+
+	return nil
+}
+
+// split messages in batches of at most max messages
+func batchMsgs(msgs []sdk.Msg, max int) (batches [][]sdk.Msg) {
+	for len(msgs) > max {
+		batches = append(batches, msgs[:max:max])
+		msgs = msgs[max:]
+	}
+	return append(batches, msgs)
+}
+
+// Returns the message submitting the latest header of the source chain to the
+// destination chain, or nil if the destination chain already verified it,
+// along with the latest source chain header verified once the message is
+// processed.
+func (r *Relayer) getClientMsg(p path) (sdk.Msg, tmtypes.Header, error) {
+	statebz, err := p.dest.QueryStore(ibc.ConsensusStateKey(p.src.ChainID()), ibcStoreName)
+	if err != nil {
+		return nil, tmtypes.Header{}, err
+	}
+
+	commit, err := p.src.Commit(0)
+	if err != nil {
+		return nil, tmtypes.Header{}, err
+	}
+
+	var state ibc.ConsensusState
+	if statebz != nil {
+		if err = r.cdc.UnmarshalBinary(statebz, &state); err != nil {
+			return nil, tmtypes.Header{}, err
+		}
+		if state.Height >= commit.Height {
+			commit, err = p.src.Commit(state.Height)
+			if err != nil {
+				return nil, tmtypes.Header{}, err
+			}
+			return nil, *commit.Header, nil
+		}
+	}
+
+	valSet, err := p.src.Validators(commit.Height)
+	if err != nil {
+		return nil, tmtypes.Header{}, err
+	}
+
+	if statebz == nil {
+		return ibc.IBCCreateClientMsg{
+			Header:     commit,
+			Validators: valSet,
+			Signer:     p.dest.Address(),
+		}, *commit.Header, nil
+	}
+	return ibc.IBCUpdateClientMsg{
+		Header:     commit,
+		Validators: valSet,
+		Signer:     p.dest.Address(),
+	}, *commit.Header, nil
+}
+
+// Returns the message receiving a packet of the source chain on the
+// destination chain.
+func (r *Relayer) getReceiveMsg(p path, index, proofHeight int64) (sdk.Msg, error) {
+	egressKey := ibc.EgressKey(p.dest.ChainID(), index)
+	packetbz, proof, err := p.src.QueryStoreWithProof(egressKey, ibcStoreName, proofHeight-1)
+	if err != nil {
+		return nil, err
+	}
+	if packetbz == nil {
+		return nil, fmt.Errorf("packet %d not found on %s", index, p.src.ChainID())
+	}
+
+	var packet ibc.IBCPacket
+	if err = r.cdc.UnmarshalBinary(packetbz, &packet); err != nil {
+		return nil, err
+	}
+
+	return ibc.IBCReceiveMsg{
+		IBCPacket:   packet,
+		Relayer:     p.dest.Address(),
+		Sequence:    index,
+		ProofHeight: proofHeight,
+		Proof:       proof,
+	}, nil
+}
+
+// Returns the message resolving a packet pending on the destination chain,
+// either with its acknowledgement written on the source chain or with the
+// proof that it timed out, or nil if it can be resolved neither way yet.
+func (r *Relayer) getResolveMsg(p path, index, proofHeight int64, proofTime time.Time) (sdk.Msg, error) {
+	packetbz, err := p.dest.QueryStore(ibc.EgressKey(p.src.ChainID(), index), ibcStoreName)
+	if err != nil {
+		return nil, err
+	}
+	var packet ibc.IBCPacket
+	if err = r.cdc.UnmarshalBinary(packetbz, &packet); err != nil {
+		return nil, err
+	}
+
+	ackKey := ibc.AckKey(p.dest.ChainID(), index)
+	ackbz, proof, err := p.src.QueryStoreWithProof(ackKey, ibcStoreName, proofHeight-1)
+	if err != nil {
+		return nil, err
+	}
+	if ackbz != nil {
+		var ack ibc.IBCAcknowledgement
+		if err = r.cdc.UnmarshalBinary(ackbz, &ack); err != nil {
+			return nil, err
+		}
+		return ibc.IBCAcknowledgementMsg{
+			IBCPacket:       packet,
+			Acknowledgement: ack,
+			Relayer:         p.dest.Address(),
+			Sequence:        index,
+			ProofHeight:     proofHeight,
+			Proof:           proof,
+		}, nil
+	}
+
+	if !packet.TimedOut(proofHeight, proofTime) {
+		return nil, nil
+	}
+
+	ingressKey := ibc.IngressSequenceKey(p.dest.ChainID())
+	nextbz, proof, err := p.src.QueryStoreWithProof(ingressKey, ibcStoreName, proofHeight-1)
+	if err != nil {
+		return nil, err
+	}
+	var next int64
+	if nextbz != nil {
+		if err = r.cdc.UnmarshalBinary(nextbz, &next); err != nil {
+			return nil, err
+		}
+	}
+	if next > index {
+		// received, the acknowledgement will be relayed once committed
+		return nil, nil
+	}
+
+	return ibc.IBCTimeoutMsg{
+		IBCPacket:    packet,
+		Relayer:      p.dest.Address(),
+		Sequence:     index,
+		NextSequence: next,
+		ProofHeight:  proofHeight,
+		Proof:        proof,
+	}, nil
+}
+
+// query an int64 of the IBC store, 0 if absent
+func (r *Relayer) queryInt64(chain Chain, key []byte) (res int64, err error) {
+	bz, err := chain.QueryStore(key, ibcStoreName)
+	if err != nil || bz == nil {
+		return 0, err
+	}
+	err = r.cdc.UnmarshalBinary(bz, &res)
+	return res, err
+}
+
+// ------------------------------
+// Persistence
+
+// LastRelayedSequenceKey is the database key of the sequence of the latest
+// packet relayed from a chain to another.
+func LastRelayedSequenceKey(srcChain, destChain string) []byte {
+	return []byte(fmt.Sprintf("sequence/%s/%s", srcChain, destChain))
+}
+
+func (r *Relayer) getLastRelayedSequence(p path) int64 {
+	bz := r.db.Get(LastRelayedSequenceKey(p.src.ChainID(), p.dest.ChainID()))
+	if bz == nil {
+		return -1
+	}
+
+	var sequence int64
+	r.cdc.MustUnmarshalBinary(bz, &sequence)
+	return sequence
+}
+
+func (r *Relayer) setLastRelayedSequence(p path, sequence int64) {
+	bz := r.cdc.MustMarshalBinary(sequence)
+	r.db.SetSync(LastRelayedSequenceKey(p.src.ChainID(), p.dest.ChainID()), bz)
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.status[p.String()].LastRelayedSequence = sequence
+}
+
+// ------------------------------
+// Status
+
+func (r *Relayer) setPendingPackets(p path, pending int64) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.status[p.String()].PendingPackets = pending
+}
+
+func (r *Relayer) recordResult(p path, err error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	status := r.status[p.String()]
+	if err != nil {
+		status.LastError = err.Error()
+		status.ConsecutiveFailures++
+		return
+	}
+	status.LastError = ""
+	status.ConsecutiveFailures = 0
+	status.LastRelayTime = time.Now()
+}
+
+// Status returns the status of every path, ordered by source and destination
+// chain.
+func (r *Relayer) Status() []PathStatus {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	statuses := make([]PathStatus, 0, len(r.status))
+	for _, status := range r.status {
+		statuses = append(statuses, *status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].SrcChain != statuses[j].SrcChain {
+			return statuses[i].SrcChain < statuses[j].SrcChain
+		}
+		return statuses[i].DestChain < statuses[j].DestChain
+	})
+	return statuses
+}
+
+// StatusHandler serves the status of every path as JSON.
+func (r *Relayer) StatusHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		output, err := codec.MarshalJSONIndent(r.cdc, r.Status())
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(output)
+	}
+}
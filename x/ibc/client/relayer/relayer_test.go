@@ -0,0 +1,371 @@
+package relayer
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+)
+
+func makeCodec() *codec.Codec {
+	var cdc = codec.New()
+
+	cdc.RegisterInterface((*sdk.Msg)(nil), nil)
+	ibc.RegisterCodec(cdc)
+	cdc.RegisterInterface((*auth.Account)(nil), nil)
+	cdc.RegisterConcrete(&auth.BaseAccount{}, "test/relayer/Account", nil)
+	codec.RegisterCrypto(cdc)
+
+	cdc.Seal()
+
+	return cdc
+}
+
+// mockChain is an in-process chain running the IBC module, committing a
+// block for each transaction.
+type mockChain struct {
+	mtx sync.Mutex
+
+	chainID string
+	address sdk.AccAddress
+	key     *sdk.KVStoreKey
+	cms     sdk.CommitMultiStore
+	ibcm    ibc.Mapper
+	ck      bank.Keeper
+	handler sdk.Handler
+
+	valSet   *tmtypes.ValidatorSet
+	privVals []tmtypes.PrivValidator
+	headers  map[int64]tmtypes.SignedHeader
+	height   int64
+
+	txs      [][]sdk.Msg
+	failures int // number of transactions to fail
+}
+
+var _ Chain = (*mockChain)(nil)
+
+func newMockChain(t *testing.T, cdc *codec.Codec, chainID string) *mockChain {
+	key := sdk.NewKVStoreKey(ibcStoreName)
+	db := dbm.NewMemDB()
+	cms := store.NewCommitMultiStore(db)
+	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	require.Nil(t, cms.LoadLatestVersion())
+
+	am := auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount)
+	ck := bank.NewBaseKeeper(am)
+	ibcm := ibc.NewMapper(cdc, key, ibc.DefaultCodespace)
+	ibcm.Router().AddRoute(ibc.TransferRoute, ibc.NewTransferCallbacks(ibcm, ck))
+
+	valSet, privVals := tmtypes.RandValidatorSet(4, 10)
+	c := &mockChain{
+		chainID:  chainID,
+		address:  sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address()),
+		key:      key,
+		cms:      cms,
+		ibcm:     ibcm,
+		ck:       ck,
+		handler:  ibc.NewHandler(ibcm, ck),
+		valSet:   valSet,
+		privVals: privVals,
+		headers:  make(map[int64]tmtypes.SignedHeader),
+	}
+	c.commit()
+	return c
+}
+
+// set the trusted roots of two chains to each other's validator sets, as
+// they would be at genesis
+func trustChains(chainA, chainB *mockChain) {
+	chainA.ibcm.SetTrustedRoot(chainA.context(), ibc.TrustedRoot{ChainID: chainB.chainID, ValidatorsHash: chainB.valSet.Hash()})
+	chainB.ibcm.SetTrustedRoot(chainB.context(), ibc.TrustedRoot{ChainID: chainA.chainID, ValidatorsHash: chainA.valSet.Hash()})
+}
+
+func (c *mockChain) context() sdk.Context {
+	header := abci.Header{ChainID: c.chainID, Height: c.height, Time: c.headers[c.height].Time}
+	return sdk.NewContext(c.cms, header, false, log.NewNopLogger())
+}
+
+// commit the state and sign the next header, whose app hash commits to it
+func (c *mockChain) commit() {
+	commitID := c.cms.Commit()
+	c.height = commitID.Version + 1
+
+	header := &tmtypes.Header{
+		ChainID:        c.chainID,
+		Height:         c.height,
+		Time:           time.Now(),
+		ValidatorsHash: c.valSet.Hash(),
+		AppHash:        commitID.Hash,
+	}
+	blockID := tmtypes.BlockID{Hash: header.Hash()}
+	voteSet := tmtypes.NewVoteSet(c.chainID, c.height, 0, tmtypes.VoteTypePrecommit, c.valSet)
+	commit, err := tmtypes.MakeCommit(blockID, c.height, 0, voteSet, c.privVals)
+	if err != nil {
+		panic(err)
+	}
+	c.headers[c.height] = tmtypes.SignedHeader{Header: header, Commit: commit}
+}
+
+func (c *mockChain) query(key []byte, height int64, prove bool) (res, proof []byte, err error) {
+	resp := c.cms.(sdk.Queryable).Query(abci.RequestQuery{
+		Path:   fmt.Sprintf("/%s/key", c.key.Name()),
+		Data:   key,
+		Height: height,
+		Prove:  prove,
+	})
+	if !resp.IsOK() {
+		return nil, nil, fmt.Errorf("query failed: (%d) %s", resp.Code, resp.Log)
+	}
+	return resp.Value, resp.Proof, nil
+}
+
+// nolint
+func (c *mockChain) ChainID() string         { return c.chainID }
+func (c *mockChain) Address() sdk.AccAddress { return c.address }
+
+func (c *mockChain) QueryStore(key []byte, storeName string) ([]byte, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	res, _, err := c.query(key, c.cms.LastCommitID().Version, false)
+	return res, err
+}
+
+func (c *mockChain) QueryStoreWithProof(key []byte, storeName string, height int64) (res, proof []byte, err error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.query(key, height, true)
+}
+
+func (c *mockChain) QuerySubspace(prefix []byte, storeName string) (kvs []sdk.KVPair, err error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	iter := sdk.KVStorePrefixIterator(c.context().KVStore(c.key), prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		kvs = append(kvs, sdk.KVPair{Key: iter.Key(), Value: iter.Value()})
+	}
+	return kvs, nil
+}
+
+func (c *mockChain) Commit(height int64) (tmtypes.SignedHeader, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if height == 0 {
+		height = c.height
+	}
+	header, ok := c.headers[height]
+	if !ok {
+		return header, fmt.Errorf("no header at height %d", height)
+	}
+	return header, nil
+}
+
+func (c *mockChain) Validators(height int64) (*tmtypes.ValidatorSet, error) {
+	return c.valSet, nil
+}
+
+func (c *mockChain) SendMsgs(msgs []sdk.Msg) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.failures > 0 {
+		c.failures--
+		return fmt.Errorf("connection refused")
+	}
+
+	ctx, write := c.context().CacheContext()
+	for _, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return err
+		}
+		res := c.handler(ctx, msg)
+		if !res.IsOK() {
+			return fmt.Errorf("deliverTx failed: (%d) %s", res.Code, res.Log)
+		}
+	}
+	write()
+	c.commit()
+
+	c.txs = append(c.txs, msgs)
+	return nil
+}
+
+func testConfig(chainA, chainB string) Config {
+	config := DefaultConfig()
+	config.ListenAddr = ""
+	config.MaxMsgsPerTx = 2
+	config.Chains = []ChainConfig{
+		{ChainID: chainA, Node: "tcp://localhost:26657", Key: "relayer"},
+		{ChainID: chainB, Node: "tcp://localhost:36657", Key: "relayer"},
+	}
+	config.Paths = []PathConfig{{ChainA: chainA, ChainB: chainB}}
+	return config
+}
+
+func TestRelayer(t *testing.T) {
+	cdc := makeCodec()
+	chainA := newMockChain(t, cdc, "chaina")
+	chainB := newMockChain(t, cdc, "chainb")
+	trustChains(chainA, chainB)
+	config := testConfig("chaina", "chainb")
+	db := dbm.NewMemDB()
+
+	r, err := NewRelayer(cdc, config, []Chain{chainA, chainB}, db, log.NewNopLogger())
+	require.Nil(t, err)
+	require.Equal(t, int64(-1), r.Status()[0].LastRelayedSequence)
+
+	// send coins from chain A to chain B in three packets
+	src := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	dest := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	_, _, sdkErr := chainA.ck.AddCoins(chainA.context(), src, sdk.Coins{sdk.NewInt64Coin("mycoin", 30)})
+	require.Nil(t, sdkErr)
+	for i := 0; i < 3; i++ {
+		msg := ibc.NewIBCTransferMsg(src, dest, sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}, "chaina", "chainb", 0, 0)
+		require.Nil(t, chainA.SendMsgs([]sdk.Msg{msg}))
+	}
+
+	// the packets are received in two batches, the first one creating the
+	// client of chain A
+	require.Nil(t, r.RelayOnce("chaina", "chainb"))
+	require.Len(t, chainB.txs, 2)
+	require.Len(t, chainB.txs[0], 3)
+	require.IsType(t, ibc.IBCCreateClientMsg{}, chainB.txs[0][0])
+	require.Len(t, chainB.txs[1], 1)
+
+	coins, _, sdkErr := chainB.ck.AddCoins(chainB.context(), dest, nil)
+	require.Nil(t, sdkErr)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("ibc/chaina/mycoin", 30)}, coins)
+
+	status := r.Status()
+	require.Equal(t, "chaina", status[0].SrcChain)
+	require.Equal(t, int64(2), status[0].LastRelayedSequence)
+	require.Equal(t, int64(0), status[0].PendingPackets)
+	require.Equal(t, 0, status[0].ConsecutiveFailures)
+
+	// nothing left to relay
+	require.Nil(t, r.RelayOnce("chaina", "chainb"))
+	require.Len(t, chainB.txs, 2)
+
+	// the acknowledgements are relayed back to chain A
+	require.Len(t, chainA.ibcm.GetPendingPackets(chainA.context(), "chainb"), 3)
+	require.Nil(t, r.RelayOnce("chainb", "chaina"))
+	require.Len(t, chainA.ibcm.GetPendingPackets(chainA.context(), "chainb"), 0)
+
+	// the relayed sequences are persisted
+	r, err = NewRelayer(cdc, config, []Chain{chainA, chainB}, db, log.NewNopLogger())
+	require.Nil(t, err)
+	require.Equal(t, int64(2), r.Status()[0].LastRelayedSequence)
+	require.Equal(t, int64(-1), r.Status()[1].LastRelayedSequence)
+}
+
+func TestRelayerFailures(t *testing.T) {
+	cdc := makeCodec()
+	chainA := newMockChain(t, cdc, "chaina")
+	chainB := newMockChain(t, cdc, "chainb")
+	trustChains(chainA, chainB)
+	config := testConfig("chaina", "chainb")
+
+	r, err := NewRelayer(cdc, config, []Chain{chainA, chainB}, dbm.NewMemDB(), log.NewNopLogger())
+	require.Nil(t, err)
+
+	src := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	_, _, sdkErr := chainA.ck.AddCoins(chainA.context(), src, sdk.Coins{sdk.NewInt64Coin("mycoin", 10)})
+	require.Nil(t, sdkErr)
+	msg := ibc.NewIBCTransferMsg(src, src, sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}, "chaina", "chainb", 0, 0)
+	require.Nil(t, chainA.SendMsgs([]sdk.Msg{msg}))
+
+	chainB.failures = 2
+	require.NotNil(t, r.RelayOnce("chaina", "chainb"))
+	require.NotNil(t, r.RelayOnce("chaina", "chainb"))
+
+	status := r.Status()[0]
+	require.Equal(t, 2, status.ConsecutiveFailures)
+	require.Equal(t, "connection refused", status.LastError)
+	require.Equal(t, int64(-1), status.LastRelayedSequence)
+	require.Equal(t, int64(1), status.PendingPackets)
+
+	require.Nil(t, r.RelayOnce("chaina", "chainb"))
+	status = r.Status()[0]
+	require.Equal(t, 0, status.ConsecutiveFailures)
+	require.Equal(t, "", status.LastError)
+	require.Equal(t, int64(0), status.LastRelayedSequence)
+
+	// a destination node behind the relayed sequence is not sent the packets again
+	r.setLastRelayedSequence(r.paths[0], 5)
+	require.Nil(t, r.RelayOnce("chaina", "chainb"))
+	require.Len(t, chainB.txs, 1)
+
+	require.NotNil(t, r.RelayOnce("chaina", "chainc"))
+}
+
+func TestRelayerStatusHandler(t *testing.T) {
+	cdc := makeCodec()
+	chainA := newMockChain(t, cdc, "chaina")
+	chainB := newMockChain(t, cdc, "chainb")
+	trustChains(chainA, chainB)
+
+	r, err := NewRelayer(cdc, testConfig("chaina", "chainb"), []Chain{chainA, chainB}, dbm.NewMemDB(), log.NewNopLogger())
+	require.Nil(t, err)
+
+	rec := httptest.NewRecorder()
+	r.StatusHandler()(rec, httptest.NewRequest("GET", "/status", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var statuses []PathStatus
+	require.Nil(t, cdc.UnmarshalJSON(rec.Body.Bytes(), &statuses))
+	require.Len(t, statuses, 2)
+	require.Equal(t, "chaina", statuses[0].SrcChain)
+	require.Equal(t, "chainb", statuses[0].DestChain)
+	require.Equal(t, "chainb", statuses[1].SrcChain)
+}
+
+func TestNextBackoff(t *testing.T) {
+	max := 10 * time.Second
+	require.Equal(t, 2*time.Second, nextBackoff(time.Second, max))
+	require.Equal(t, 8*time.Second, nextBackoff(4*time.Second, max))
+	require.Equal(t, max, nextBackoff(8*time.Second, max))
+	require.Equal(t, max, nextBackoff(max, max))
+}
+
+func TestConfigValidateBasic(t *testing.T) {
+	config := testConfig("chaina", "chainb")
+	require.Nil(t, config.ValidateBasic())
+
+	invalid := config
+	invalid.Paths = []PathConfig{{ChainA: "chaina", ChainB: "chainc"}}
+	require.NotNil(t, invalid.ValidateBasic())
+
+	invalid = config
+	invalid.Paths = []PathConfig{{ChainA: "chaina", ChainB: "chaina"}}
+	require.NotNil(t, invalid.ValidateBasic())
+
+	invalid = config
+	invalid.Chains = append(invalid.Chains, invalid.Chains[0])
+	require.NotNil(t, invalid.ValidateBasic())
+
+	invalid = config
+	invalid.MaxMsgsPerTx = 0
+	require.NotNil(t, invalid.ValidateBasic())
+
+	invalid = config
+	invalid.MaxBackoff = invalid.MinBackoff / 2
+	require.NotNil(t, invalid.ValidateBasic())
+}
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"fmt"
 	"io/ioutil"
 	"net/http"
 
@@ -19,9 +20,11 @@ import (
 // RegisterRoutes - Central function to define routes that get registered by the main application
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, kb keys.Keybase) {
 	r.HandleFunc("/ibc/{destchain}/{address}/send", TransferRequestHandlerFn(cdc, kb, cliCtx)).Methods("POST")
+	r.HandleFunc("/ibc/denom_traces/{denom:.+}", denomTraceHandlerFn(cdc, cliCtx)).Methods("GET")
 }
 
-type transferBody struct {
+// TransferBody is the REST body transferring coins to an address on another chain
+type TransferBody struct {
 	// Fees             sdk.Coin  `json="fees"`
 	Amount           sdk.Coins `json:"amount"`
 	LocalAccountName string    `json:"name"`
@@ -31,6 +34,8 @@ type transferBody struct {
 	Sequence         int64     `json:"sequence"`
 	Gas              string    `json:"gas"`
 	GasAdjustment    string    `json:"gas_adjustment"`
+	TimeoutHeight    int64     `json:"timeout_height"`
+	TimeoutTimestamp int64     `json:"timeout_timestamp"`
 }
 
 // TransferRequestHandler - http request handler to transfer coins to a address
@@ -48,7 +53,7 @@ func TransferRequestHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx context.
 			return
 		}
 
-		var m transferBody
+		var m TransferBody
 		body, err := ioutil.ReadAll(r.Body)
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -68,8 +73,8 @@ func TransferRequestHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx context.
 		}
 
 		// build message
-		packet := ibc.NewIBCPacket(sdk.AccAddress(info.GetPubKey().Address()), to, m.Amount, m.SrcChainID, destChainID)
-		msg := ibc.IBCTransferMsg{packet}
+		msg := ibc.NewIBCTransferMsg(sdk.AccAddress(info.GetPubKey().Address()), to, m.Amount, m.SrcChainID, destChainID,
+			m.TimeoutHeight, m.TimeoutTimestamp)
 
 		simulateGas, gas, err := client.ReadGasFlag(m.Gas)
 		if err != nil {
@@ -129,3 +134,24 @@ func TransferRequestHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx context.
 		w.Write(output)
 	}
 }
+
+// http request handler to trace a voucher denom back to its origin chain
+func denomTraceHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		bz, err := cdc.MarshalJSON(ibc.QueryDenomTraceParams{Denom: vars["denom"]})
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/ibc/%s", ibc.QueryDenomTrace), bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("couldn't query denom trace. Error: %s", err.Error()))
+			return
+		}
+
+		w.Write(res)
+	}
+}
@@ -0,0 +1,67 @@
+package ibc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// VoucherDenomPrefix prefixes the denoms of the vouchers minted for tokens
+// received over IBC, as in "ibc/<source chain>/<denom>".
+const VoucherDenomPrefix = "ibc"
+
+// DenomTrace is the path a voucher denom went through: the chains it was
+// received from, the latest first, down to the denom on its origin chain.
+type DenomTrace struct {
+	Denom     string   `json:"denom"`
+	Path      []string `json:"path"`
+	BaseDenom string   `json:"base_denom"`
+}
+
+// GetVoucherDenom returns the denom of the vouchers minted for tokens of a
+// denom received from a chain.
+func GetVoucherDenom(srcChain, denom string) string {
+	return fmt.Sprintf("%s/%s/%s", VoucherDenomPrefix, srcChain, denom)
+}
+
+// ParseVoucherDenom returns the chain a voucher denom was received from and
+// the denom of the tokens on that chain.
+func ParseVoucherDenom(denom string) (srcChain, srcDenom string, err error) {
+	parts := strings.SplitN(denom, "/", 3)
+	if len(parts) != 3 || parts[0] != VoucherDenomPrefix || parts[1] == "" || parts[2] == "" {
+		return "", "", fmt.Errorf("%s is not a voucher denom", denom)
+	}
+	return parts[1], parts[2], nil
+}
+
+// NewDenomTrace traces a voucher denom back to its origin chain.
+func NewDenomTrace(denom string) (trace DenomTrace, err error) {
+	trace.Denom = denom
+	srcChain, srcDenom, err := ParseVoucherDenom(denom)
+	if err != nil {
+		return trace, err
+	}
+	for err == nil {
+		trace.Path = append(trace.Path, srcChain)
+		trace.BaseDenom = srcDenom
+		srcChain, srcDenom, err = ParseVoucherDenom(srcDenom)
+	}
+	return trace, nil
+}
+
+// GetEscrowAddress returns the address holding the native tokens sent to a
+// chain until they are sent back. No private key exists for this address.
+func GetEscrowAddress(destChain string) sdk.AccAddress {
+	bz := []byte(fmt.Sprintf("%s/escrow/%s", VoucherDenomPrefix, destChain))
+	return sdk.AccAddress(tmhash.Sum(bz)[:sdk.AddrLen])
+}
+
+// Whether tokens of a denom sent to a chain return to it, in which case the
+// vouchers are burned instead of escrowed.
+func returnsToSource(denom, destChain string) bool {
+	srcChain, _, err := ParseVoucherDenom(denom)
+	return err == nil && srcChain == destChain
+}
@@ -0,0 +1,38 @@
+package ibc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDenomTrace(t *testing.T) {
+	cases := []struct {
+		denom     string
+		valid     bool
+		path      []string
+		baseDenom string
+	}{
+		{"ibc/chain-a/steak", true, []string{"chain-a"}, "steak"},
+		{"ibc/chain-b/ibc/chain-a/steak", true, []string{"chain-b", "chain-a"}, "steak"},
+		{"ibc/chain-a/delshare/cosmosvaloper1xyz", true, []string{"chain-a"}, "delshare/cosmosvaloper1xyz"},
+		{"steak", false, nil, ""},
+		{"ibc/chain-a", false, nil, ""},
+		{"ibc//steak", false, nil, ""},
+	}
+
+	for i, tc := range cases {
+		trace, err := NewDenomTrace(tc.denom)
+		if !tc.valid {
+			require.NotNil(t, err, "%d", i)
+			continue
+		}
+		require.Nil(t, err, "%d", i)
+		require.Equal(t, tc.denom, trace.Denom, "%d", i)
+		require.Equal(t, tc.path, trace.Path, "%d", i)
+		require.Equal(t, tc.baseDenom, trace.BaseDenom, "%d", i)
+	}
+
+	require.Equal(t, "ibc/chain-a/steak", GetVoucherDenom("chain-a", "steak"))
+	require.NotEqual(t, GetEscrowAddress("chain-a"), GetEscrowAddress("chain-b"))
+}
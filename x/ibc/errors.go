@@ -1,6 +1,8 @@
 package ibc
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -11,6 +13,17 @@ const (
 	// IBC errors reserve 200 - 299.
 	CodeInvalidSequence sdk.CodeType = 200
 	CodeIdenticalChains sdk.CodeType = 201
+	CodeClientExists    sdk.CodeType = 202
+	CodeNoClient        sdk.CodeType = 203
+	CodeInvalidHeader   sdk.CodeType = 204
+	CodeInvalidProof    sdk.CodeType = 205
+	CodeInvalidTimeout  sdk.CodeType = 206
+	CodeUnknownPacket   sdk.CodeType = 207
+	CodeNotTimedOut     sdk.CodeType = 208
+	CodeUnknownDenom    sdk.CodeType = 209
+	CodeUnknownRoute    sdk.CodeType = 210
+	CodeNoTrustedRoot   sdk.CodeType = 211
+	CodeWrongSrcChain   sdk.CodeType = 212
 	CodeUnknownRequest  sdk.CodeType = sdk.CodeUnknownRequest
 )
 
@@ -20,6 +33,28 @@ func codeToDefaultMsg(code sdk.CodeType) string {
 		return "invalid IBC packet sequence"
 	case CodeIdenticalChains:
 		return "source and destination chain cannot be identical"
+	case CodeClientExists:
+		return "a client already exists for this chain"
+	case CodeNoClient:
+		return "no client exists for this chain"
+	case CodeInvalidHeader:
+		return "invalid counterparty chain header"
+	case CodeInvalidProof:
+		return "invalid IBC packet proof"
+	case CodeInvalidTimeout:
+		return "invalid IBC packet timeout"
+	case CodeUnknownPacket:
+		return "unknown or already resolved IBC packet"
+	case CodeNotTimedOut:
+		return "IBC packet has not timed out"
+	case CodeUnknownDenom:
+		return "unknown voucher denom"
+	case CodeUnknownRoute:
+		return "unknown IBC packet route"
+	case CodeNoTrustedRoot:
+		return "no trusted root exists for this chain"
+	case CodeWrongSrcChain:
+		return "IBC packet source chain is not this chain"
 	default:
 		return sdk.CodeToDefaultMsg(code)
 	}
@@ -32,6 +67,39 @@ func ErrInvalidSequence(codespace sdk.CodespaceType) sdk.Error {
 func ErrIdenticalChains(codespace sdk.CodespaceType) sdk.Error {
 	return newError(codespace, CodeIdenticalChains, "")
 }
+func ErrClientExists(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeClientExists, "")
+}
+func ErrNoClient(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeNoClient, "")
+}
+func ErrInvalidHeader(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidHeader, msg)
+}
+func ErrInvalidProof(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidProof, msg)
+}
+func ErrInvalidTimeout(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeInvalidTimeout, "")
+}
+func ErrUnknownPacket(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeUnknownPacket, "")
+}
+func ErrNotTimedOut(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeNotTimedOut, msg)
+}
+func ErrUnknownDenom(codespace sdk.CodespaceType, denom string) sdk.Error {
+	return newError(codespace, CodeUnknownDenom, fmt.Sprintf("no voucher denom %s has been minted", denom))
+}
+func ErrUnknownRoute(codespace sdk.CodespaceType, route string) sdk.Error {
+	return newError(codespace, CodeUnknownRoute, fmt.Sprintf("no callbacks registered for IBC packet route %q", route))
+}
+func ErrNoTrustedRoot(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeNoTrustedRoot, "")
+}
+func ErrWrongSrcChain(codespace sdk.CodespaceType, srcChain string) sdk.Error {
+	return newError(codespace, CodeWrongSrcChain, fmt.Sprintf("IBC packet source chain %s is not this chain", srcChain))
+}
 
 // -------------------------
 // Helpers
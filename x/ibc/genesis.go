@@ -0,0 +1,30 @@
+package ibc
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - the trusted roots clients of counterparty chains can be
+// created from
+type GenesisState struct {
+	TrustedRoots []TrustedRoot `json:"trusted_roots"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// InitGenesis - store the trusted roots of the counterparty chains
+func InitGenesis(ctx sdk.Context, ibcm Mapper, data GenesisState) {
+	for _, root := range data.TrustedRoots {
+		ibcm.SetTrustedRoot(ctx, root)
+	}
+}
+
+// WriteGenesis - output the trusted roots of the counterparty chains
+func WriteGenesis(ctx sdk.Context, ibcm Mapper) GenesisState {
+	return GenesisState{
+		TrustedRoots: ibcm.GetTrustedRoots(ctx),
+	}
+}
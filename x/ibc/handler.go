@@ -1,6 +1,7 @@
 package ibc
 
 import (
+	"fmt"
 	"reflect"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -13,7 +14,15 @@ func NewHandler(ibcm Mapper, ck bank.Keeper) sdk.Handler {
 		case IBCTransferMsg:
 			return handleIBCTransferMsg(ctx, ibcm, ck, msg)
 		case IBCReceiveMsg:
-			return handleIBCReceiveMsg(ctx, ibcm, ck, msg)
+			return handleIBCReceiveMsg(ctx, ibcm, msg)
+		case IBCAcknowledgementMsg:
+			return handleIBCAcknowledgementMsg(ctx, ibcm, msg)
+		case IBCTimeoutMsg:
+			return handleIBCTimeoutMsg(ctx, ibcm, msg)
+		case IBCCreateClientMsg:
+			return handleIBCCreateClientMsg(ctx, ibcm, msg)
+		case IBCUpdateClientMsg:
+			return handleIBCUpdateClientMsg(ctx, ibcm, msg)
 		default:
 			errMsg := "Unrecognized IBC Msg type: " + reflect.TypeOf(msg).Name()
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -21,38 +30,138 @@ func NewHandler(ibcm Mapper, ck bank.Keeper) sdk.Handler {
 	}
 }
 
-// IBCTransferMsg deducts coins from the account and creates an egress IBC packet.
-func handleIBCTransferMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCTransferMsg) sdk.Result {
+// IBCReceiveMsg verifies that the packet was posted on the source chain, then
+// passes it to the receive callback of its route and creates an ingress IBC
+// packet. An acknowledgement is written for the packet, with an error if it
+// timed out, its route is unknown or the callback failed.
+func handleIBCReceiveMsg(ctx sdk.Context, ibcm Mapper, msg IBCReceiveMsg) sdk.Result {
 	packet := msg.IBCPacket
 
-	_, _, err := ck.SubtractCoins(ctx, packet.SrcAddr, packet.Coins)
+	seq := ibcm.GetIngressSequence(ctx, packet.SrcChain)
+	if msg.Sequence != seq {
+		return ErrInvalidSequence(ibcm.codespace).Result()
+	}
+
+	err := ibcm.VerifyPacket(ctx, packet, msg.Sequence, msg.ProofHeight, msg.Proof)
 	if err != nil {
 		return err.Result()
 	}
 
-	err = ibcm.PostIBCPacket(ctx, packet)
+	var ack IBCAcknowledgement
+	cbs, found := ibcm.router.Route(packet.Route)
+	switch {
+	case packet.TimedOut(ctx.BlockHeight(), ctx.BlockHeader().Time):
+		ack.Error = "packet timed out"
+	case !found:
+		ack.Error = ErrUnknownRoute(ibcm.codespace, packet.Route).Error()
+	default:
+		// the effects of the callback are discarded if it fails
+		cacheCtx, write := ctx.CacheContext()
+		ack.Data, err = cbs.OnReceive(cacheCtx, packet)
+		if err != nil {
+			ack.Data, ack.Error = nil, err.Error()
+		} else {
+			write()
+		}
+	}
+
+	ibcm.setAcknowledgement(ctx, packet.SrcChain, seq, ack)
+	ibcm.SetIngressSequence(ctx, packet.SrcChain, seq+1)
+
+	return sdk.Result{}
+}
+
+// IBCAcknowledgementMsg verifies the acknowledgement written by the
+// destination chain for a pending outgoing packet, and passes it to the
+// acknowledgement callback of the packet route.
+func handleIBCAcknowledgementMsg(ctx sdk.Context, ibcm Mapper, msg IBCAcknowledgementMsg) sdk.Result {
+	packet := msg.IBCPacket
+
+	err := ibcm.checkPendingPacket(ctx, packet, msg.Sequence)
 	if err != nil {
 		return err.Result()
 	}
 
+	err = ibcm.VerifyAcknowledgement(ctx, packet, msg.Acknowledgement, msg.Sequence, msg.ProofHeight, msg.Proof)
+	if err != nil {
+		return err.Result()
+	}
+
+	cbs, found := ibcm.router.Route(packet.Route)
+	if !found {
+		return ErrUnknownRoute(ibcm.codespace, packet.Route).Result()
+	}
+	err = cbs.OnAcknowledgement(ctx, packet, msg.Acknowledgement)
+	if err != nil {
+		return err.Result()
+	}
+
+	ibcm.resolvePacket(ctx, packet.DestChain, msg.Sequence)
+
 	return sdk.Result{}
 }
 
-// IBCReceiveMsg adds coins to the destination address and creates an ingress IBC packet.
-func handleIBCReceiveMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCReceiveMsg) sdk.Result {
+// IBCTimeoutMsg verifies that a pending outgoing packet was not received by
+// the destination chain before its timeout, and passes it to the timeout
+// callback of the packet route.
+func handleIBCTimeoutMsg(ctx sdk.Context, ibcm Mapper, msg IBCTimeoutMsg) sdk.Result {
 	packet := msg.IBCPacket
 
-	seq := ibcm.GetIngressSequence(ctx, packet.SrcChain)
-	if msg.Sequence != seq {
-		return ErrInvalidSequence(ibcm.codespace).Result()
+	err := ibcm.checkPendingPacket(ctx, packet, msg.Sequence)
+	if err != nil {
+		return err.Result()
+	}
+
+	// a packet is rejected from the block reaching its timeout on, so the
+	// state committed by the previous block, which is the one proven against
+	// the header, is final for the packet
+	header, found := ibcm.GetHeader(ctx, packet.DestChain, msg.ProofHeight)
+	if !found {
+		return ErrInvalidProof(ibcm.codespace,
+			fmt.Sprintf("no verified header of chain %s at height %d", packet.DestChain, msg.ProofHeight)).Result()
+	}
+	if !packet.TimedOut(header.Height, header.Time) {
+		return ErrNotTimedOut(ibcm.codespace, "").Result()
+	}
+	if msg.NextSequence > msg.Sequence {
+		return ErrNotTimedOut(ibcm.codespace, "packet has been received").Result()
 	}
 
-	_, _, err := ck.AddCoins(ctx, packet.DestAddr, packet.Coins)
+	err = ibcm.VerifyIngressSequence(ctx, packet, msg.NextSequence, msg.ProofHeight, msg.Proof)
 	if err != nil {
 		return err.Result()
 	}
 
-	ibcm.SetIngressSequence(ctx, packet.SrcChain, seq+1)
+	cbs, found := ibcm.router.Route(packet.Route)
+	if !found {
+		return ErrUnknownRoute(ibcm.codespace, packet.Route).Result()
+	}
+	err = cbs.OnTimeout(ctx, packet)
+	if err != nil {
+		return err.Result()
+	}
+
+	ibcm.resolvePacket(ctx, packet.DestChain, msg.Sequence)
+
+	return sdk.Result{}
+}
+
+// IBCCreateClientMsg starts tracking the headers of a counterparty chain.
+func handleIBCCreateClientMsg(ctx sdk.Context, ibcm Mapper, msg IBCCreateClientMsg) sdk.Result {
+	err := ibcm.CreateClient(ctx, msg.Header, msg.Validators)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{}
+}
+
+// IBCUpdateClientMsg verifies and stores a new header of a counterparty chain.
+func handleIBCUpdateClientMsg(ctx sdk.Context, ibcm Mapper, msg IBCUpdateClientMsg) sdk.Result {
+	err := ibcm.UpdateClient(ctx, msg.Header, msg.Validators)
+	if err != nil {
+		return err.Result()
+	}
 
 	return sdk.Result{}
 }
@@ -1,7 +1,9 @@
 package ibc
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -9,6 +11,7 @@ import (
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store"
@@ -19,13 +22,60 @@ import (
 
 // AccountMapper(/Keeper) and IBCMapper should use different StoreKey later
 
-func defaultContext(key sdk.StoreKey) sdk.Context {
+func defaultMultiStore(key sdk.StoreKey) sdk.CommitMultiStore {
 	db := dbm.NewMemDB()
 	cms := store.NewCommitMultiStore(db)
 	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
 	cms.LoadLatestVersion()
-	ctx := sdk.NewContext(cms, abci.Header{}, false, log.NewNopLogger())
-	return ctx
+	return cms
+}
+
+func defaultContext(key sdk.StoreKey) sdk.Context {
+	return sdk.NewContext(defaultMultiStore(key), abci.Header{}, false, log.NewNopLogger())
+}
+
+// query a key of the committed store with a multistore proof, as a relayer would
+func queryProof(t *testing.T, cms sdk.CommitMultiStore, key sdk.StoreKey, storeKey []byte, height int64) []byte {
+	res := cms.(sdk.Queryable).Query(abci.RequestQuery{
+		Path:   fmt.Sprintf("/%s/key", key.Name()),
+		Data:   storeKey,
+		Height: height,
+		Prove:  true,
+	})
+	require.True(t, res.IsOK(), res.Log)
+	require.NotNil(t, res.Value)
+	return res.Proof
+}
+
+// query an absent key of the committed store with a multistore proof
+func queryAbsenceProof(t *testing.T, cms sdk.CommitMultiStore, key sdk.StoreKey, storeKey []byte, height int64) []byte {
+	res := cms.(sdk.Queryable).Query(abci.RequestQuery{
+		Path:   fmt.Sprintf("/%s/key", key.Name()),
+		Data:   storeKey,
+		Height: height,
+		Prove:  true,
+	})
+	require.True(t, res.IsOK(), res.Log)
+	require.Nil(t, res.Value)
+	return res.Proof
+}
+
+// sign a header with all the given validators
+func signHeader(t *testing.T, chainID string, height int64, appHash []byte,
+	valSet *tmtypes.ValidatorSet, privVals []tmtypes.PrivValidator) tmtypes.SignedHeader {
+
+	header := &tmtypes.Header{
+		ChainID:        chainID,
+		Height:         height,
+		Time:           time.Now(),
+		ValidatorsHash: valSet.Hash(),
+		AppHash:        appHash,
+	}
+	blockID := tmtypes.BlockID{Hash: header.Hash()}
+	voteSet := tmtypes.NewVoteSet(chainID, height, 0, tmtypes.VoteTypePrecommit, valSet)
+	commit, err := tmtypes.MakeCommit(blockID, height, 0, voteSet, privVals)
+	require.Nil(t, err)
+	return tmtypes.SignedHeader{Header: header, Commit: commit}
 }
 
 func newAddress() sdk.AccAddress {
@@ -47,6 +97,10 @@ func makeCodec() *codec.Codec {
 	cdc.RegisterConcrete(bank.MsgIssue{}, "test/ibc/Issue", nil)
 	cdc.RegisterConcrete(IBCTransferMsg{}, "test/ibc/IBCTransferMsg", nil)
 	cdc.RegisterConcrete(IBCReceiveMsg{}, "test/ibc/IBCReceiveMsg", nil)
+	cdc.RegisterConcrete(IBCAcknowledgementMsg{}, "test/ibc/IBCAcknowledgementMsg", nil)
+	cdc.RegisterConcrete(IBCTimeoutMsg{}, "test/ibc/IBCTimeoutMsg", nil)
+	cdc.RegisterConcrete(IBCCreateClientMsg{}, "test/ibc/IBCCreateClientMsg", nil)
+	cdc.RegisterConcrete(IBCUpdateClientMsg{}, "test/ibc/IBCUpdateClientMsg", nil)
 
 	// Register AppAccount
 	cdc.RegisterInterface((*auth.Account)(nil), nil)
@@ -62,14 +116,17 @@ func TestIBC(t *testing.T) {
 	cdc := makeCodec()
 
 	key := sdk.NewKVStoreKey("ibc")
-	ctx := defaultContext(key)
+	cms := defaultMultiStore(key)
+	ctx := sdk.NewContext(cms, abci.Header{}, false, log.NewNopLogger())
 
 	am := auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount)
 	ck := bank.NewBaseKeeper(am)
 
 	src := newAddress()
 	dest := newAddress()
-	chainid := "ibcchain"
+	srcChain := "srcchain"
+	destChain := "destchain"
+	ctx = ctx.WithChainID(srcChain)
 	zero := sdk.Coins(nil)
 	mycoins := sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}
 
@@ -78,14 +135,12 @@ func TestIBC(t *testing.T) {
 	require.Equal(t, mycoins, coins)
 
 	ibcm := NewMapper(cdc, key, DefaultCodespace)
+	ibcm.Router().AddRoute(TransferRoute, NewTransferCallbacks(ibcm, ck))
 	h := NewHandler(ibcm, ck)
-	packet := IBCPacket{
-		SrcAddr:   src,
-		DestAddr:  dest,
-		Coins:     mycoins,
-		SrcChain:  chainid,
-		DestChain: chainid,
-	}
+	valSet, privVals := tmtypes.RandValidatorSet(4, 10)
+	ibcm.SetTrustedRoot(ctx, TrustedRoot{srcChain, valSet.Hash()})
+	transferMsg := NewIBCTransferMsg(src, dest, mycoins, srcChain, destChain, 0, 0)
+	packet := transferMsg.Packet()
 
 	store := ctx.KVStore(key)
 
@@ -94,12 +149,15 @@ func TestIBC(t *testing.T) {
 	var egl int64
 	var igs int64
 
-	egl = ibcm.getEgressLength(store, chainid)
+	egl = ibcm.getEgressLength(store, destChain)
 	require.Equal(t, egl, int64(0))
 
-	msg = IBCTransferMsg{
-		IBCPacket: packet,
-	}
+	// packets can only be sent from this chain
+	res = h(ctx.WithChainID("otherchain"), transferMsg)
+	require.False(t, res.IsOK())
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeWrongSrcChain), res.Code)
+
+	msg = transferMsg
 	res = h(ctx, msg)
 	require.True(t, res.IsOK())
 
@@ -107,30 +165,370 @@ func TestIBC(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, zero, coins)
 
-	egl = ibcm.getEgressLength(store, chainid)
+	coins, err = getCoins(ck, ctx, GetEscrowAddress(destChain))
+	require.Nil(t, err)
+	require.Equal(t, mycoins, coins)
+
+	egl = ibcm.getEgressLength(store, destChain)
 	require.Equal(t, egl, int64(1))
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
+	// commit the source chain state and prove the egress packet, the app hash
+	// of the commit at height 1 being part of the header at height 2
+	commitID := cms.Commit()
+	proof := queryProof(t, cms, key, EgressKey(destChain, 0), commitID.Version)
+	header := signHeader(t, srcChain, 2, commitID.Hash, valSet, privVals)
+
+	// receive on the destination chain
+	ctx = ctx.WithChainID(destChain)
+
+	igs = ibcm.GetIngressSequence(ctx, srcChain)
 	require.Equal(t, igs, int64(0))
 
-	msg = IBCReceiveMsg{
-		IBCPacket: packet,
-		Relayer:   src,
-		Sequence:  0,
+	receiveMsg := IBCReceiveMsg{
+		IBCPacket:   packet,
+		Relayer:     src,
+		Sequence:    0,
+		ProofHeight: 2,
+		Proof:       proof,
 	}
-	res = h(ctx, msg)
+
+	// no header of the source chain has been verified yet
+	res = h(ctx, receiveMsg)
+	require.False(t, res.IsOK())
+
+	res = h(ctx, IBCCreateClientMsg{header, valSet, src})
+	require.True(t, res.IsOK())
+
+	// the proof must match the packet
+	forgedMsg := receiveMsg
+	forgedMsg.IBCPacket = NewIBCTransferMsg(src, dest, sdk.Coins{sdk.NewInt64Coin("mycoin", 1000)},
+		srcChain, destChain, 0, 0).Packet()
+	res = h(ctx, forgedMsg)
+	require.False(t, res.IsOK())
+
+	// the proof must be against the app hash of a verified header
+	forgedMsg = receiveMsg
+	forgedMsg.ProofHeight = 3
+	res = h(ctx, forgedMsg)
+	require.False(t, res.IsOK())
+
+	res = h(ctx, receiveMsg)
 	require.True(t, res.IsOK())
 
 	coins, err = getCoins(ck, ctx, dest)
 	require.Nil(t, err)
-	require.Equal(t, mycoins, coins)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("ibc/srcchain/mycoin", 10)}, coins)
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
+	igs = ibcm.GetIngressSequence(ctx, srcChain)
 	require.Equal(t, igs, int64(1))
 
-	res = h(ctx, msg)
+	res = h(ctx, receiveMsg)
 	require.False(t, res.IsOK())
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
+	igs = ibcm.GetIngressSequence(ctx, srcChain)
 	require.Equal(t, igs, int64(1))
 }
+
+func TestIBCAcknowledgement(t *testing.T) {
+	cdc := makeCodec()
+
+	key := sdk.NewKVStoreKey("ibc")
+	cms := defaultMultiStore(key)
+	ctx := sdk.NewContext(cms, abci.Header{}, false, log.NewNopLogger())
+
+	am := auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount)
+	ck := bank.NewBaseKeeper(am)
+	ibcm := NewMapper(cdc, key, DefaultCodespace)
+	ibcm.Router().AddRoute(TransferRoute, NewTransferCallbacks(ibcm, ck))
+	h := NewHandler(ibcm, ck)
+
+	src := newAddress()
+	dest := newAddress()
+	srcChain := "srcchain"
+	destChain := "destchain"
+	mycoins := sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}
+	valSet, privVals := tmtypes.RandValidatorSet(4, 10)
+	ibcm.SetTrustedRoot(ctx, TrustedRoot{srcChain, valSet.Hash()})
+	ibcm.SetTrustedRoot(ctx, TrustedRoot{destChain, valSet.Hash()})
+
+	// send a packet timing out at height 5 of the destination chain
+	srcCtx := ctx.WithChainID(srcChain)
+	_, _, err := ck.AddCoins(srcCtx, src, mycoins)
+	require.Nil(t, err)
+	transferMsg := NewIBCTransferMsg(src, dest, mycoins, srcChain, destChain, 5, 0)
+	packet := transferMsg.Packet()
+	res := h(srcCtx, transferMsg)
+	require.True(t, res.IsOK())
+	require.Equal(t, []int64{0}, ibcm.GetPendingPackets(srcCtx, destChain))
+
+	// receive it at height 10, which writes an error acknowledgement
+	commitID := cms.Commit()
+	proof := queryProof(t, cms, key, EgressKey(destChain, 0), commitID.Version)
+	destCtx := ctx.WithChainID(destChain).WithBlockHeight(10)
+	res = h(destCtx, IBCCreateClientMsg{signHeader(t, srcChain, 2, commitID.Hash, valSet, privVals), valSet, dest})
+	require.True(t, res.IsOK())
+	res = h(destCtx, IBCReceiveMsg{packet, dest, 0, 2, proof})
+	require.True(t, res.IsOK())
+
+	coins, err := getCoins(ck, destCtx, dest)
+	require.Nil(t, err)
+	require.True(t, coins.IsZero())
+	ack, found := ibcm.GetAcknowledgement(destCtx, srcChain, 0)
+	require.True(t, found)
+	require.False(t, ack.Success())
+
+	// relay the acknowledgement back to the source chain
+	commitID = cms.Commit()
+	proof = queryProof(t, cms, key, AckKey(srcChain, 0), commitID.Version)
+	res = h(srcCtx, IBCCreateClientMsg{signHeader(t, destChain, 2, commitID.Hash, valSet, privVals), valSet, src})
+	require.True(t, res.IsOK())
+
+	// the acknowledgement must match the proof
+	res = h(srcCtx, IBCAcknowledgementMsg{packet, IBCAcknowledgement{}, src, 0, 2, proof})
+	require.False(t, res.IsOK())
+
+	res = h(srcCtx, IBCAcknowledgementMsg{packet, ack, src, 0, 2, proof})
+	require.True(t, res.IsOK())
+
+	coins, err = getCoins(ck, srcCtx, src)
+	require.Nil(t, err)
+	require.Equal(t, mycoins, coins)
+	require.Empty(t, ibcm.GetPendingPackets(srcCtx, destChain))
+
+	// the packet is resolved and cannot be refunded twice
+	res = h(srcCtx, IBCAcknowledgementMsg{packet, ack, src, 0, 2, proof})
+	require.False(t, res.IsOK())
+}
+
+func TestIBCTimeout(t *testing.T) {
+	cdc := makeCodec()
+
+	key := sdk.NewKVStoreKey("ibc")
+	cms := defaultMultiStore(key)
+	ctx := sdk.NewContext(cms, abci.Header{}, false, log.NewNopLogger())
+
+	am := auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount)
+	ck := bank.NewBaseKeeper(am)
+	ibcm := NewMapper(cdc, key, DefaultCodespace)
+	ibcm.Router().AddRoute(TransferRoute, NewTransferCallbacks(ibcm, ck))
+	h := NewHandler(ibcm, ck)
+
+	src := newAddress()
+	dest := newAddress()
+	srcChain := "srcchain"
+	destChain := "destchain"
+	mycoins := sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}
+	valSet, privVals := tmtypes.RandValidatorSet(4, 10)
+	ibcm.SetTrustedRoot(ctx, TrustedRoot{srcChain, valSet.Hash()})
+	ibcm.SetTrustedRoot(ctx, TrustedRoot{destChain, valSet.Hash()})
+
+	// send a packet timing out at height 3 of the destination chain
+	srcCtx := ctx.WithChainID(srcChain)
+	_, _, err := ck.AddCoins(srcCtx, src, mycoins)
+	require.Nil(t, err)
+	transferMsg := NewIBCTransferMsg(src, dest, mycoins, srcChain, destChain, 3, 0)
+	packet := transferMsg.Packet()
+	res := h(srcCtx, transferMsg)
+	require.True(t, res.IsOK())
+
+	// the destination chain never received the packet
+	commitID := cms.Commit()
+	res = h(srcCtx, IBCCreateClientMsg{signHeader(t, destChain, 2, commitID.Hash, valSet, privVals), valSet, src})
+	require.True(t, res.IsOK())
+	proof := queryAbsenceProof(t, cms, key, IngressSequenceKey(srcChain), commitID.Version)
+
+	// the header at height 2 has not reached the timeout
+	res = h(srcCtx, IBCTimeoutMsg{packet, src, 0, 0, 2, proof})
+	require.False(t, res.IsOK())
+
+	res = h(srcCtx, IBCUpdateClientMsg{signHeader(t, destChain, 3, commitID.Hash, valSet, privVals), valSet, src})
+	require.True(t, res.IsOK())
+
+	// the proof must match the next sequence
+	res = h(srcCtx, IBCTimeoutMsg{packet, src, 0, 1, 3, proof})
+	require.False(t, res.IsOK())
+
+	res = h(srcCtx, IBCTimeoutMsg{packet, src, 0, 0, 3, proof})
+	require.True(t, res.IsOK())
+
+	coins, err := getCoins(ck, srcCtx, src)
+	require.Nil(t, err)
+	require.Equal(t, mycoins, coins)
+
+	res = h(srcCtx, IBCTimeoutMsg{packet, src, 0, 0, 3, proof})
+	require.False(t, res.IsOK())
+}
+
+func TestIBCVoucher(t *testing.T) {
+	cdc := makeCodec()
+
+	key := sdk.NewKVStoreKey("ibc")
+	cms := defaultMultiStore(key)
+	ctx := sdk.NewContext(cms, abci.Header{}, false, log.NewNopLogger())
+
+	am := auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount)
+	ck := bank.NewBaseKeeper(am)
+	ibcm := NewMapper(cdc, key, DefaultCodespace)
+	ibcm.Router().AddRoute(TransferRoute, NewTransferCallbacks(ibcm, ck))
+	h := NewHandler(ibcm, ck)
+
+	addrA := newAddress()
+	addrB := newAddress()
+	chainA := "chaina"
+	chainB := "chainb"
+	mycoins := sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}
+	vouchers := sdk.Coins{sdk.NewInt64Coin("ibc/chaina/mycoin", 10)}
+	valSet, privVals := tmtypes.RandValidatorSet(4, 10)
+	ibcm.SetTrustedRoot(ctx, TrustedRoot{chainA, valSet.Hash()})
+	ibcm.SetTrustedRoot(ctx, TrustedRoot{chainB, valSet.Hash()})
+
+	ctxA := ctx.WithChainID(chainA)
+	ctxB := ctx.WithChainID(chainB)
+	_, _, err := ck.AddCoins(ctxA, addrA, mycoins)
+	require.Nil(t, err)
+
+	// native coins are escrowed on chain A, and vouchers minted on chain B
+	transferMsg := NewIBCTransferMsg(addrA, addrB, mycoins, chainA, chainB, 0, 0)
+	packet := transferMsg.Packet()
+	res := h(ctxA, transferMsg)
+	require.True(t, res.IsOK())
+
+	commitID := cms.Commit()
+	proof := queryProof(t, cms, key, EgressKey(chainB, 0), commitID.Version)
+	res = h(ctxB, IBCCreateClientMsg{signHeader(t, chainA, 2, commitID.Hash, valSet, privVals), valSet, addrB})
+	require.True(t, res.IsOK())
+	res = h(ctxB, IBCReceiveMsg{packet, addrB, 0, 2, proof})
+	require.True(t, res.IsOK())
+
+	coins, err := getCoins(ck, ctxB, addrB)
+	require.Nil(t, err)
+	require.Equal(t, vouchers, coins)
+
+	trace, found := ibcm.GetDenomTrace(ctxB, "ibc/chaina/mycoin")
+	require.True(t, found)
+	require.Equal(t, []string{chainA}, trace.Path)
+	require.Equal(t, "mycoin", trace.BaseDenom)
+
+	// vouchers sent back are burned on chain B, and released from escrow on chain A
+	transferMsg = NewIBCTransferMsg(addrB, addrA, vouchers, chainB, chainA, 0, 0)
+	packet = transferMsg.Packet()
+	res = h(ctxB, transferMsg)
+	require.True(t, res.IsOK())
+
+	coins, err = getCoins(ck, ctxB, GetEscrowAddress(chainA))
+	require.Nil(t, err)
+	require.True(t, coins.IsZero())
+
+	commitID = cms.Commit()
+	proof = queryProof(t, cms, key, EgressKey(chainA, 0), commitID.Version)
+	res = h(ctxA, IBCCreateClientMsg{signHeader(t, chainB, 2, commitID.Hash, valSet, privVals), valSet, addrA})
+	require.True(t, res.IsOK())
+	res = h(ctxA, IBCReceiveMsg{packet, addrA, 0, 2, proof})
+	require.True(t, res.IsOK())
+
+	coins, err = getCoins(ck, ctxA, addrA)
+	require.Nil(t, err)
+	require.Equal(t, mycoins, coins)
+
+	coins, err = getCoins(ck, ctxA, GetEscrowAddress(chainB))
+	require.Nil(t, err)
+	require.True(t, coins.IsZero())
+
+	_, found = ibcm.GetDenomTrace(ctxA, "mycoin")
+	require.False(t, found)
+}
+
+func TestIBCCustomRoute(t *testing.T) {
+	cdc := makeCodec()
+
+	// each chain has its own store
+	key := sdk.NewKVStoreKey("ibc")
+	cmsA := defaultMultiStore(key)
+	cmsB := defaultMultiStore(key)
+
+	am := auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount)
+	ck := bank.NewBaseKeeper(am)
+	relayer := newAddress()
+	chainA := "chaina"
+	chainB := "chainb"
+	valSet, privVals := tmtypes.RandValidatorSet(4, 10)
+
+	// a module exchanging opaque data on the "ping" route, only registered
+	// with the IBC Mapper of chain B at first
+	var acked []byte
+	pingCallbacks := Callbacks{
+		OnReceive: func(ctx sdk.Context, packet IBCPacket) ([]byte, sdk.Error) {
+			ctx.KVStore(key).Set([]byte("ping"), packet.Data)
+			return []byte("pong"), nil
+		},
+		OnAcknowledgement: func(ctx sdk.Context, packet IBCPacket, ack IBCAcknowledgement) sdk.Error {
+			acked = ack.Data
+			return nil
+		},
+		OnTimeout: func(ctx sdk.Context, packet IBCPacket) sdk.Error {
+			return nil
+		},
+	}
+	ibcmA := NewMapper(cdc, key, DefaultCodespace)
+	ibcmB := NewMapper(cdc, key, DefaultCodespace)
+	ibcmB.Router().AddRoute("ping", pingCallbacks)
+	hA := NewHandler(ibcmA, ck)
+	hB := NewHandler(ibcmB, ck)
+
+	ctxA := sdk.NewContext(cmsA, abci.Header{}, false, log.NewNopLogger()).WithChainID(chainA)
+	ctxB := sdk.NewContext(cmsB, abci.Header{}, false, log.NewNopLogger()).WithChainID(chainB)
+	ibcmA.SetTrustedRoot(ctxA, TrustedRoot{chainB, valSet.Hash()})
+	ibcmB.SetTrustedRoot(ctxB, TrustedRoot{chainA, valSet.Hash()})
+
+	// packets can only be sent on registered routes
+	packet := NewIBCPacket("ping", []byte("hello"), chainA, chainB, 0, 0)
+	require.NotNil(t, ibcmA.PostIBCPacket(ctxA, packet))
+
+	// packets can only be sent from the chain itself
+	require.NotNil(t, ibcmB.PostIBCPacket(ctxB, packet))
+
+	// chain B sends a packet which chain A has no callbacks for
+	packet = NewIBCPacket("ping", []byte("hello"), chainB, chainA, 0, 0)
+	require.Nil(t, ibcmB.PostIBCPacket(ctxB, packet))
+
+	commitID := cmsB.Commit()
+	proof := queryProof(t, cmsB, key, EgressKey(chainA, 0), commitID.Version)
+	res := hA(ctxA, IBCCreateClientMsg{signHeader(t, chainB, 2, commitID.Hash, valSet, privVals), valSet, relayer})
+	require.True(t, res.IsOK())
+	res = hA(ctxA, IBCReceiveMsg{packet, relayer, 0, 2, proof})
+	require.True(t, res.IsOK())
+
+	ack, found := ibcmA.GetAcknowledgement(ctxA, chainB, 0)
+	require.True(t, found)
+	require.False(t, ack.Success())
+	require.Nil(t, ctxA.KVStore(key).Get([]byte("ping")))
+
+	// once chain A registers the callbacks, the next packet is received
+	ibcmA.Router().AddRoute("ping", pingCallbacks)
+	packet = NewIBCPacket("ping", []byte("hello"), chainB, chainA, 0, 0)
+	require.Nil(t, ibcmB.PostIBCPacket(ctxB, packet))
+
+	commitID = cmsB.Commit()
+	proof = queryProof(t, cmsB, key, EgressKey(chainA, 1), commitID.Version)
+	res = hA(ctxA, IBCCreateClientMsg{signHeader(t, chainB, 3, commitID.Hash, valSet, privVals), valSet, relayer})
+	require.False(t, res.IsOK())
+	res = hA(ctxA, IBCUpdateClientMsg{signHeader(t, chainB, 3, commitID.Hash, valSet, privVals), valSet, relayer})
+	require.True(t, res.IsOK())
+	res = hA(ctxA, IBCReceiveMsg{packet, relayer, 1, 3, proof})
+	require.True(t, res.IsOK())
+
+	require.Equal(t, []byte("hello"), ctxA.KVStore(key).Get([]byte("ping")))
+	ack, found = ibcmA.GetAcknowledgement(ctxA, chainB, 1)
+	require.True(t, found)
+	require.True(t, ack.Success())
+	require.Equal(t, []byte("pong"), ack.Data)
+
+	// the acknowledgement is passed to the callbacks of the sender
+	commitID = cmsA.Commit()
+	proof = queryProof(t, cmsA, key, AckKey(chainB, 1), commitID.Version)
+	res = hB(ctxB, IBCCreateClientMsg{signHeader(t, chainA, 2, commitID.Hash, valSet, privVals), valSet, relayer})
+	require.True(t, res.IsOK())
+	res = hB(ctxB, IBCAcknowledgementMsg{packet, ack, relayer, 1, 2, proof})
+	require.True(t, res.IsOK())
+	require.Equal(t, []byte("pong"), acked)
+}
@@ -0,0 +1,291 @@
+package ibc
+
+import (
+	"bytes"
+	"fmt"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	codec "github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ConsensusState is the latest verified state of a counterparty chain: the
+// height of the last header submitted and the validator set which committed it.
+type ConsensusState struct {
+	ChainID    string                `json:"chain_id"`
+	Height     int64                 `json:"height"`
+	Validators *tmtypes.ValidatorSet `json:"validators"`
+}
+
+// MaxHeaders is the number of verified headers kept for each counterparty
+// chain. Older headers are pruned, so proofs must be made against one of the
+// latest MaxHeaders headers submitted for the chain.
+const MaxHeaders = 100
+
+// TrustedRoot is the hash of the validator set a client of a counterparty
+// chain must start from. Trusted roots are set at genesis, as the first
+// header of a client cannot be verified against an earlier one.
+type TrustedRoot struct {
+	ChainID        string       `json:"chain_id"`
+	ValidatorsHash cmn.HexBytes `json:"validators_hash"`
+}
+
+// Starts tracking a counterparty chain from a signed header. The validator set
+// must match the trusted root of the chain and be consistent with the header.
+func (ibcm Mapper) CreateClient(ctx sdk.Context, header tmtypes.SignedHeader, validators *tmtypes.ValidatorSet) sdk.Error {
+	chainID := header.Header.ChainID
+	if _, found := ibcm.GetConsensusState(ctx, chainID); found {
+		return ErrClientExists(ibcm.codespace)
+	}
+
+	root, found := ibcm.GetTrustedRoot(ctx, chainID)
+	if !found {
+		return ErrNoTrustedRoot(ibcm.codespace)
+	}
+	if !bytes.Equal(validators.Hash(), root.ValidatorsHash) {
+		return ErrInvalidHeader(ibcm.codespace, "validator set does not match the trusted root of the chain")
+	}
+
+	err := verifySignedHeader(header, validators, validators)
+	if err != nil {
+		return ErrInvalidHeader(ibcm.codespace, err.Error())
+	}
+
+	ibcm.setHeader(ctx, chainID, *header.Header)
+	ibcm.setConsensusState(ctx, ConsensusState{
+		ChainID:    chainID,
+		Height:     header.Header.Height,
+		Validators: validators,
+	})
+	return nil
+}
+
+// Submits a new header of a counterparty chain. The header must be more recent
+// than the latest verified one, and its commit must be signed by more than 2/3
+// of the voting power of both the latest verified validator set and the new
+// validator set.
+func (ibcm Mapper) UpdateClient(ctx sdk.Context, header tmtypes.SignedHeader, validators *tmtypes.ValidatorSet) sdk.Error {
+	chainID := header.Header.ChainID
+	state, found := ibcm.GetConsensusState(ctx, chainID)
+	if !found {
+		return ErrNoClient(ibcm.codespace)
+	}
+
+	if header.Header.Height <= state.Height {
+		return ErrInvalidHeader(ibcm.codespace,
+			fmt.Sprintf("header height %d is not above the latest height %d", header.Header.Height, state.Height))
+	}
+
+	err := verifySignedHeader(header, validators, state.Validators)
+	if err != nil {
+		return ErrInvalidHeader(ibcm.codespace, err.Error())
+	}
+
+	ibcm.setHeader(ctx, chainID, *header.Header)
+	state.Height = header.Header.Height
+	state.Validators = validators
+	ibcm.setConsensusState(ctx, state)
+	return nil
+}
+
+// Verifies that a packet is stored on its source chain under
+// EgressKey(packet.DestChain, sequence), using a Merkle proof against the app
+// hash of the verified source chain header at proofHeight.
+func (ibcm Mapper) VerifyPacket(ctx sdk.Context, packet IBCPacket, sequence int64, proofHeight int64, proof []byte) sdk.Error {
+	if packet.DestChain != ctx.ChainID() {
+		return ErrInvalidProof(ibcm.codespace,
+			fmt.Sprintf("packet is destined to chain %s instead of %s", packet.DestChain, ctx.ChainID()))
+	}
+
+	key := EgressKey(packet.DestChain, sequence)
+	value := marshalBinaryPanic(ibcm.cdc, packet)
+	return ibcm.verifyStoreValue(ctx, packet.SrcChain, proofHeight, proof, key, value)
+}
+
+// Verifies that the acknowledgement of a packet is stored on its destination
+// chain under AckKey(packet.SrcChain, sequence), using a Merkle proof against
+// the app hash of the verified destination chain header at proofHeight.
+func (ibcm Mapper) VerifyAcknowledgement(ctx sdk.Context, packet IBCPacket, ack IBCAcknowledgement,
+	sequence int64, proofHeight int64, proof []byte) sdk.Error {
+
+	key := AckKey(packet.SrcChain, sequence)
+	value := marshalBinaryPanic(ibcm.cdc, ack)
+	return ibcm.verifyStoreValue(ctx, packet.DestChain, proofHeight, proof, key, value)
+}
+
+// Verifies that the ingress sequence of the destination chain of a packet for
+// its source chain is nextSequence, using a Merkle proof against the app hash
+// of the verified destination chain header at proofHeight. A zero
+// nextSequence may also be proven by the absence of the ingress sequence.
+func (ibcm Mapper) VerifyIngressSequence(ctx sdk.Context, packet IBCPacket, nextSequence int64,
+	proofHeight int64, proof []byte) sdk.Error {
+
+	key := IngressSequenceKey(packet.SrcChain)
+	value := marshalBinaryPanic(ibcm.cdc, nextSequence)
+	err := ibcm.verifyStoreValue(ctx, packet.DestChain, proofHeight, proof, key, value)
+	if err != nil && nextSequence == 0 {
+		return ibcm.verifyStoreValue(ctx, packet.DestChain, proofHeight, proof, key, nil)
+	}
+	return err
+}
+
+// Verifies that a key of the IBC store of a counterparty chain holds a value,
+// or is absent if the value is empty, using a Merkle proof against the app
+// hash of the verified header of the chain at proofHeight.
+func (ibcm Mapper) verifyStoreValue(ctx sdk.Context, chainID string, proofHeight int64, proof []byte,
+	key []byte, value []byte) sdk.Error {
+
+	header, found := ibcm.GetHeader(ctx, chainID, proofHeight)
+	if !found {
+		return ErrInvalidProof(ibcm.codespace,
+			fmt.Sprintf("no verified header of chain %s at height %d", chainID, proofHeight))
+	}
+
+	var multiStoreProof store.MultiStoreProof
+	err := codec.New().UnmarshalBinary(proof, &multiStoreProof)
+	if err != nil {
+		return ErrInvalidProof(ibcm.codespace, err.Error())
+	}
+	if multiStoreProof.StoreName != ibcm.key.Name() {
+		return ErrInvalidProof(ibcm.codespace,
+			fmt.Sprintf("proof is for store %s instead of %s", multiStoreProof.StoreName, ibcm.key.Name()))
+	}
+
+	substoreCommitHash, err := store.VerifyMultiStoreCommitInfo(multiStoreProof.StoreName,
+		multiStoreProof.StoreInfos, header.AppHash)
+	if err != nil {
+		return ErrInvalidProof(ibcm.codespace, err.Error())
+	}
+
+	err = store.VerifyRangeProof(key, value, substoreCommitHash, &multiStoreProof.RangeProof)
+	if err != nil {
+		return ErrInvalidProof(ibcm.codespace, err.Error())
+	}
+	return nil
+}
+
+// Checks that a signed header is consistent with the given validator set and
+// that its commit is signed by more than 2/3 of the voting power of both the
+// given and the trusted validator sets.
+func verifySignedHeader(header tmtypes.SignedHeader, validators, trusted *tmtypes.ValidatorSet) error {
+	if !bytes.Equal(header.Header.ValidatorsHash, validators.Hash()) {
+		return fmt.Errorf("validator set does not match the header validators hash")
+	}
+	if !bytes.Equal(header.Commit.BlockID.Hash, header.Header.Hash()) {
+		return fmt.Errorf("commit is not for the header")
+	}
+
+	chainID, blockID, height := header.Header.ChainID, header.Commit.BlockID, header.Header.Height
+	if bytes.Equal(trusted.Hash(), validators.Hash()) {
+		return trusted.VerifyCommit(chainID, blockID, height, header.Commit)
+	}
+	return trusted.VerifyCommitAny(validators, chainID, blockID, height, header.Commit)
+}
+
+// --------------------------
+// Functions for accessing the underlying KVStore.
+
+// Retrieves the latest verified state of a counterparty chain.
+func (ibcm Mapper) GetConsensusState(ctx sdk.Context, chainID string) (state ConsensusState, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(ConsensusStateKey(chainID))
+	if bz == nil {
+		return state, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &state)
+	return state, true
+}
+
+func (ibcm Mapper) setConsensusState(ctx sdk.Context, state ConsensusState) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(ConsensusStateKey(state.ChainID), marshalBinaryPanic(ibcm.cdc, state))
+}
+
+// Retrieves the trusted root of a counterparty chain.
+func (ibcm Mapper) GetTrustedRoot(ctx sdk.Context, chainID string) (root TrustedRoot, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(TrustedRootKey(chainID))
+	if bz == nil {
+		return root, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &root)
+	return root, true
+}
+
+// Retrieves the trusted roots of all the counterparty chains.
+func (ibcm Mapper) GetTrustedRoots(ctx sdk.Context) (roots []TrustedRoot) {
+	store := ctx.KVStore(ibcm.key)
+	iterator := sdk.KVStorePrefixIterator(store, []byte("root/"))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var root TrustedRoot
+		unmarshalBinaryPanic(ibcm.cdc, iterator.Value(), &root)
+		roots = append(roots, root)
+	}
+	return roots
+}
+
+// Sets the trusted root of a counterparty chain. This is only to be invoked
+// at genesis.
+func (ibcm Mapper) SetTrustedRoot(ctx sdk.Context, root TrustedRoot) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(TrustedRootKey(root.ChainID), marshalBinaryPanic(ibcm.cdc, root))
+}
+
+// Retrieves a verified header of a counterparty chain.
+func (ibcm Mapper) GetHeader(ctx sdk.Context, chainID string, height int64) (header tmtypes.Header, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(HeaderKey(chainID, height))
+	if bz == nil {
+		return header, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &header)
+	return header, true
+}
+
+// Stores a verified header of a counterparty chain, and prunes the headers of
+// the chain older than the latest MaxHeaders ones.
+func (ibcm Mapper) setHeader(ctx sdk.Context, chainID string, header tmtypes.Header) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(HeaderKey(chainID, header.Height), marshalBinaryPanic(ibcm.cdc, header))
+
+	// headers are iterated from the oldest one
+	var keys [][]byte
+	iterator := sdk.KVStorePrefixIterator(store, HeaderPrefix(chainID))
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	for i := 0; i < len(keys)-MaxHeaders; i++ {
+		store.Delete(keys[i])
+	}
+}
+
+// Stores the latest verified state of a counterparty chain under "client/chain_id".
+func ConsensusStateKey(chainID string) []byte {
+	return []byte(fmt.Sprintf("client/%s", chainID))
+}
+
+// Stores the trusted root of a counterparty chain under "root/chain_id".
+func TrustedRootKey(chainID string) []byte {
+	return []byte(fmt.Sprintf("root/%s", chainID))
+}
+
+// Stores a verified header of a counterparty chain under
+// "header/len(chain_id)/chain_id/height". The chain ID is prefixed with its
+// length so that the headers of a chain whose ID contains a "/" cannot collide
+// with those of another chain, and the height is zero-padded so that the
+// headers of a chain are ordered by height.
+func HeaderKey(chainID string, height int64) []byte {
+	return append(HeaderPrefix(chainID), []byte(fmt.Sprintf("%020d", height))...)
+}
+
+// Prefix of the verified headers of a counterparty chain.
+func HeaderPrefix(chainID string) []byte {
+	return []byte(fmt.Sprintf("header/%d/%s/", len(chainID), chainID))
+}
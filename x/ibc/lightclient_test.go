@@ -0,0 +1,148 @@
+package ibc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestIBCClient(t *testing.T) {
+	cdc := makeCodec()
+
+	key := sdk.NewKVStoreKey("ibc")
+	ctx := defaultContext(key)
+	ibcm := NewMapper(cdc, key, DefaultCodespace)
+
+	chainID := "srcchain"
+	valSet, privVals := tmtypes.RandValidatorSet(4, 10)
+
+	_, found := ibcm.GetConsensusState(ctx, chainID)
+	require.False(t, found)
+
+	// updating requires an existing client
+	err := ibcm.UpdateClient(ctx, signHeader(t, chainID, 2, nil, valSet, privVals), valSet)
+	require.NotNil(t, err)
+
+	// a client can only be created from a trusted root
+	header := signHeader(t, chainID, 2, []byte("apphash"), valSet, privVals)
+	err = ibcm.CreateClient(ctx, header, valSet)
+	require.NotNil(t, err)
+
+	ibcm.SetTrustedRoot(ctx, TrustedRoot{chainID, valSet.Hash()})
+	err = ibcm.CreateClient(ctx, header, valSet)
+	require.Nil(t, err)
+
+	state, found := ibcm.GetConsensusState(ctx, chainID)
+	require.True(t, found)
+	require.Equal(t, int64(2), state.Height)
+	require.Equal(t, valSet.Hash(), state.Validators.Hash())
+
+	stored, found := ibcm.GetHeader(ctx, chainID, 2)
+	require.True(t, found)
+	require.Equal(t, header.Header.Hash(), stored.Hash())
+
+	// a client can only be created once
+	err = ibcm.CreateClient(ctx, header, valSet)
+	require.NotNil(t, err)
+
+	// headers must be more recent than the latest one
+	err = ibcm.UpdateClient(ctx, signHeader(t, chainID, 2, nil, valSet, privVals), valSet)
+	require.NotNil(t, err)
+
+	// headers must be signed by the trusted validators
+	otherSet, otherPrivVals := tmtypes.RandValidatorSet(4, 10)
+	err = ibcm.UpdateClient(ctx, signHeader(t, chainID, 3, nil, otherSet, otherPrivVals), otherSet)
+	require.NotNil(t, err)
+
+	// the validator set must match the header
+	err = ibcm.UpdateClient(ctx, signHeader(t, chainID, 3, nil, valSet, privVals), otherSet)
+	require.NotNil(t, err)
+
+	// the commit must be for the header
+	forged := signHeader(t, chainID, 3, nil, valSet, privVals)
+	forged.Header.AppHash = []byte("forged")
+	err = ibcm.UpdateClient(ctx, forged, valSet)
+	require.NotNil(t, err)
+
+	err = ibcm.UpdateClient(ctx, signHeader(t, chainID, 3, nil, valSet, privVals), valSet)
+	require.Nil(t, err)
+
+	state, found = ibcm.GetConsensusState(ctx, chainID)
+	require.True(t, found)
+	require.Equal(t, int64(3), state.Height)
+
+	_, found = ibcm.GetHeader(ctx, chainID, 3)
+	require.True(t, found)
+}
+
+func TestIBCClientForged(t *testing.T) {
+	cdc := makeCodec()
+
+	key := sdk.NewKVStoreKey("ibc")
+	ctx := defaultContext(key)
+	ibcm := NewMapper(cdc, key, DefaultCodespace)
+
+	chainID := "srcchain"
+	valSet, _ := tmtypes.RandValidatorSet(4, 10)
+	ibcm.SetTrustedRoot(ctx, TrustedRoot{chainID, valSet.Hash()})
+
+	// a client signed by other validators than the trusted ones is rejected,
+	// even though the header is consistent with its validator set
+	forgedSet, forgedPrivVals := tmtypes.RandValidatorSet(4, 10)
+	err := ibcm.CreateClient(ctx, signHeader(t, chainID, 2, []byte("forged"), forgedSet, forgedPrivVals), forgedSet)
+	require.NotNil(t, err)
+	require.Equal(t, CodeInvalidHeader, err.Code())
+
+	// as is a client of a chain without a trusted root
+	err = ibcm.CreateClient(ctx, signHeader(t, "otherchain", 2, []byte("forged"), forgedSet, forgedPrivVals), forgedSet)
+	require.NotNil(t, err)
+	require.Equal(t, CodeNoTrustedRoot, err.Code())
+
+	_, found := ibcm.GetConsensusState(ctx, chainID)
+	require.False(t, found)
+	_, found = ibcm.GetConsensusState(ctx, "otherchain")
+	require.False(t, found)
+
+	// trusted roots are exported at genesis
+	require.Equal(t, GenesisState{[]TrustedRoot{{chainID, valSet.Hash()}}}, WriteGenesis(ctx, ibcm))
+}
+
+func TestIBCClientPruneHeaders(t *testing.T) {
+	cdc := makeCodec()
+
+	key := sdk.NewKVStoreKey("ibc")
+	ctx := defaultContext(key)
+	ibcm := NewMapper(cdc, key, DefaultCodespace)
+
+	chainID := "srcchain"
+	valSet, privVals := tmtypes.RandValidatorSet(4, 10)
+	ibcm.SetTrustedRoot(ctx, TrustedRoot{chainID, valSet.Hash()})
+
+	err := ibcm.CreateClient(ctx, signHeader(t, chainID, 1, nil, valSet, privVals), valSet)
+	require.Nil(t, err)
+	for height := int64(2); height <= MaxHeaders+5; height++ {
+		err = ibcm.UpdateClient(ctx, signHeader(t, chainID, height, nil, valSet, privVals), valSet)
+		require.Nil(t, err)
+	}
+
+	// only the latest MaxHeaders headers are kept
+	for height := int64(1); height <= MaxHeaders+5; height++ {
+		_, found := ibcm.GetHeader(ctx, chainID, height)
+		require.Equal(t, height > 5, found, "height %d", height)
+	}
+}
+
+func TestHeaderKey(t *testing.T) {
+	// chain IDs containing a "/" cannot collide with other chains
+	require.NotEqual(t, HeaderKey("a/1", 2), HeaderKey("a", 12))
+	require.False(t, bytes.HasPrefix(HeaderKey("a/1", 2), HeaderPrefix("a")))
+	require.False(t, bytes.HasPrefix(ConsensusStateKey("a/1"), HeaderPrefix("a")))
+
+	// headers are ordered by height
+	require.True(t, bytes.Compare(HeaderKey("a", 9), HeaderKey("a", 10)) < 0)
+}
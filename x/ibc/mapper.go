@@ -1,6 +1,7 @@
 package ibc
 
 import (
+	"bytes"
 	"fmt"
 
 	codec "github.com/cosmos/cosmos-sdk/codec"
@@ -12,6 +13,7 @@ type Mapper struct {
 	key       sdk.StoreKey
 	cdc       *codec.Codec
 	codespace sdk.CodespaceType
+	router    *router
 }
 
 // XXX: The Mapper should not take a CoinKeeper. Rather have the CoinKeeper
@@ -22,14 +24,29 @@ func NewMapper(cdc *codec.Codec, key sdk.StoreKey, codespace sdk.CodespaceType)
 		key:       key,
 		cdc:       cdc,
 		codespace: codespace,
+		router:    newRouter(),
 	}
 }
 
-// XXX: This is not the public API. This will change in MVP2 and will henceforth
-// only be invoked from another module directly and not through a user
-// transaction.
-// TODO: Handle invalid IBC packets and return errors.
+// Router returns the router modules register their packet callbacks with.
+// It is shared by all the copies of the Mapper.
+func (ibcm Mapper) Router() Router {
+	return ibcm.router
+}
+
+// Sends a packet to its destination chain. This is to be invoked by the
+// module which registered the callbacks of the packet route, after having
+// applied the effects of sending the packet. The source chain of the packet
+// must be this chain, as the destination chain verifies it against the
+// headers of its source chain.
 func (ibcm Mapper) PostIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
+	if packet.SrcChain != ctx.ChainID() {
+		return ErrWrongSrcChain(ibcm.codespace, packet.SrcChain)
+	}
+	if _, found := ibcm.router.Route(packet.Route); !found {
+		return ErrUnknownRoute(ibcm.codespace, packet.Route)
+	}
+
 	// write everything into the state
 	store := ctx.KVStore(ibcm.key)
 	index := ibcm.getEgressLength(store, packet.DestChain)
@@ -45,18 +62,101 @@ func (ibcm Mapper) PostIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
 	}
 	store.Set(EgressLengthKey(packet.DestChain), bz)
 
+	// track the packet until it is acknowledged or times out
+	store.Set(PendingPacketKey(packet.DestChain, index), marshalBinaryPanic(ibcm.cdc, index))
+
 	return nil
 }
 
-// XXX: In the future every module is able to register it's own handler for
-// handling it's own IBC packets. The "ibc" handler will only route the packets
-// to the appropriate callbacks.
-// XXX: For now this handles all interactions with the CoinKeeper.
-// XXX: This needs to do some authentication checking.
-func (ibcm Mapper) ReceiveIBCPacket(ctx sdk.Context, packet IBCPacket) sdk.Error {
+// Retrieves an outgoing IBC packet.
+func (ibcm Mapper) GetEgressPacket(ctx sdk.Context, destChain string, index int64) (packet IBCPacket, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(EgressKey(destChain, index))
+	if bz == nil {
+		return packet, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &packet)
+	return packet, true
+}
+
+// Checks that an outgoing IBC packet has neither been acknowledged nor timed
+// out yet, and that it is the packet stored at its index.
+func (ibcm Mapper) checkPendingPacket(ctx sdk.Context, packet IBCPacket, index int64) sdk.Error {
+	store := ctx.KVStore(ibcm.key)
+	if !store.Has(PendingPacketKey(packet.DestChain, index)) {
+		return ErrUnknownPacket(ibcm.codespace)
+	}
+
+	stored, _ := ibcm.GetEgressPacket(ctx, packet.DestChain, index)
+	if !bytes.Equal(marshalBinaryPanic(ibcm.cdc, stored), marshalBinaryPanic(ibcm.cdc, packet)) {
+		return ErrUnknownPacket(ibcm.codespace)
+	}
 	return nil
 }
 
+// Stops tracking an outgoing IBC packet once it has been acknowledged or has
+// timed out.
+func (ibcm Mapper) resolvePacket(ctx sdk.Context, destChain string, index int64) {
+	store := ctx.KVStore(ibcm.key)
+	store.Delete(PendingPacketKey(destChain, index))
+}
+
+// Retrieves the indexes of the outgoing IBC packets to a chain which are
+// neither acknowledged nor timed out.
+func (ibcm Mapper) GetPendingPackets(ctx sdk.Context, destChain string) (indexes []int64) {
+	store := ctx.KVStore(ibcm.key)
+	iterator := sdk.KVStorePrefixIterator(store, PendingPacketPrefix(destChain))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var index int64
+		unmarshalBinaryPanic(ibcm.cdc, iterator.Value(), &index)
+		indexes = append(indexes, index)
+	}
+	return indexes
+}
+
+// Retrieves the trace of a voucher denom minted by this chain.
+func (ibcm Mapper) GetDenomTrace(ctx sdk.Context, denom string) (trace DenomTrace, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(DenomTraceKey(denom))
+	if bz == nil {
+		return trace, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &trace)
+	return trace, true
+}
+
+// Records the trace of a voucher denom the first time it is minted.
+func (ibcm Mapper) trackDenom(ctx sdk.Context, denom string) {
+	store := ctx.KVStore(ibcm.key)
+	if store.Has(DenomTraceKey(denom)) {
+		return
+	}
+
+	trace, err := NewDenomTrace(denom)
+	if err != nil {
+		panic(err)
+	}
+	store.Set(DenomTraceKey(denom), marshalBinaryPanic(ibcm.cdc, trace))
+}
+
+// Retrieves the acknowledgement written for an incoming IBC packet.
+func (ibcm Mapper) GetAcknowledgement(ctx sdk.Context, srcChain string, sequence int64) (ack IBCAcknowledgement, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(AckKey(srcChain, sequence))
+	if bz == nil {
+		return ack, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &ack)
+	return ack, true
+}
+
+func (ibcm Mapper) setAcknowledgement(ctx sdk.Context, srcChain string, sequence int64, ack IBCAcknowledgement) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(AckKey(srcChain, sequence), marshalBinaryPanic(ibcm.cdc, ack))
+}
+
 // --------------------------
 // Functions for accessing the underlying KVStore.
 
@@ -128,3 +228,23 @@ func EgressLengthKey(destChain string) []byte {
 func IngressSequenceKey(srcChain string) []byte {
 	return []byte(fmt.Sprintf("ingress/%s", srcChain))
 }
+
+// Stores the trace of a voucher denom minted by this chain under "trace/denom".
+func DenomTraceKey(denom string) []byte {
+	return []byte(fmt.Sprintf("trace/%s", denom))
+}
+
+// Stores the acknowledgement of an incoming IBC packet under "ack/chain_id/index".
+func AckKey(srcChain string, index int64) []byte {
+	return []byte(fmt.Sprintf("ack/%s/%d", srcChain, index))
+}
+
+// Tracks an unresolved outgoing IBC packet under "pending/chain_id/index".
+func PendingPacketKey(destChain string, index int64) []byte {
+	return []byte(fmt.Sprintf("pending/%s/%d", destChain, index))
+}
+
+// Prefix of the unresolved outgoing IBC packets to a chain.
+func PendingPacketPrefix(destChain string) []byte {
+	return []byte(fmt.Sprintf("pending/%s/", destChain))
+}
@@ -0,0 +1,53 @@
+package ibc
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the ibc Querier
+const (
+	QueryDenomTrace = "denomTrace"
+)
+
+// creates a querier for ibc REST endpoints
+func NewQuerier(ibcm Mapper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryDenomTrace:
+			return queryDenomTrace(ctx, req, ibcm)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown ibc query endpoint")
+		}
+	}
+}
+
+// defines the params for the following queries:
+// - 'custom/ibc/denomTrace'
+type QueryDenomTraceParams struct {
+	Denom string
+}
+
+func queryDenomTrace(ctx sdk.Context, req abci.RequestQuery, ibcm Mapper) (res []byte, err sdk.Error) {
+	var params QueryDenomTraceParams
+
+	errRes := ibcm.cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf("incorrectly formatted request data: %s", errRes.Error()))
+	}
+
+	trace, found := ibcm.GetDenomTrace(ctx, params.Denom)
+	if !found {
+		return []byte{}, ErrUnknownDenom(ibcm.codespace, params.Denom)
+	}
+
+	res, errRes = codec.MarshalJSONIndent(ibcm.cdc, trace)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", errRes.Error()))
+	}
+	return res, nil
+}
@@ -0,0 +1,78 @@
+package ibc
+
+import (
+	"regexp"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ReceiveHandler processes a packet received on a route. The returned data
+// is written in the acknowledgement of the packet; on error, the state changes
+// are discarded and the error is acknowledged instead.
+type ReceiveHandler func(ctx sdk.Context, packet IBCPacket) ([]byte, sdk.Error)
+
+// AcknowledgementHandler processes the acknowledgement of a packet sent on a
+// route.
+type AcknowledgementHandler func(ctx sdk.Context, packet IBCPacket, ack IBCAcknowledgement) sdk.Error
+
+// TimeoutHandler processes a packet sent on a route which timed out before
+// being received.
+type TimeoutHandler func(ctx sdk.Context, packet IBCPacket) sdk.Error
+
+// Callbacks are the functions a module registers with the IBC Mapper to
+// exchange packets on a route.
+type Callbacks struct {
+	OnReceive         ReceiveHandler
+	OnAcknowledgement AcknowledgementHandler
+	OnTimeout         TimeoutHandler
+}
+
+// Router provides callbacks for each packet route.
+type Router interface {
+	AddRoute(r string, cbs Callbacks) (rtr Router)
+	Route(path string) (cbs Callbacks, found bool)
+}
+
+// map a packet route to its callbacks
+type route struct {
+	r   string
+	cbs Callbacks
+}
+
+type router struct {
+	routes []route
+}
+
+func newRouter() *router {
+	return &router{
+		routes: make([]route, 0),
+	}
+}
+
+var isAlphaNumeric = regexp.MustCompile(`^[a-zA-Z0-9]+$`).MatchString
+
+// AddRoute registers the callbacks of a route, all callbacks must be set
+func (rtr *router) AddRoute(r string, cbs Callbacks) Router {
+	if !isAlphaNumeric(r) {
+		panic("route expressions can only contain alphanumeric characters")
+	}
+	if cbs.OnReceive == nil || cbs.OnAcknowledgement == nil || cbs.OnTimeout == nil {
+		panic("all the callbacks of a route must be set")
+	}
+	if _, found := rtr.Route(r); found {
+		panic("route " + r + " has already been registered")
+	}
+	rtr.routes = append(rtr.routes, route{r, cbs})
+
+	return rtr
+}
+
+// Route returns the callbacks registered for a route
+func (rtr *router) Route(path string) (cbs Callbacks, found bool) {
+	for _, route := range rtr.routes {
+		if route.r == path {
+			return route.cbs, true
+		}
+	}
+	return cbs, false
+}
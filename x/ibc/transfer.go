@@ -0,0 +1,226 @@
+package ibc
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// TransferRoute is the route of the packets of the coin transfer application.
+const TransferRoute = "transfer"
+
+// ------------------------------
+// TransferPayload
+
+// TransferPayload is the data of a coin transfer packet. The coins are in
+// the denoms of the source chain.
+type TransferPayload struct {
+	SrcAddr  sdk.AccAddress `json:"src_addr"`
+	DestAddr sdk.AccAddress `json:"dest_addr"`
+	Coins    sdk.Coins      `json:"coins"`
+}
+
+// validate the transfer payload
+func (p TransferPayload) ValidateBasic() sdk.Error {
+	if !p.Coins.IsValid() {
+		return sdk.ErrInvalidCoins("")
+	}
+	return nil
+}
+
+// ----------------------------------
+// IBCTransferMsg
+
+// nolint - TODO rename to TransferMsg as folks will reference with ibc.TransferMsg
+// IBCTransferMsg defines how an account sends coins to another chain.
+type IBCTransferMsg struct {
+	TransferPayload
+	SrcChain         string `json:"src_chain"`
+	DestChain        string `json:"dest_chain"`
+	TimeoutHeight    int64  `json:"timeout_height"`
+	TimeoutTimestamp int64  `json:"timeout_timestamp"`
+}
+
+func NewIBCTransferMsg(srcAddr sdk.AccAddress, destAddr sdk.AccAddress, coins sdk.Coins,
+	srcChain string, destChain string, timeoutHeight int64, timeoutTimestamp int64) IBCTransferMsg {
+
+	return IBCTransferMsg{
+		TransferPayload: TransferPayload{
+			SrcAddr:  srcAddr,
+			DestAddr: destAddr,
+			Coins:    coins,
+		},
+		SrcChain:         srcChain,
+		DestChain:        destChain,
+		TimeoutHeight:    timeoutHeight,
+		TimeoutTimestamp: timeoutTimestamp,
+	}
+}
+
+// nolint
+func (msg IBCTransferMsg) Type() string { return "ibc" }
+
+// x/bank/tx.go MsgSend.GetSigners()
+func (msg IBCTransferMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.SrcAddr} }
+
+// get the sign bytes for ibc transfer message
+func (msg IBCTransferMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// validate ibc transfer message
+func (msg IBCTransferMsg) ValidateBasic() sdk.Error {
+	err := msg.TransferPayload.ValidateBasic()
+	if err != nil {
+		return err
+	}
+	return msg.Packet().ValidateBasic()
+}
+
+// the packet sent for the transfer
+func (msg IBCTransferMsg) Packet() IBCPacket {
+	data := msgCdc.MustMarshalBinary(msg.TransferPayload)
+	return NewIBCPacket(TransferRoute, data, msg.SrcChain, msg.DestChain,
+		msg.TimeoutHeight, msg.TimeoutTimestamp)
+}
+
+// ----------------------------------
+// Coin transfer application
+
+// IBCTransferMsg deducts coins from the account and creates an egress IBC
+// packet. Native coins are escrowed, vouchers returning to their source chain
+// are burned.
+func handleIBCTransferMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCTransferMsg) sdk.Result {
+	packet := msg.Packet()
+	if packet.SrcChain != ctx.ChainID() {
+		return ErrWrongSrcChain(ibcm.codespace, packet.SrcChain).Result()
+	}
+
+	err := sendCoins(ctx, ck, packet.DestChain, msg.TransferPayload)
+	if err != nil {
+		return err.Result()
+	}
+
+	err = ibcm.PostIBCPacket(ctx, packet)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{}
+}
+
+// NewTransferCallbacks returns the callbacks of the coin transfer
+// application, to be registered for TransferRoute. Received coins returning
+// to this chain are released from escrow, other coins are minted as
+// vouchers. The coins of packets which are rejected or time out are refunded
+// to the source address.
+func NewTransferCallbacks(ibcm Mapper, ck bank.Keeper) Callbacks {
+	return Callbacks{
+		OnReceive: func(ctx sdk.Context, packet IBCPacket) ([]byte, sdk.Error) {
+			payload, err := decodeTransferPayload(packet)
+			if err != nil {
+				return nil, err
+			}
+			return nil, receiveCoins(ctx, ibcm, ck, packet.SrcChain, payload)
+		},
+		OnAcknowledgement: func(ctx sdk.Context, packet IBCPacket, ack IBCAcknowledgement) sdk.Error {
+			if ack.Success() {
+				return nil
+			}
+			payload, err := decodeTransferPayload(packet)
+			if err != nil {
+				return err
+			}
+			return refundCoins(ctx, ck, packet.DestChain, payload)
+		},
+		OnTimeout: func(ctx sdk.Context, packet IBCPacket) sdk.Error {
+			payload, err := decodeTransferPayload(packet)
+			if err != nil {
+				return err
+			}
+			return refundCoins(ctx, ck, packet.DestChain, payload)
+		},
+	}
+}
+
+func decodeTransferPayload(packet IBCPacket) (payload TransferPayload, err sdk.Error) {
+	errRes := msgCdc.UnmarshalBinary(packet.Data, &payload)
+	if errRes != nil {
+		return payload, sdk.ErrTxDecode(errRes.Error())
+	}
+	return payload, payload.ValidateBasic()
+}
+
+// deduct the coins of an outgoing transfer from the source address, escrowing
+// all but the vouchers returning to their source chain, which are burned
+func sendCoins(ctx sdk.Context, ck bank.Keeper, destChain string, payload TransferPayload) sdk.Error {
+	_, _, err := ck.SubtractCoins(ctx, payload.SrcAddr, payload.Coins)
+	if err != nil {
+		return err
+	}
+
+	escrowed := escrowedCoins(destChain, payload.Coins)
+	if escrowed.IsZero() {
+		return nil
+	}
+	_, _, err = ck.AddCoins(ctx, GetEscrowAddress(destChain), escrowed)
+	return err
+}
+
+// give the coins of an outgoing transfer which was not delivered back to the
+// source address
+func refundCoins(ctx sdk.Context, ck bank.Keeper, destChain string, payload TransferPayload) sdk.Error {
+	escrowed := escrowedCoins(destChain, payload.Coins)
+	if !escrowed.IsZero() {
+		_, _, err := ck.SubtractCoins(ctx, GetEscrowAddress(destChain), escrowed)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, _, err := ck.AddCoins(ctx, payload.SrcAddr, payload.Coins)
+	return err
+}
+
+// add the coins of an incoming transfer to the destination address, releasing
+// the coins returning to this chain from escrow and minting vouchers for the
+// others
+func receiveCoins(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, srcChain string, payload TransferPayload) sdk.Error {
+	var released, minted sdk.Coins
+	for _, coin := range payload.Coins {
+		if returnsToSource(coin.Denom, ctx.ChainID()) {
+			_, denom, _ := ParseVoucherDenom(coin.Denom)
+			released = append(released, sdk.NewCoin(denom, coin.Amount))
+			continue
+		}
+
+		voucher := sdk.NewCoin(GetVoucherDenom(srcChain, coin.Denom), coin.Amount)
+		ibcm.trackDenom(ctx, voucher.Denom)
+		minted = append(minted, voucher)
+	}
+	released = released.Sort()
+	minted = minted.Sort()
+
+	if !released.IsZero() {
+		_, _, err := ck.SubtractCoins(ctx, GetEscrowAddress(srcChain), released)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, _, err := ck.AddCoins(ctx, payload.DestAddr, released.Plus(minted))
+	return err
+}
+
+// the coins of an outgoing transfer which are escrowed rather than burned
+func escrowedCoins(destChain string, coins sdk.Coins) (escrowed sdk.Coins) {
+	for _, coin := range coins {
+		if !returnsToSource(coin.Denom, destChain) {
+			escrowed = append(escrowed, coin)
+		}
+	}
+	return escrowed
+}
@@ -2,6 +2,9 @@ package ibc
 
 import (
 	"encoding/json"
+	"time"
+
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	codec "github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -13,6 +16,7 @@ var (
 
 func init() {
 	msgCdc = codec.New()
+	codec.RegisterCrypto(msgCdc)
 }
 
 // ------------------------------
@@ -20,24 +24,29 @@ func init() {
 
 // nolint - TODO rename to Packet as IBCPacket stutters (golint)
 // IBCPacket defines a piece of data that can be send between two separate
-// blockchains.
+// blockchains. Data is opaque to the IBC module, it is handled by the
+// callbacks registered for Route on both chains. A packet is not delivered
+// once the destination chain reaches TimeoutHeight or a block time, in unix
+// seconds, of TimeoutTimestamp; zero values disable the respective timeout.
 type IBCPacket struct {
-	SrcAddr   sdk.AccAddress `json:"src_addr"`
-	DestAddr  sdk.AccAddress `json:"dest_addr"`
-	Coins     sdk.Coins      `json:"coins"`
-	SrcChain  string         `json:"src_chain"`
-	DestChain string         `json:"dest_chain"`
+	Route            string `json:"route"`
+	Data             []byte `json:"data"`
+	SrcChain         string `json:"src_chain"`
+	DestChain        string `json:"dest_chain"`
+	TimeoutHeight    int64  `json:"timeout_height"`
+	TimeoutTimestamp int64  `json:"timeout_timestamp"`
 }
 
-func NewIBCPacket(srcAddr sdk.AccAddress, destAddr sdk.AccAddress, coins sdk.Coins,
-	srcChain string, destChain string) IBCPacket {
+func NewIBCPacket(route string, data []byte, srcChain string, destChain string,
+	timeoutHeight int64, timeoutTimestamp int64) IBCPacket {
 
 	return IBCPacket{
-		SrcAddr:   srcAddr,
-		DestAddr:  destAddr,
-		Coins:     coins,
-		SrcChain:  srcChain,
-		DestChain: destChain,
+		Route:            route,
+		Data:             data,
+		SrcChain:         srcChain,
+		DestChain:        destChain,
+		TimeoutHeight:    timeoutHeight,
+		TimeoutTimestamp: timeoutTimestamp,
 	}
 }
 
@@ -52,50 +61,64 @@ func (p IBCPacket) GetSignBytes() []byte {
 
 // validator the ibc packey
 func (p IBCPacket) ValidateBasic() sdk.Error {
+	if !isAlphaNumeric(p.Route) {
+		return ErrUnknownRoute(DefaultCodespace, p.Route).TraceSDK("")
+	}
 	if p.SrcChain == p.DestChain {
 		return ErrIdenticalChains(DefaultCodespace).TraceSDK("")
 	}
-	if !p.Coins.IsValid() {
-		return sdk.ErrInvalidCoins("")
+	if p.TimeoutHeight < 0 || p.TimeoutTimestamp < 0 {
+		return ErrInvalidTimeout(DefaultCodespace).TraceSDK("")
 	}
 	return nil
 }
 
-// ----------------------------------
-// IBCTransferMsg
-
-// nolint - TODO rename to TransferMsg as folks will reference with ibc.TransferMsg
-// IBCTransferMsg defines how another module can send an IBCPacket.
-type IBCTransferMsg struct {
-	IBCPacket
+// Whether the packet has timed out on the destination chain at a block of
+// the given height and time.
+func (p IBCPacket) TimedOut(height int64, blockTime time.Time) bool {
+	if p.TimeoutHeight != 0 && height >= p.TimeoutHeight {
+		return true
+	}
+	if p.TimeoutTimestamp != 0 && blockTime.Unix() >= p.TimeoutTimestamp {
+		return true
+	}
+	return false
 }
 
-// nolint
-func (msg IBCTransferMsg) Type() string { return "ibc" }
-
-// x/bank/tx.go MsgSend.GetSigners()
-func (msg IBCTransferMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.SrcAddr} }
+// ------------------------------
+// IBCAcknowledgement
 
-// get the sign bytes for ibc transfer message
-func (msg IBCTransferMsg) GetSignBytes() []byte {
-	return msg.IBCPacket.GetSignBytes()
+// nolint - TODO rename to Acknowledgement as IBCAcknowledgement stutters (golint)
+// IBCAcknowledgement is written by the destination chain for every packet it
+// receives. An empty Error means the packet was processed by the receive
+// callback of its route, which returned Data, otherwise the packet was
+// rejected and its effects are to be reverted on the source chain.
+type IBCAcknowledgement struct {
+	Data  []byte `json:"data"`
+	Error string `json:"error"`
 }
 
-// validate ibc transfer message
-func (msg IBCTransferMsg) ValidateBasic() sdk.Error {
-	return msg.IBCPacket.ValidateBasic()
-}
+// nolint
+func (ack IBCAcknowledgement) Success() bool { return ack.Error == "" }
 
 // ----------------------------------
 // IBCReceiveMsg
 
 // nolint - TODO rename to ReceiveMsg as folks will reference with ibc.ReceiveMsg
 // IBCReceiveMsg defines the message that a relayer uses to post an IBCPacket
-// to the destination chain.
+// to the destination chain. Proof is a Merkle proof, as returned by a store
+// query with prove set, that the packet is stored under
+// EgressKey(DestChain, Sequence) on the source chain. It is verified against
+// the app hash of the source chain header at ProofHeight, which must have
+// been submitted beforehand with an IBCUpdateClientMsg; as the app hash of
+// header H commits to the state after block H-1, the proof must come from a
+// query at height ProofHeight-1.
 type IBCReceiveMsg struct {
 	IBCPacket
-	Relayer  sdk.AccAddress
-	Sequence int64
+	Relayer     sdk.AccAddress
+	Sequence    int64
+	ProofHeight int64
+	Proof       []byte
 }
 
 // nolint
@@ -108,16 +131,200 @@ func (msg IBCReceiveMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress
 // get the sign bytes for ibc receive message
 func (msg IBCReceiveMsg) GetSignBytes() []byte {
 	b, err := msgCdc.MarshalJSON(struct {
-		IBCPacket json.RawMessage
-		Relayer   sdk.AccAddress
-		Sequence  int64
+		IBCPacket   json.RawMessage
+		Relayer     sdk.AccAddress
+		Sequence    int64
+		ProofHeight int64
+		Proof       []byte
+	}{
+		IBCPacket:   json.RawMessage(msg.IBCPacket.GetSignBytes()),
+		Relayer:     msg.Relayer,
+		Sequence:    msg.Sequence,
+		ProofHeight: msg.ProofHeight,
+		Proof:       msg.Proof,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// ----------------------------------
+// IBCAcknowledgementMsg
+
+// nolint - TODO rename to AcknowledgementMsg as folks will reference with ibc.AcknowledgementMsg
+// IBCAcknowledgementMsg defines the message that a relayer uses to post the
+// acknowledgement of an IBCPacket back to its source chain. Proof is a Merkle
+// proof that the acknowledgement is stored under AckKey(SrcChain, Sequence)
+// on the destination chain, verified against the app hash of the destination
+// chain header at ProofHeight like for an IBCReceiveMsg.
+type IBCAcknowledgementMsg struct {
+	IBCPacket
+	Acknowledgement IBCAcknowledgement
+	Relayer         sdk.AccAddress
+	Sequence        int64
+	ProofHeight     int64
+	Proof           []byte
+}
+
+// nolint
+func (msg IBCAcknowledgementMsg) Type() string             { return "ibc" }
+func (msg IBCAcknowledgementMsg) ValidateBasic() sdk.Error { return msg.IBCPacket.ValidateBasic() }
+
+// x/bank/tx.go MsgSend.GetSigners()
+func (msg IBCAcknowledgementMsg) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Relayer}
+}
+
+// get the sign bytes for ibc acknowledgement message
+func (msg IBCAcknowledgementMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		IBCPacket       json.RawMessage
+		Acknowledgement IBCAcknowledgement
+		Relayer         sdk.AccAddress
+		Sequence        int64
+		ProofHeight     int64
+		Proof           []byte
 	}{
-		IBCPacket: json.RawMessage(msg.IBCPacket.GetSignBytes()),
-		Relayer:   msg.Relayer,
-		Sequence:  msg.Sequence,
+		IBCPacket:       json.RawMessage(msg.IBCPacket.GetSignBytes()),
+		Acknowledgement: msg.Acknowledgement,
+		Relayer:         msg.Relayer,
+		Sequence:        msg.Sequence,
+		ProofHeight:     msg.ProofHeight,
+		Proof:           msg.Proof,
 	})
 	if err != nil {
 		panic(err)
 	}
 	return sdk.MustSortJSON(b)
 }
+
+// ----------------------------------
+// IBCTimeoutMsg
+
+// nolint - TODO rename to TimeoutMsg as folks will reference with ibc.TimeoutMsg
+// IBCTimeoutMsg defines the message that a relayer uses to prove to the source
+// chain that an IBCPacket timed out before being received. Proof is a Merkle
+// proof that the ingress sequence of the destination chain for the source
+// chain is NextSequence, which must not be above Sequence, verified against
+// the app hash of a destination chain header at ProofHeight which has reached
+// the timeout of the packet. An absence proof is expected if NextSequence is
+// zero and the ingress sequence was never stored.
+type IBCTimeoutMsg struct {
+	IBCPacket
+	Relayer      sdk.AccAddress
+	Sequence     int64
+	NextSequence int64
+	ProofHeight  int64
+	Proof        []byte
+}
+
+// nolint
+func (msg IBCTimeoutMsg) Type() string                 { return "ibc" }
+func (msg IBCTimeoutMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Relayer} }
+
+// get the sign bytes for ibc timeout message
+func (msg IBCTimeoutMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		IBCPacket    json.RawMessage
+		Relayer      sdk.AccAddress
+		Sequence     int64
+		NextSequence int64
+		ProofHeight  int64
+		Proof        []byte
+	}{
+		IBCPacket:    json.RawMessage(msg.IBCPacket.GetSignBytes()),
+		Relayer:      msg.Relayer,
+		Sequence:     msg.Sequence,
+		NextSequence: msg.NextSequence,
+		ProofHeight:  msg.ProofHeight,
+		Proof:        msg.Proof,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// validate ibc timeout message
+func (msg IBCTimeoutMsg) ValidateBasic() sdk.Error {
+	if msg.TimeoutHeight == 0 && msg.TimeoutTimestamp == 0 {
+		return ErrInvalidTimeout(DefaultCodespace).TraceSDK("packet has no timeout")
+	}
+	return msg.IBCPacket.ValidateBasic()
+}
+
+// ----------------------------------
+// IBCCreateClientMsg
+
+// IBCCreateClientMsg defines the message that starts tracking a counterparty
+// chain, whose chain ID is the one of the header, from a signed header and the
+// validator set which committed it. The validator set must match the trusted
+// root of the chain set at genesis.
+type IBCCreateClientMsg struct {
+	Header     tmtypes.SignedHeader  `json:"header"`
+	Validators *tmtypes.ValidatorSet `json:"validators"`
+	Signer     sdk.AccAddress        `json:"signer"`
+}
+
+// nolint
+func (msg IBCCreateClientMsg) Type() string                 { return "ibc" }
+func (msg IBCCreateClientMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Signer} }
+
+// get the sign bytes for ibc create client message
+func (msg IBCCreateClientMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// validate ibc create client message
+func (msg IBCCreateClientMsg) ValidateBasic() sdk.Error {
+	return validateSignedHeader(msg.Header, msg.Validators)
+}
+
+// ----------------------------------
+// IBCUpdateClientMsg
+
+// IBCUpdateClientMsg defines the message that submits a new header of a
+// counterparty chain along with the validator set which committed it. The
+// header must be more recent than the last one submitted and be committed by
+// more than 2/3 of the voting power of the last validator set.
+type IBCUpdateClientMsg struct {
+	Header     tmtypes.SignedHeader  `json:"header"`
+	Validators *tmtypes.ValidatorSet `json:"validators"`
+	Signer     sdk.AccAddress        `json:"signer"`
+}
+
+// nolint
+func (msg IBCUpdateClientMsg) Type() string                 { return "ibc" }
+func (msg IBCUpdateClientMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Signer} }
+
+// get the sign bytes for ibc update client message
+func (msg IBCUpdateClientMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// validate ibc update client message
+func (msg IBCUpdateClientMsg) ValidateBasic() sdk.Error {
+	return validateSignedHeader(msg.Header, msg.Validators)
+}
+
+func validateSignedHeader(header tmtypes.SignedHeader, validators *tmtypes.ValidatorSet) sdk.Error {
+	if header.Header == nil || header.Commit == nil {
+		return ErrInvalidHeader(DefaultCodespace, "header and commit must be provided")
+	}
+	if len(header.Header.ChainID) == 0 {
+		return ErrInvalidHeader(DefaultCodespace, "chain ID must be provided")
+	}
+	if validators == nil || validators.Size() == 0 {
+		return ErrInvalidHeader(DefaultCodespace, "validator set must be provided")
+	}
+	return nil
+}
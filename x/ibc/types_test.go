@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -18,6 +19,7 @@ func TestIBCPacketValidation(t *testing.T) {
 	}{
 		{true, constructIBCPacket(true)},
 		{false, constructIBCPacket(false)},
+		{false, NewIBCPacket("my/route", nil, "source-chain", "dest-chain", 0, 0)},
 	}
 
 	for i, tc := range cases {
@@ -34,22 +36,28 @@ func TestIBCPacketValidation(t *testing.T) {
 // IBCTransferMsg Tests
 
 func TestIBCTransferMsg(t *testing.T) {
-	packet := constructIBCPacket(true)
-	msg := IBCTransferMsg{packet}
+	msg := constructIBCTransferMsg(true)
 
 	require.Equal(t, msg.Type(), "ibc")
+
+	packet := msg.Packet()
+	require.Equal(t, TransferRoute, packet.Route)
+	payload, err := decodeTransferPayload(packet)
+	require.Nil(t, err)
+	require.Equal(t, msg.TransferPayload, payload)
 }
 
 func TestIBCTransferMsgValidation(t *testing.T) {
-	validPacket := constructIBCPacket(true)
-	invalidPacket := constructIBCPacket(false)
+	invalidCoins := constructIBCTransferMsg(true)
+	invalidCoins.Coins = sdk.Coins{sdk.NewInt64Coin("atom", 0)}
 
 	cases := []struct {
 		valid bool
 		msg   IBCTransferMsg
 	}{
-		{true, IBCTransferMsg{validPacket}},
-		{false, IBCTransferMsg{invalidPacket}},
+		{true, constructIBCTransferMsg(true)},
+		{false, constructIBCTransferMsg(false)},
+		{false, invalidCoins},
 	}
 
 	for i, tc := range cases {
@@ -67,7 +75,7 @@ func TestIBCTransferMsgValidation(t *testing.T) {
 
 func TestIBCReceiveMsg(t *testing.T) {
 	packet := constructIBCPacket(true)
-	msg := IBCReceiveMsg{packet, sdk.AccAddress([]byte("relayer")), 0}
+	msg := IBCReceiveMsg{packet, sdk.AccAddress([]byte("relayer")), 0, 1, nil}
 
 	require.Equal(t, msg.Type(), "ibc")
 }
@@ -80,8 +88,41 @@ func TestIBCReceiveMsgValidation(t *testing.T) {
 		valid bool
 		msg   IBCReceiveMsg
 	}{
-		{true, IBCReceiveMsg{validPacket, sdk.AccAddress([]byte("relayer")), 0}},
-		{false, IBCReceiveMsg{invalidPacket, sdk.AccAddress([]byte("relayer")), 0}},
+		{true, IBCReceiveMsg{validPacket, sdk.AccAddress([]byte("relayer")), 0, 1, nil}},
+		{false, IBCReceiveMsg{invalidPacket, sdk.AccAddress([]byte("relayer")), 0, 1, nil}},
+	}
+
+	for i, tc := range cases {
+		err := tc.msg.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err, "%d: %+v", i, err)
+		} else {
+			require.NotNil(t, err, "%d", i)
+		}
+	}
+}
+
+// -------------------------------
+// IBCTimeoutMsg Tests
+
+func TestIBCTimeoutMsgValidation(t *testing.T) {
+	relayer := sdk.AccAddress([]byte("relayer"))
+	noTimeout := constructIBCPacket(true)
+	timeoutHeight := noTimeout
+	timeoutHeight.TimeoutHeight = 10
+	timeoutTimestamp := noTimeout
+	timeoutTimestamp.TimeoutTimestamp = 1000
+	negativeTimeout := noTimeout
+	negativeTimeout.TimeoutHeight = -1
+
+	cases := []struct {
+		valid bool
+		msg   IBCTimeoutMsg
+	}{
+		{true, IBCTimeoutMsg{timeoutHeight, relayer, 0, 0, 10, nil}},
+		{true, IBCTimeoutMsg{timeoutTimestamp, relayer, 0, 0, 10, nil}},
+		{false, IBCTimeoutMsg{noTimeout, relayer, 0, 0, 10, nil}},
+		{false, IBCTimeoutMsg{negativeTimeout, relayer, 0, 0, 10, nil}},
 	}
 
 	for i, tc := range cases {
@@ -94,10 +135,53 @@ func TestIBCReceiveMsgValidation(t *testing.T) {
 	}
 }
 
+// -------------------------------
+// IBCCreateClientMsg / IBCUpdateClientMsg Tests
+
+func TestIBCClientMsgValidation(t *testing.T) {
+	valSet, privVals := tmtypes.RandValidatorSet(1, 10)
+	header := signHeader(t, "source-chain", 1, []byte("apphash"), valSet, privVals)
+	signer := sdk.AccAddress([]byte("relayer"))
+
+	cases := []struct {
+		valid      bool
+		header     tmtypes.SignedHeader
+		validators *tmtypes.ValidatorSet
+	}{
+		{true, header, valSet},
+		{false, tmtypes.SignedHeader{Header: header.Header}, valSet},
+		{false, tmtypes.SignedHeader{Commit: header.Commit}, valSet},
+		{false, header, nil},
+	}
+
+	for i, tc := range cases {
+		createMsg := IBCCreateClientMsg{tc.header, tc.validators, signer}
+		updateMsg := IBCUpdateClientMsg{tc.header, tc.validators, signer}
+		if tc.valid {
+			require.Nil(t, createMsg.ValidateBasic(), "%d", i)
+			require.Nil(t, updateMsg.ValidateBasic(), "%d", i)
+		} else {
+			require.NotNil(t, createMsg.ValidateBasic(), "%d", i)
+			require.NotNil(t, updateMsg.ValidateBasic(), "%d", i)
+		}
+	}
+}
+
 // -------------------------------
 // Helpers
 
 func constructIBCPacket(valid bool) IBCPacket {
+	data := []byte("data")
+	srcChain := "source-chain"
+	destChain := "dest-chain"
+
+	if valid {
+		return NewIBCPacket("myroute", data, srcChain, destChain, 0, 0)
+	}
+	return NewIBCPacket("myroute", data, srcChain, srcChain, 0, 0)
+}
+
+func constructIBCTransferMsg(valid bool) IBCTransferMsg {
 	srcAddr := sdk.AccAddress([]byte("source"))
 	destAddr := sdk.AccAddress([]byte("destination"))
 	coins := sdk.Coins{sdk.NewInt64Coin("atom", 10)}
@@ -105,7 +189,7 @@ func constructIBCPacket(valid bool) IBCPacket {
 	destChain := "dest-chain"
 
 	if valid {
-		return NewIBCPacket(srcAddr, destAddr, coins, srcChain, destChain)
+		return NewIBCTransferMsg(srcAddr, destAddr, coins, srcChain, destChain, 0, 0)
 	}
-	return NewIBCPacket(srcAddr, destAddr, coins, srcChain, srcChain)
+	return NewIBCTransferMsg(srcAddr, destAddr, coins, srcChain, srcChain, 0, 0)
 }
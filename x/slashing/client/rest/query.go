@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
@@ -16,6 +17,16 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Co
 		"/slashing/signing_info/{validator}",
 		signingInfoHandlerFn(cliCtx, "slashing", cdc),
 	).Methods("GET")
+
+	r.HandleFunc(
+		"/slashing/missed_blocks/{validator}",
+		missedBlocksHandlerFn(cliCtx, cdc),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/slashing/slashes/{validator}",
+		slashesHandlerFn(cliCtx, cdc),
+	).Methods("GET")
 }
 
 // http request handler to query signing info
@@ -59,3 +70,59 @@ func signingInfoHandlerFn(cliCtx context.CLIContext, storeName string, cdc *code
 		w.Write(output)
 	}
 }
+
+// http request handler to query the missed blocks of a validator, takes a
+// cosmosvalcons address
+func missedBlocksHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		consAddr, err := sdk.ConsAddressFromBech32(vars["validator"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		bz, err := cdc.MarshalJSON(slashing.QuerySigningInfoParams{ConsAddress: consAddr})
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/slashing/%s", slashing.QueryMissedBlocks), bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("couldn't query missed blocks. Error: %s", err.Error()))
+			return
+		}
+
+		w.Write(res)
+	}
+}
+
+// http request handler to query the slashing history of a validator, takes a
+// cosmosvaloper address
+func slashesHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		valAddr, err := sdk.ValAddressFromBech32(vars["validator"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		bz, err := cdc.MarshalJSON(slashing.QuerySlashesParams{ValidatorAddr: valAddr})
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/slashing/%s", slashing.QuerySlashes), bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("couldn't query slashes. Error: %s", err.Error()))
+			return
+		}
+
+		w.Write(res)
+	}
+}
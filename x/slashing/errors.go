@@ -2,6 +2,8 @@
 package slashing
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -16,6 +18,7 @@ const (
 	CodeValidatorJailed       CodeType = 102
 	CodeValidatorNotJailed    CodeType = 103
 	CodeMissingSelfDelegation CodeType = 104
+	CodeNoSigningInfo         CodeType = 105
 )
 
 func ErrNoValidatorForAddress(codespace sdk.CodespaceType) sdk.Error {
@@ -37,3 +40,7 @@ func ErrValidatorNotJailed(codespace sdk.CodespaceType) sdk.Error {
 func ErrMissingSelfDelegation(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeMissingSelfDelegation, "validator has no self-delegation; cannot be unjailed")
 }
+
+func ErrNoSigningInfoFound(codespace sdk.CodespaceType, consAddr sdk.ConsAddress) sdk.Error {
+	return sdk.NewError(codespace, CodeNoSigningInfo, fmt.Sprintf("no signing info found for address: %s", consAddr))
+}
@@ -22,6 +22,49 @@ func (k Keeper) onValidatorBeginUnbonding(ctx sdk.Context, address sdk.ConsAddre
 	k.addOrUpdateValidatorSlashingPeriod(ctx, slashingPeriod)
 }
 
+// Move the signing info, signed blocks bit array and slashing periods of a
+// validator to its new consensus address when it rotates its consensus pubkey,
+// so that a validator has a single record of each whichever pubkey it signs
+// with. The old address is mapped to the new one, so that evidence and missed
+// signatures of the old pubkey are handled against the moved records.
+func (k Keeper) onValidatorConsPubKeyRotated(ctx sdk.Context, oldAddress, newAddress sdk.ConsAddress) {
+	if signInfo, found := k.getValidatorSigningInfo(ctx, oldAddress); found {
+		k.setValidatorSigningInfo(ctx, newAddress, signInfo)
+		k.deleteValidatorSigningInfo(ctx, oldAddress)
+
+		// collect before writing, so as not to write to the store while iterating it
+		var indices []int64
+		var signedBlocks []bool
+		k.iterateValidatorSigningBitArray(ctx, oldAddress, func(index int64, signed bool) (stop bool) {
+			indices = append(indices, index)
+			signedBlocks = append(signedBlocks, signed)
+			return false
+		})
+		for i, index := range indices {
+			k.setValidatorSigningBitArray(ctx, newAddress, index, signedBlocks[i])
+			k.setValidatorSigningHeight(ctx, newAddress, index, k.getValidatorSigningHeight(ctx, oldAddress, index))
+			k.deleteValidatorSigningBitArray(ctx, oldAddress, index)
+			k.deleteValidatorSigningHeight(ctx, oldAddress, index)
+		}
+	}
+
+	var slashingPeriods []ValidatorSlashingPeriod
+	k.iterateValidatorSlashingPeriods(ctx, oldAddress, func(slashingPeriod ValidatorSlashingPeriod) (stop bool) {
+		slashingPeriods = append(slashingPeriods, slashingPeriod)
+		return false
+	})
+	for _, slashingPeriod := range slashingPeriods {
+		k.deleteValidatorSlashingPeriod(ctx, oldAddress, slashingPeriod.StartHeight)
+		slashingPeriod.ValidatorAddr = newAddress
+		k.addOrUpdateValidatorSlashingPeriod(ctx, slashingPeriod)
+	}
+
+	// the new address holds the records from now on, it no longer resolves to
+	// another address should it be the address of a formerly rotated pubkey
+	k.deleteConsAddrRotation(ctx, newAddress)
+	k.setConsAddrRotation(ctx, oldAddress, newAddress)
+}
+
 // Wrapper struct for sdk.ValidatorHooks
 type ValidatorHooks struct {
 	k Keeper
@@ -44,3 +87,8 @@ func (v ValidatorHooks) OnValidatorBonded(ctx sdk.Context, address sdk.ConsAddre
 func (v ValidatorHooks) OnValidatorBeginUnbonding(ctx sdk.Context, address sdk.ConsAddress) {
 	v.k.onValidatorBeginUnbonding(ctx, address)
 }
+
+// Implements sdk.ValidatorHooks
+func (v ValidatorHooks) OnValidatorConsPubKeyRotated(ctx sdk.Context, oldAddress, newAddress sdk.ConsAddress) {
+	v.k.onValidatorConsPubKeyRotated(ctx, oldAddress, newAddress)
+}
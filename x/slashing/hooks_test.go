@@ -24,3 +24,56 @@ func TestHookOnValidatorBeginUnbonding(t *testing.T) {
 	period := keeper.getValidatorSlashingPeriodForHeight(ctx, addr, ctx.BlockHeight())
 	require.Equal(t, ValidatorSlashingPeriod{addr, ctx.BlockHeight(), ctx.BlockHeight(), sdk.ZeroDec()}, period)
 }
+
+func TestHookOnValidatorConsPubKeyRotated(t *testing.T) {
+	ctx, _, _, _, keeper := createTestInput(t)
+	oldAddr, newAddr := sdk.ConsAddress(addrs[0]), sdk.ConsAddress(addrs[1])
+	keeper.onValidatorBonded(ctx, oldAddr)
+	keeper.addPubkey(ctx, pks[0])
+	keeper.handleValidatorSignature(ctx, pks[0].Address(), 100, false)
+	keeper.handleValidatorSignature(ctx, pks[0].Address(), 100, true)
+	oldInfo, found := keeper.getValidatorSigningInfo(ctx, oldAddr)
+	require.True(t, found)
+
+	keeper.onValidatorConsPubKeyRotated(ctx, oldAddr, newAddr)
+
+	// signing info and bit array are moved
+	newInfo, found := keeper.getValidatorSigningInfo(ctx, newAddr)
+	require.True(t, found)
+	require.Equal(t, oldInfo, newInfo)
+	require.False(t, keeper.getValidatorSigningBitArray(ctx, newAddr, 0))
+	require.True(t, keeper.getValidatorSigningBitArray(ctx, newAddr, 1))
+	_, found = keeper.getValidatorSigningInfo(ctx, oldAddr)
+	require.False(t, found)
+	require.False(t, keeper.getValidatorSigningBitArray(ctx, oldAddr, 1))
+
+	// slashing period is moved
+	period := keeper.getValidatorSlashingPeriodForHeight(ctx, newAddr, ctx.BlockHeight())
+	require.Equal(t, ValidatorSlashingPeriod{newAddr, ctx.BlockHeight(), 0, sdk.ZeroDec()}, period)
+	keeper.iterateValidatorSlashingPeriods(ctx, oldAddr, func(ValidatorSlashingPeriod) bool {
+		t.Fatal("slashing period of the old address is kept")
+		return true
+	})
+
+	// the old address resolves to the new one, signatures of the old pubkey
+	// update the moved records
+	require.Equal(t, newAddr, keeper.getCanonicalConsAddress(ctx, oldAddr))
+	keeper.handleValidatorSignature(ctx, pks[0].Address(), 100, true)
+	newInfo, found = keeper.getValidatorSigningInfo(ctx, newAddr)
+	require.True(t, found)
+	require.Equal(t, oldInfo.IndexOffset+1, newInfo.IndexOffset)
+	_, found = keeper.getValidatorSigningInfo(ctx, oldAddr)
+	require.False(t, found)
+
+	// infractions of both pubkeys share the slashing period
+	fraction := sdk.NewDecWithPrec(5, 2)
+	require.True(t, fraction.Equal(keeper.capBySlashingPeriod(ctx, keeper.getCanonicalConsAddress(ctx, oldAddr), fraction, ctx.BlockHeight())))
+	require.True(t, keeper.capBySlashingPeriod(ctx, newAddr, fraction, ctx.BlockHeight()).IsZero())
+
+	// rotating back to the old pubkey moves the records back
+	keeper.onValidatorConsPubKeyRotated(ctx, newAddr, oldAddr)
+	require.Equal(t, oldAddr, keeper.getCanonicalConsAddress(ctx, oldAddr))
+	require.Equal(t, oldAddr, keeper.getCanonicalConsAddress(ctx, newAddr))
+	_, found = keeper.getValidatorSigningInfo(ctx, oldAddr)
+	require.True(t, found)
+}
@@ -40,7 +40,9 @@ func (k Keeper) handleDoubleSign(ctx sdk.Context, addr crypto.Address, infractio
 	logger := ctx.Logger().With("module", "x/slashing")
 	time := ctx.BlockHeader().Time
 	age := time.Sub(timestamp)
-	address := sdk.ConsAddress(addr)
+	// the evidence may concern a since rotated pubkey, whose records have
+	// moved to the current consensus address of the validator
+	address := k.getCanonicalConsAddress(ctx, sdk.ConsAddress(addr))
 	pubkey, err := k.getPubkey(ctx, addr)
 	if err != nil {
 		panic(fmt.Sprintf("Validator address %v not found", addr))
@@ -63,7 +65,7 @@ func (k Keeper) handleDoubleSign(ctx sdk.Context, addr crypto.Address, infractio
 	logger.Info(fmt.Sprintf("Fraction slashed capped by slashing period from %v to %v", fraction, revisedFraction))
 
 	// Slash validator
-	k.validatorSet.Slash(ctx, pubkey, infractionHeight, power, revisedFraction)
+	k.slash(ctx, pubkey, infractionHeight, power, revisedFraction, SlashReasonDoubleSign)
 
 	// Jail validator
 	k.validatorSet.Jail(ctx, pubkey)
@@ -82,7 +84,9 @@ func (k Keeper) handleDoubleSign(ctx sdk.Context, addr crypto.Address, infractio
 func (k Keeper) handleValidatorSignature(ctx sdk.Context, addr crypto.Address, power int64, signed bool) {
 	logger := ctx.Logger().With("module", "x/slashing")
 	height := ctx.BlockHeight()
-	address := sdk.ConsAddress(addr)
+	// the signatures of the block following a rotation are still those of
+	// the rotated pubkey
+	address := k.getCanonicalConsAddress(ctx, sdk.ConsAddress(addr))
 	pubkey, err := k.getPubkey(ctx, addr)
 	if err != nil {
 		panic(fmt.Sprintf("Validator address %v not found", addr))
@@ -113,6 +117,10 @@ func (k Keeper) handleValidatorSignature(ctx sdk.Context, addr crypto.Address, p
 		signInfo.SignedBlocksCounter++
 	}
 
+	// The signatures handled in a block are those of the commit of the previous
+	// block, record its height along with the entry
+	k.setValidatorSigningHeight(ctx, address, index, height-1)
+
 	if !signed {
 		logger.Info(fmt.Sprintf("Absent validator %s at height %d, %d signed, threshold %d", addr, height, signInfo.SignedBlocksCounter, k.MinSignedPerWindow(ctx)))
 	}
@@ -123,7 +131,7 @@ func (k Keeper) handleValidatorSignature(ctx sdk.Context, addr crypto.Address, p
 			// Downtime confirmed: slash and jail the validator
 			logger.Info(fmt.Sprintf("Validator %s past min height of %d and below signed blocks threshold of %d",
 				pubkey.Address(), minHeight, k.MinSignedPerWindow(ctx)))
-			k.validatorSet.Slash(ctx, pubkey, height, power, k.SlashFractionDowntime(ctx))
+			k.slash(ctx, pubkey, height, power, k.SlashFractionDowntime(ctx), SlashReasonDowntime)
 			k.validatorSet.Jail(ctx, pubkey)
 			signInfo.JailedUntil = ctx.BlockHeader().Time.Add(k.DowntimeUnbondDuration(ctx))
 		} else {
@@ -175,3 +183,28 @@ func (k Keeper) deleteAddrPubkeyRelation(ctx sdk.Context, addr crypto.Address) {
 	store := ctx.KVStore(k.storeKey)
 	store.Delete(getAddrPubkeyRelationKey(addr))
 }
+
+// Returns the consensus address under which the signing info, signed blocks
+// bit array and slashing periods of a validator are stored: its current
+// consensus address, which the addresses of its rotated consensus pubkeys
+// resolve to.
+func (k Keeper) getCanonicalConsAddress(ctx sdk.Context, address sdk.ConsAddress) sdk.ConsAddress {
+	store := ctx.KVStore(k.storeKey)
+	for {
+		bz := store.Get(GetConsAddrRotationKey(address))
+		if bz == nil {
+			return address
+		}
+		address = sdk.ConsAddress(bz)
+	}
+}
+
+func (k Keeper) setConsAddrRotation(ctx sdk.Context, oldAddress, newAddress sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetConsAddrRotationKey(oldAddress), newAddress.Bytes())
+}
+
+func (k Keeper) deleteConsAddrRotation(ctx sdk.Context, address sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetConsAddrRotationKey(address))
+}
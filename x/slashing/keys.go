@@ -12,6 +12,9 @@ var (
 	ValidatorSigningBitArrayKey = []byte{0x02} // Prefix for signature bit array
 	ValidatorSlashingPeriodKey  = []byte{0x03} // Prefix for slashing period
 	AddrPubkeyRelationKey       = []byte{0x04} // Prefix for address-pubkey relation
+	ValidatorSlashEventKey      = []byte{0x05} // Prefix for slash event history
+	ValidatorSigningHeightKey   = []byte{0x06} // Prefix for the heights of the signature bit array entries
+	ConsAddrRotationKey         = []byte{0x07} // Prefix for rotated consensus address to new consensus address relation
 )
 
 // stored by *Tendermint* address (not operator address)
@@ -19,6 +22,11 @@ func GetValidatorSigningInfoKey(v sdk.ConsAddress) []byte {
 	return append(ValidatorSigningInfoKey, v.Bytes()...)
 }
 
+// stored by *Tendermint* address (not operator address)
+func GetValidatorSigningBitArrayPrefix(v sdk.ConsAddress) []byte {
+	return append(ValidatorSigningBitArrayKey, v.Bytes()...)
+}
+
 // stored by *Tendermint* address (not operator address)
 func GetValidatorSigningBitArrayKey(v sdk.ConsAddress, i int64) []byte {
 	b := make([]byte, 8)
@@ -26,6 +34,14 @@ func GetValidatorSigningBitArrayKey(v sdk.ConsAddress, i int64) []byte {
 	return append(ValidatorSigningBitArrayKey, append(v.Bytes(), b...)...)
 }
 
+// stored by *Tendermint* address (not operator address) followed by the
+// index of the bit array entry
+func GetValidatorSigningHeightKey(v sdk.ConsAddress, i int64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, uint64(i))
+	return append(ValidatorSigningHeightKey, append(v.Bytes(), b...)...)
+}
+
 // stored by *Tendermint* address (not operator address)
 func GetValidatorSlashingPeriodPrefix(v sdk.ConsAddress) []byte {
 	return append(ValidatorSlashingPeriodKey, v.Bytes()...)
@@ -41,3 +57,23 @@ func GetValidatorSlashingPeriodKey(v sdk.ConsAddress, startHeight int64) []byte
 func getAddrPubkeyRelationKey(address []byte) []byte {
 	return append(AddrPubkeyRelationKey, address...)
 }
+
+// stored by the rotated *Tendermint* address
+func GetConsAddrRotationKey(v sdk.ConsAddress) []byte {
+	return append(ConsAddrRotationKey, v.Bytes()...)
+}
+
+// stored by *operator* address (not Tendermint address)
+func GetValidatorSlashEventsPrefix(v sdk.ValAddress) []byte {
+	return append(ValidatorSlashEventKey, v.Bytes()...)
+}
+
+// stored by *operator* address (not Tendermint address) followed by the height
+// at which the slash was applied and the index of the slash within that block.
+// Big endian is used so that events iterate in chronological order.
+func GetValidatorSlashEventKey(v sdk.ValAddress, height int64, index int64) []byte {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[:8], uint64(height))
+	binary.BigEndian.PutUint64(b[8:], uint64(index))
+	return append(GetValidatorSlashEventsPrefix(v), b...)
+}
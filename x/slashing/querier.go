@@ -0,0 +1,122 @@
+package slashing
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// query endpoints supported by the slashing Querier
+const (
+	QuerySigningInfo  = "signingInfo"
+	QueryMissedBlocks = "missedBlocks"
+	QuerySlashes      = "slashes"
+)
+
+// creates a querier for slashing REST endpoints
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QuerySigningInfo:
+			return querySigningInfo(ctx, req, k)
+		case QueryMissedBlocks:
+			return queryMissedBlocks(ctx, req, k)
+		case QuerySlashes:
+			return querySlashes(ctx, req, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown slashing query endpoint")
+		}
+	}
+}
+
+// defines the params for the following queries:
+// - 'custom/slashing/signingInfo'
+// - 'custom/slashing/missedBlocks'
+type QuerySigningInfoParams struct {
+	ConsAddress sdk.ConsAddress
+}
+
+// defines the params for the following queries:
+// - 'custom/slashing/slashes'
+type QuerySlashesParams struct {
+	ValidatorAddr sdk.ValAddress
+}
+
+// Missed blocks of a validator within the current signed blocks window
+type ValidatorMissedBlocks struct {
+	Address             sdk.ConsAddress `json:"address"`
+	SignedBlocksWindow  int64           `json:"signed_blocks_window"`
+	SignedBlocksCounter int64           `json:"signed_blocks_counter"`
+	MissedBlocks        []MissedBlock   `json:"missed_blocks"`
+}
+
+func querySigningInfo(ctx sdk.Context, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QuerySigningInfoParams
+
+	errRes := k.cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownAddress(fmt.Sprintf("incorrectly formatted request address: %s", errRes.Error()))
+	}
+
+	// the address of a rotated pubkey resolves to the current one of the validator
+	address := k.getCanonicalConsAddress(ctx, params.ConsAddress)
+	signingInfo, found := k.getValidatorSigningInfo(ctx, address)
+	if !found {
+		return []byte{}, ErrNoSigningInfoFound(DefaultCodespace, params.ConsAddress)
+	}
+
+	res, errRes = codec.MarshalJSONIndent(k.cdc, signingInfo)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryMissedBlocks(ctx sdk.Context, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QuerySigningInfoParams
+
+	errRes := k.cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownAddress(fmt.Sprintf("incorrectly formatted request address: %s", errRes.Error()))
+	}
+
+	// the address of a rotated pubkey resolves to the current one of the validator
+	address := k.getCanonicalConsAddress(ctx, params.ConsAddress)
+	signingInfo, found := k.getValidatorSigningInfo(ctx, address)
+	if !found {
+		return []byte{}, ErrNoSigningInfoFound(DefaultCodespace, params.ConsAddress)
+	}
+	missedBlocks, _ := k.getValidatorMissedBlocks(ctx, address)
+
+	result := ValidatorMissedBlocks{
+		Address:             params.ConsAddress,
+		SignedBlocksWindow:  k.SignedBlocksWindow(ctx),
+		SignedBlocksCounter: signingInfo.SignedBlocksCounter,
+		MissedBlocks:        missedBlocks,
+	}
+
+	res, errRes = codec.MarshalJSONIndent(k.cdc, result)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", errRes.Error()))
+	}
+	return res, nil
+}
+
+func querySlashes(ctx sdk.Context, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QuerySlashesParams
+
+	errRes := k.cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownAddress(fmt.Sprintf("incorrectly formatted request address: %s", errRes.Error()))
+	}
+
+	events := k.GetValidatorSlashEvents(ctx, params.ValidatorAddr)
+
+	res, errRes = codec.MarshalJSONIndent(k.cdc, events)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", errRes.Error()))
+	}
+	return res, nil
+}
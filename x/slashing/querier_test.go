@@ -0,0 +1,121 @@
+package slashing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestQueryMissedBlocks(t *testing.T) {
+	ctx, _, sk, _, keeper := createTestInput(t)
+	sk = sk.WithValidatorHooks(keeper.ValidatorHooks())
+	amtInt := int64(100)
+	addr, val, amt := addrs[0], pks[0], sdk.NewInt(amtInt)
+	got := stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(addr, val, amt))
+	require.True(t, got.IsOK())
+	keeper.AddValidators(ctx, stake.EndBlocker(ctx, sk))
+	consAddr := sdk.ConsAddress(val.Address())
+
+	// no signing info yet
+	bz, err := keeper.cdc.MarshalJSON(QuerySigningInfoParams{ConsAddress: consAddr})
+	require.Nil(t, err)
+	query := abci.RequestQuery{Data: bz}
+	_, sdkErr := queryMissedBlocks(ctx, query, keeper)
+	require.NotNil(t, sdkErr)
+
+	// sign, miss, sign blocks 1 to 3, whose commits are handled at the next height
+	for height, signed := range []bool{true, false, true} {
+		ctx = ctx.WithBlockHeight(int64(height + 2))
+		keeper.handleValidatorSignature(ctx, val.Address(), amtInt, signed)
+	}
+
+	res, sdkErr := queryMissedBlocks(ctx, query, keeper)
+	require.Nil(t, sdkErr)
+
+	var missed ValidatorMissedBlocks
+	require.Nil(t, keeper.cdc.UnmarshalJSON(res, &missed))
+	require.Equal(t, consAddr, missed.Address)
+	require.Equal(t, keeper.SignedBlocksWindow(ctx), missed.SignedBlocksWindow)
+	require.Equal(t, int64(2), missed.SignedBlocksCounter)
+	require.Equal(t, []MissedBlock{{1, false}, {2, true}, {3, false}}, missed.MissedBlocks)
+
+	res, sdkErr = querySigningInfo(ctx, query, keeper)
+	require.Nil(t, sdkErr)
+
+	var info ValidatorSigningInfo
+	require.Nil(t, keeper.cdc.UnmarshalJSON(res, &info))
+	require.Equal(t, int64(3), info.IndexOffset)
+}
+
+func TestQueryMissedBlocksWrapped(t *testing.T) {
+	ctx, _, sk, ps, keeper := createTestInput(t)
+	require.Nil(t, ps.Set(ctx, SignedBlocksWindowKey, int64(4)))
+	sk = sk.WithValidatorHooks(keeper.ValidatorHooks())
+	amtInt := int64(100)
+	addr, val, amt := addrs[0], pks[0], sdk.NewInt(amtInt)
+	got := stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(addr, val, amt))
+	require.True(t, got.IsOK())
+	keeper.AddValidators(ctx, stake.EndBlocker(ctx, sk))
+	consAddr := sdk.ConsAddress(val.Address())
+
+	// 6 signatures in a window of 4, the first 2 entries are overwritten; no
+	// signature is handled at heights 13 and 14, while the validator is jailed
+	for i, height := range []int64{10, 11, 12, 15, 16, 17} {
+		ctx = ctx.WithBlockHeight(height)
+		keeper.handleValidatorSignature(ctx, val.Address(), amtInt, i%2 == 0)
+	}
+
+	// the heights do not depend on the height of the queried state
+	ctx = ctx.WithBlockHeader(abci.Header{}).WithBlockHeight(0)
+
+	bz, err := keeper.cdc.MarshalJSON(QuerySigningInfoParams{ConsAddress: consAddr})
+	require.Nil(t, err)
+	res, sdkErr := queryMissedBlocks(ctx, abci.RequestQuery{Data: bz}, keeper)
+	require.Nil(t, sdkErr)
+
+	var missed ValidatorMissedBlocks
+	require.Nil(t, keeper.cdc.UnmarshalJSON(res, &missed))
+	require.Equal(t, int64(4), missed.SignedBlocksWindow)
+	require.Equal(t, int64(2), missed.SignedBlocksCounter)
+	require.Equal(t, []MissedBlock{{11, false}, {14, true}, {15, false}, {16, true}}, missed.MissedBlocks)
+}
+
+func TestQuerySlashes(t *testing.T) {
+	ctx, _, sk, _, keeper := createTestInput(t)
+	sk = sk.WithValidatorHooks(keeper.ValidatorHooks())
+	amtInt := int64(100)
+	addr, val, amt := addrs[0], pks[0], sdk.NewInt(amtInt)
+	got := stake.NewHandler(sk)(ctx, newTestMsgCreateValidator(addr, val, amt))
+	require.True(t, got.IsOK())
+	keeper.AddValidators(ctx, stake.EndBlocker(ctx, sk))
+	keeper.handleValidatorSignature(ctx, val.Address(), amtInt, true)
+
+	bz, err := keeper.cdc.MarshalJSON(QuerySlashesParams{ValidatorAddr: addr})
+	require.Nil(t, err)
+	query := abci.RequestQuery{Data: bz}
+
+	// no slashes yet
+	res, sdkErr := querySlashes(ctx, query, keeper)
+	require.Nil(t, sdkErr)
+	var events []SlashEvent
+	require.Nil(t, keeper.cdc.UnmarshalJSON(res, &events))
+	require.Len(t, events, 0)
+
+	// double sign
+	keeper.handleDoubleSign(ctx, val.Address(), 0, time.Unix(0, 0), amtInt)
+
+	res, sdkErr = querySlashes(ctx, query, keeper)
+	require.Nil(t, sdkErr)
+	require.Nil(t, keeper.cdc.UnmarshalJSON(res, &events))
+	require.Len(t, events, 1)
+	require.Equal(t, addr, events[0].ValidatorAddr)
+	require.Equal(t, SlashReasonDoubleSign, events[0].Reason)
+	require.Equal(t, int64(0), events[0].InfractionHeight)
+	require.True(t, keeper.SlashFractionDoubleSign(ctx).Equal(events[0].Fraction))
+	require.True(t, sdk.NewDec(5).Equal(events[0].TokensBurned))
+}
@@ -1,6 +1,7 @@
 package slashing
 
 import (
+	"encoding/binary"
 	"fmt"
 	"time"
 
@@ -27,6 +28,12 @@ func (k Keeper) setValidatorSigningInfo(ctx sdk.Context, address sdk.ConsAddress
 	store.Set(GetValidatorSigningInfoKey(address), bz)
 }
 
+// Stored by *validator* address (not operator address)
+func (k Keeper) deleteValidatorSigningInfo(ctx sdk.Context, address sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetValidatorSigningInfoKey(address))
+}
+
 // Stored by *validator* address (not operator address)
 func (k Keeper) getValidatorSigningBitArray(ctx sdk.Context, address sdk.ConsAddress, index int64) (signed bool) {
 	store := ctx.KVStore(k.storeKey)
@@ -47,6 +54,82 @@ func (k Keeper) setValidatorSigningBitArray(ctx sdk.Context, address sdk.ConsAdd
 	store.Set(GetValidatorSigningBitArrayKey(address, index), bz)
 }
 
+// Stored by *validator* address (not operator address)
+func (k Keeper) deleteValidatorSigningBitArray(ctx sdk.Context, address sdk.ConsAddress, index int64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetValidatorSigningBitArrayKey(address, index))
+}
+
+// Stored by *validator* address (not operator address)
+// Iterates over the set entries of the signed blocks bit array
+func (k Keeper) iterateValidatorSigningBitArray(ctx sdk.Context, address sdk.ConsAddress, handler func(index int64, signed bool) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	prefix := GetValidatorSigningBitArrayPrefix(address)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		index := int64(binary.LittleEndian.Uint64(iterator.Key()[len(prefix):]))
+		var signed bool
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &signed)
+		if handler(index, signed) {
+			break
+		}
+	}
+}
+
+// Stored by *validator* address (not operator address)
+// Height of the block whose commit the bit array entry at the index records
+func (k Keeper) getValidatorSigningHeight(ctx sdk.Context, address sdk.ConsAddress, index int64) (height int64) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetValidatorSigningHeightKey(address, index))
+	if bz == nil {
+		return 0
+	}
+	k.cdc.MustUnmarshalBinary(bz, &height)
+	return
+}
+
+// Stored by *validator* address (not operator address)
+func (k Keeper) setValidatorSigningHeight(ctx sdk.Context, address sdk.ConsAddress, index int64, height int64) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(height)
+	store.Set(GetValidatorSigningHeightKey(address, index), bz)
+}
+
+// Stored by *validator* address (not operator address)
+func (k Keeper) deleteValidatorSigningHeight(ctx sdk.Context, address sdk.ConsAddress, index int64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetValidatorSigningHeightKey(address, index))
+}
+
+// Stored by *validator* address (not operator address)
+// Returns the entries of the signed blocks window which have been filled so far,
+// oldest first, with the height of the block each of them records. The heights
+// need not be consecutive, as no entry is filled while the validator is not in
+// the validator set.
+func (k Keeper) getValidatorMissedBlocks(ctx sdk.Context, address sdk.ConsAddress) (missed []MissedBlock, found bool) {
+	info, found := k.getValidatorSigningInfo(ctx, address)
+	if !found {
+		return
+	}
+	window := k.SignedBlocksWindow(ctx)
+	filled := info.IndexOffset
+	if filled > window {
+		filled = window
+	}
+	// the bit array is a ring buffer, the next entry to fill is the oldest one
+	first := info.IndexOffset - filled
+	missed = make([]MissedBlock, filled)
+	for i := int64(0); i < filled; i++ {
+		index := (first + i) % window
+		missed[i] = MissedBlock{
+			Height: k.getValidatorSigningHeight(ctx, address, index),
+			Missed: !k.getValidatorSigningBitArray(ctx, address, index),
+		}
+	}
+	return
+}
+
 // Construct a new `ValidatorSigningInfo` struct
 func NewValidatorSigningInfo(startHeight int64, indexOffset int64, jailedUntil time.Time, signedBlocksCounter int64) ValidatorSigningInfo {
 	return ValidatorSigningInfo{
@@ -70,3 +153,9 @@ func (i ValidatorSigningInfo) HumanReadableString() string {
 	return fmt.Sprintf("Start height: %d, index offset: %d, jailed until: %v, signed blocks counter: %d",
 		i.StartHeight, i.IndexOffset, i.JailedUntil, i.SignedBlocksCounter)
 }
+
+// Entry of a validator's signed blocks bit array
+type MissedBlock struct {
+	Height int64 `json:"height"` // height of the block the signature was expected for
+	Missed bool  `json:"missed"` // whether the validator missed signing the block
+}
@@ -36,3 +36,12 @@ func TestGetSetValidatorSigningBitArray(t *testing.T) {
 	signed = keeper.getValidatorSigningBitArray(ctx, sdk.ConsAddress(addrs[0]), 0)
 	require.True(t, signed) // now should be signed
 }
+
+func TestGetSetValidatorSigningHeight(t *testing.T) {
+	ctx, _, _, _, keeper := createTestInput(t)
+	height := keeper.getValidatorSigningHeight(ctx, sdk.ConsAddress(addrs[0]), 0)
+	require.Equal(t, int64(0), height)
+	keeper.setValidatorSigningHeight(ctx, sdk.ConsAddress(addrs[0]), 0, 42)
+	height = keeper.getValidatorSigningHeight(ctx, sdk.ConsAddress(addrs[0]), 0)
+	require.Equal(t, int64(42), height)
+}
@@ -0,0 +1,102 @@
+package slashing
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// Reason for which a validator was slashed
+type SlashReason string
+
+// nolint
+const (
+	SlashReasonDoubleSign SlashReason = "double_sign"
+	SlashReasonDowntime   SlashReason = "downtime"
+)
+
+// Slash a validator through the validator set and record the slash event
+// in the validator's slashing history
+func (k Keeper) slash(ctx sdk.Context, pubkey crypto.PubKey, infractionHeight int64, power int64, fraction sdk.Dec, reason SlashReason) {
+	validator := k.validatorSet.ValidatorByPubKey(ctx, pubkey)
+	if validator == nil {
+		// the validator set will ignore the slash, so there is nothing to record
+		k.validatorSet.Slash(ctx, pubkey, infractionHeight, power, fraction)
+		return
+	}
+	operator := validator.GetOperator()
+	tokensBefore := validator.GetTokens()
+
+	k.validatorSet.Slash(ctx, pubkey, infractionHeight, power, fraction)
+
+	// the validator may have been removed if it was slashed to zero tokens
+	tokensAfter := sdk.ZeroDec()
+	if validator = k.validatorSet.Validator(ctx, operator); validator != nil {
+		tokensAfter = validator.GetTokens()
+	}
+
+	event := NewSlashEvent(operator, ctx.BlockHeight(), infractionHeight, fraction, reason, tokensBefore.Sub(tokensAfter))
+	k.addValidatorSlashEvent(ctx, event)
+}
+
+// Stored by *operator* address (not Tendermint address)
+// Appends a slash event after any other events recorded at the same height
+func (k Keeper) addValidatorSlashEvent(ctx sdk.Context, event SlashEvent) {
+	store := ctx.KVStore(k.storeKey)
+	start := GetValidatorSlashEventKey(event.ValidatorAddr, event.Height, 0)
+	end := GetValidatorSlashEventKey(event.ValidatorAddr, event.Height+1, 0)
+	iterator := store.Iterator(start, end)
+	index := int64(0)
+	for ; iterator.Valid(); iterator.Next() {
+		index++
+	}
+	iterator.Close()
+	bz := k.cdc.MustMarshalBinary(event)
+	store.Set(GetValidatorSlashEventKey(event.ValidatorAddr, event.Height, index), bz)
+}
+
+// Stored by *operator* address (not Tendermint address)
+// Returns the slash events of a validator, oldest first
+func (k Keeper) GetValidatorSlashEvents(ctx sdk.Context, address sdk.ValAddress) (events []SlashEvent) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetValidatorSlashEventsPrefix(address))
+	defer iterator.Close()
+	events = []SlashEvent{}
+	for ; iterator.Valid(); iterator.Next() {
+		var event SlashEvent
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &event)
+		events = append(events, event)
+	}
+	return
+}
+
+// Construct a new `SlashEvent` struct
+func NewSlashEvent(validatorAddr sdk.ValAddress, height int64, infractionHeight int64,
+	fraction sdk.Dec, reason SlashReason, tokensBurned sdk.Dec) SlashEvent {
+
+	return SlashEvent{
+		ValidatorAddr:    validatorAddr,
+		Height:           height,
+		InfractionHeight: infractionHeight,
+		Fraction:         fraction,
+		Reason:           reason,
+		TokensBurned:     tokensBurned,
+	}
+}
+
+// Slash event of a validator
+type SlashEvent struct {
+	ValidatorAddr    sdk.ValAddress `json:"validator_addr"`    // operator address of the slashed validator
+	Height           int64          `json:"height"`            // height at which the slash was applied
+	InfractionHeight int64          `json:"infraction_height"` // height at which the infraction was committed
+	Fraction         sdk.Dec        `json:"fraction"`          // fraction of stake slashed, after the slashing period cap
+	Reason           SlashReason    `json:"reason"`            // reason for the slash
+	TokensBurned     sdk.Dec        `json:"tokens_burned"`     // tokens burned from the validator's bonded stake
+}
+
+// Return human readable slash event
+func (e SlashEvent) HumanReadableString() string {
+	return fmt.Sprintf("Height: %d, infraction height: %d, reason: %s, fraction: %v, tokens burned: %v",
+		e.Height, e.InfractionHeight, e.Reason, e.Fraction, e.TokensBurned)
+}
@@ -63,6 +63,27 @@ func (k Keeper) addOrUpdateValidatorSlashingPeriod(ctx sdk.Context, slashingPeri
 	store.Set(GetValidatorSlashingPeriodKey(slashingPeriod.ValidatorAddr, slashingPeriod.StartHeight), bz)
 }
 
+// Stored by validator Tendermint address (not operator address)
+// Removes the slashing period of a validator with the given start height
+func (k Keeper) deleteValidatorSlashingPeriod(ctx sdk.Context, address sdk.ConsAddress, startHeight int64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetValidatorSlashingPeriodKey(address, startHeight))
+}
+
+// Stored by validator Tendermint address (not operator address)
+// Iterates over all slashing periods of a validator
+func (k Keeper) iterateValidatorSlashingPeriods(ctx sdk.Context, address sdk.ConsAddress, handler func(slashingPeriod ValidatorSlashingPeriod) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetValidatorSlashingPeriodPrefix(address))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		slashingPeriod := k.unmarshalSlashingPeriodKeyValue(iterator.Key(), iterator.Value())
+		if handler(slashingPeriod) {
+			break
+		}
+	}
+}
+
 // Unmarshal key/value into a ValidatorSlashingPeriod
 func (k Keeper) unmarshalSlashingPeriodKeyValue(key []byte, value []byte) ValidatorSlashingPeriod {
 	var slashingPeriodValue ValidatorSlashingPeriodValue
@@ -3,6 +3,7 @@ package cli
 import (
 	flag "github.com/spf13/pflag"
 
+	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
@@ -16,11 +17,15 @@ const (
 	FlagAmount              = "amount"
 	FlagSharesAmount        = "shares-amount"
 	FlagSharesPercent       = "shares-percent"
+	FlagAutoRestake         = "auto-restake"
 
 	FlagMoniker  = "moniker"
 	FlagIdentity = "identity"
 	FlagWebsite  = "website"
 	FlagDetails  = "details"
+
+	FlagStart = "start"
+	FlagLimit = "limit"
 )
 
 // common flagsets to add to various functions
@@ -33,6 +38,7 @@ var (
 	fsValidator         = flag.NewFlagSet("", flag.ContinueOnError)
 	fsDelegator         = flag.NewFlagSet("", flag.ContinueOnError)
 	fsRedelegation      = flag.NewFlagSet("", flag.ContinueOnError)
+	fsPage              = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
 func init() {
@@ -52,4 +58,6 @@ func init() {
 	fsDelegator.String(FlagAddressDelegator, "", "hex address of the delegator")
 	fsRedelegation.String(FlagAddressValidatorSrc, "", "hex address of the source validator")
 	fsRedelegation.String(FlagAddressValidatorDst, "", "hex address of the destination validator")
+	fsPage.String(FlagStart, "", "Hex-encoded store key of the first record, as printed after the previous page; omit to start from the first record")
+	fsPage.Int(FlagLimit, context.DefaultPageLimit, "Maximum number of records")
 }
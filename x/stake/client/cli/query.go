@@ -1,7 +1,11 @@
 package cli
 
 import (
+	"bytes"
+	"encoding/hex"
 	"fmt"
+	"os"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -68,12 +72,12 @@ func GetCmdQueryValidator(storeName string, cdc *codec.Codec) *cobra.Command {
 func GetCmdQueryValidators(storeName string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "validators",
-		Short: "Query for all validators",
+		Short: "Query for all validators, one page at a time",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			key := stake.ValidatorsKey
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
-			resKVs, err := cliCtx.QuerySubspace(key, storeName)
+			resKVs, err := queryPage(cliCtx, key, storeName)
 			if err != nil {
 				return err
 			}
@@ -111,6 +115,8 @@ func GetCmdQueryValidators(storeName string, cdc *codec.Codec) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().AddFlagSet(fsPage)
+
 	return cmd
 }
 
@@ -177,7 +183,7 @@ func GetCmdQueryDelegation(storeName string, cdc *codec.Codec) *cobra.Command {
 func GetCmdQueryDelegations(storeName string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "delegations [delegator-addr]",
-		Short: "Query all delegations made from one delegator",
+		Short: "Query all delegations made from one delegator, one page at a time",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			delegatorAddr, err := sdk.AccAddressFromBech32(args[0])
@@ -188,7 +194,7 @@ func GetCmdQueryDelegations(storeName string, cdc *codec.Codec) *cobra.Command {
 			key := stake.GetDelegationsKey(delegatorAddr)
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
-			resKVs, err := cliCtx.QuerySubspace(key, storeName)
+			resKVs, err := queryPage(cliCtx, key, storeName)
 			if err != nil {
 				return err
 			}
@@ -212,6 +218,8 @@ func GetCmdQueryDelegations(storeName string, cdc *codec.Codec) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().AddFlagSet(fsPage)
+
 	return cmd
 }
 
@@ -276,7 +284,7 @@ func GetCmdQueryUnbondingDelegation(storeName string, cdc *codec.Codec) *cobra.C
 func GetCmdQueryUnbondingDelegations(storeName string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "unbonding-delegations [delegator-addr]",
-		Short: "Query all unbonding-delegations records for one delegator",
+		Short: "Query all unbonding-delegations records for one delegator, one page at a time",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			delegatorAddr, err := sdk.AccAddressFromBech32(args[0])
@@ -287,7 +295,7 @@ func GetCmdQueryUnbondingDelegations(storeName string, cdc *codec.Codec) *cobra.
 			key := stake.GetUBDsKey(delegatorAddr)
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
-			resKVs, err := cliCtx.QuerySubspace(key, storeName)
+			resKVs, err := queryPage(cliCtx, key, storeName)
 			if err != nil {
 				return err
 			}
@@ -311,6 +319,8 @@ func GetCmdQueryUnbondingDelegations(storeName string, cdc *codec.Codec) *cobra.
 		},
 	}
 
+	cmd.Flags().AddFlagSet(fsPage)
+
 	return cmd
 }
 
@@ -380,7 +390,7 @@ func GetCmdQueryRedelegation(storeName string, cdc *codec.Codec) *cobra.Command
 func GetCmdQueryRedelegations(storeName string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "redelegations [delegator-addr]",
-		Short: "Query all redelegations records for one delegator",
+		Short: "Query all redelegations records for one delegator, one page at a time",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			delegatorAddr, err := sdk.AccAddressFromBech32(args[0])
@@ -391,7 +401,7 @@ func GetCmdQueryRedelegations(storeName string, cdc *codec.Codec) *cobra.Command
 			key := stake.GetREDsKey(delegatorAddr)
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
-			resKVs, err := cliCtx.QuerySubspace(key, storeName)
+			resKVs, err := queryPage(cliCtx, key, storeName)
 			if err != nil {
 				return err
 			}
@@ -415,6 +425,8 @@ func GetCmdQueryRedelegations(storeName string, cdc *codec.Codec) *cobra.Command
 		},
 	}
 
+	cmd.Flags().AddFlagSet(fsPage)
+
 	return cmd
 }
 
@@ -495,3 +507,77 @@ func GetCmdQueryParams(storeName string, cdc *codec.Codec) *cobra.Command {
 
 	return cmd
 }
+
+// GetCmdQueryHistoricalInfo implements the historical info query command.
+func GetCmdQueryHistoricalInfo(storeName string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "historical-info [height]",
+		Short: "Query the bonded validator set recorded at a height",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			key := stake.GetHistoricalInfoKey(height)
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.QueryStore(key, storeName)
+			if err != nil {
+				return err
+			} else if len(res) == 0 {
+				return fmt.Errorf("no historical info found for height %d", height)
+			}
+
+			hi := types.MustUnmarshalHistoricalInfo(cdc, res)
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				human := hi.HumanReadableString()
+
+				fmt.Println(human)
+
+			case "json":
+				// parse out the historical info
+				output, err := codec.MarshalJSONIndent(cdc, hi)
+				if err != nil {
+					return err
+				}
+
+				fmt.Println(string(output))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// queryPage queries a page of the records of the store under the prefix, in
+// the order of their key, starting from the hex-encoded key of the --start
+// flag. The flag starting the next page, if any, is printed to the standard
+// error.
+func queryPage(cliCtx context.CLIContext, prefix []byte, storeName string) ([]sdk.KVPair, error) {
+	var startKey []byte
+	if start := viper.GetString(FlagStart); start != "" {
+		key, err := hex.DecodeString(start)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.HasPrefix(key, prefix) {
+			return nil, fmt.Errorf("--%s=%s does not start a page of the queried records", FlagStart, start)
+		}
+		startKey = key
+	}
+
+	resKVs, nextKey, err := cliCtx.QuerySubspacePaginated(prefix, storeName, startKey, viper.GetInt(FlagLimit))
+	if err != nil {
+		return nil, err
+	}
+
+	if nextKey != nil {
+		fmt.Fprintf(os.Stderr, "More records follow, query them with --%s=%s\n", FlagStart, hex.EncodeToString(nextKey))
+	}
+	return resKVs, nil
+}
@@ -132,6 +132,48 @@ func GetCmdEditValidator(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdRotateConsPubKey implements the rotate consensus pubkey command.
+func GetCmdRotateConsPubKey(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate-cons-pubkey",
+		Short: "replace the consensus pubkey of an existing validator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			valAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			pkStr := viper.GetString(FlagPubKey)
+			if len(pkStr) == 0 {
+				return fmt.Errorf("must use --pubkey flag")
+			}
+
+			pk, err := sdk.GetConsPubKeyBech32(pkStr)
+			if err != nil {
+				return err
+			}
+
+			msg := stake.NewMsgRotateConsPubKey(sdk.ValAddress(valAddr), pk)
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+			}
+			// build and sign the transaction, then broadcast to Tendermint
+			return utils.SendTx(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsPk)
+
+	return cmd
+}
+
 // GetCmdDelegate implements the delegate command.
 func GetCmdDelegate(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -175,6 +217,45 @@ func GetCmdDelegate(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdSetAutoRestake implements the command to turn the restaking of
+// withdrawn rewards of a delegation on or off.
+func GetCmdSetAutoRestake(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-auto-restake",
+		Short: "turn the restaking of withdrawn rewards of a delegation on or off",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			delAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			valAddr, err := sdk.ValAddressFromBech32(viper.GetString(FlagAddressValidator))
+			if err != nil {
+				return err
+			}
+
+			msg := stake.NewMsgSetAutoRestake(delAddr, valAddr, viper.GetBool(FlagAutoRestake))
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+			}
+			// build and sign the transaction, then broadcast to Tendermint
+			return utils.SendTx(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().Bool(FlagAutoRestake, true, "restake withdrawn rewards with the validator")
+	cmd.Flags().AddFlagSet(fsValidator)
+
+	return cmd
+}
+
 // GetCmdRedelegate implements the redelegate validator command.
 func GetCmdRedelegate(storeName string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -247,6 +328,88 @@ func GetCmdBeginRedelegate(storeName string, cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdTokenizeShares implements the command to convert delegation shares
+// into bank-transferable tokens.
+func GetCmdTokenizeShares(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tokenize-shares",
+		Short: "convert delegation shares into tokens of a denom unique to the validator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			delAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			valAddr, err := sdk.ValAddressFromBech32(viper.GetString(FlagAddressValidator))
+			if err != nil {
+				return err
+			}
+
+			sharesAmount, err := sdk.NewDecFromStr(viper.GetString(FlagSharesAmount))
+			if err != nil {
+				return err
+			}
+
+			msg := stake.NewMsgTokenizeShares(delAddr, valAddr, sharesAmount)
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+			}
+			// build and sign the transaction, then broadcast to Tendermint
+			return utils.SendTx(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagSharesAmount, "", "Amount of shares to tokenize as a positive integer")
+	cmd.Flags().AddFlagSet(fsValidator)
+
+	return cmd
+}
+
+// GetCmdRedeemTokens implements the command to convert tokenized delegation
+// shares back into a delegation.
+func GetCmdRedeemTokens(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "redeem-tokens",
+		Short: "convert tokenized delegation shares back into a delegation",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			amount, err := sdk.ParseCoin(viper.GetString(FlagAmount))
+			if err != nil {
+				return err
+			}
+
+			delAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			msg := stake.NewMsgRedeemTokens(delAddr, amount)
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+			}
+			// build and sign the transaction, then broadcast to Tendermint
+			return utils.SendTx(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(FlagAmount, "", "Amount of tokenized shares to redeem (ex. 10delshare/cosmosvaloper1...)")
+
+	return cmd
+}
+
 // nolint: gocyclo
 // TODO: Make this pass gocyclo linting
 func getShares(
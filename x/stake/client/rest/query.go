@@ -3,6 +3,7 @@ package rest
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
@@ -79,6 +80,12 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Co
 		paramsHandlerFn(cliCtx),
 	).Methods("GET")
 
+	// Get the bonded validator set recorded at a height
+	r.HandleFunc(
+		"/stake/historical_info/{height}",
+		historicalInfoHandlerFn(cliCtx, cdc),
+	).Methods("GET")
+
 }
 
 // HTTP request handler to query a delegator delegations
@@ -468,3 +475,41 @@ func paramsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 		w.Write(res)
 	}
 }
+
+// HTTP request handler to query the historical info recorded at a height
+func historicalInfoHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		vars := mux.Vars(r)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		height, err := strconv.ParseInt(vars["height"], 10, 64)
+		if err != nil || height < 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf("invalid height: %s", vars["height"])))
+			return
+		}
+
+		params := stake.QueryHistoricalInfoParams{
+			Height: height,
+		}
+
+		bz, err := cdc.MarshalJSON(params)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		res, err := cliCtx.QueryWithData("custom/stake/historicalInfo", bz)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+
+			return
+		}
+
+		w.Write(res)
+	}
+}
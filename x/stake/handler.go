@@ -19,8 +19,12 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 			return handleMsgCreateValidator(ctx, msg, k)
 		case types.MsgEditValidator:
 			return handleMsgEditValidator(ctx, msg, k)
+		case types.MsgRotateConsPubKey:
+			return handleMsgRotateConsPubKey(ctx, msg, k)
 		case types.MsgDelegate:
 			return handleMsgDelegate(ctx, msg, k)
+		case types.MsgSetAutoRestake:
+			return handleMsgSetAutoRestake(ctx, msg, k)
 		case types.MsgBeginRedelegate:
 			return handleMsgBeginRedelegate(ctx, msg, k)
 		case types.MsgCompleteRedelegate:
@@ -29,12 +33,21 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 			return handleMsgBeginUnbonding(ctx, msg, k)
 		case types.MsgCompleteUnbonding:
 			return handleMsgCompleteUnbonding(ctx, msg, k)
+		case types.MsgTokenizeShares:
+			return handleMsgTokenizeShares(ctx, msg, k)
+		case types.MsgRedeemTokens:
+			return handleMsgRedeemTokens(ctx, msg, k)
 		default:
 			return sdk.ErrTxDecode("invalid message parse in staking module").Result()
 		}
 	}
 }
 
+// Called every block, record the historical info of the new block
+func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper) {
+	k.TrackHistoricalInfo(ctx, req.Hash)
+}
+
 // Called every block, process inflation, update validator set
 func EndBlocker(ctx sdk.Context, k keeper.Keeper) (ValidatorUpdates []abci.Validator) {
 	pool := k.GetPool(ctx)
@@ -51,6 +64,9 @@ func EndBlocker(ctx sdk.Context, k keeper.Keeper) (ValidatorUpdates []abci.Valid
 	// reset the intra-transaction counter
 	k.SetIntraTxCounter(ctx, 0)
 
+	// drop the consensus pubkeys retired for longer than the unbonding period
+	k.PruneRetiredConsPubKeys(ctx)
+
 	// calculate validator set changes
 	ValidatorUpdates = k.GetValidTendermintUpdates(ctx)
 	return
@@ -127,6 +143,21 @@ func handleMsgEditValidator(ctx sdk.Context, msg types.MsgEditValidator, k keepe
 	}
 }
 
+func handleMsgRotateConsPubKey(ctx sdk.Context, msg types.MsgRotateConsPubKey, k keeper.Keeper) sdk.Result {
+	err := k.RotateConsPubKey(ctx, msg.ValidatorAddr, msg.NewPubKey)
+	if err != nil {
+		return err.Result()
+	}
+
+	tags := sdk.NewTags(
+		tags.Action, tags.ActionRotateConsPubKey,
+		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
 func handleMsgDelegate(ctx sdk.Context, msg types.MsgDelegate, k keeper.Keeper) sdk.Result {
 	validator, found := k.GetValidator(ctx, msg.ValidatorAddr)
 	if !found {
@@ -157,6 +188,23 @@ func handleMsgDelegate(ctx sdk.Context, msg types.MsgDelegate, k keeper.Keeper)
 	}
 }
 
+func handleMsgSetAutoRestake(ctx sdk.Context, msg types.MsgSetAutoRestake, k keeper.Keeper) sdk.Result {
+	err := k.SetAutoRestake(ctx, msg.DelegatorAddr, msg.ValidatorAddr, msg.AutoRestake)
+	if err != nil {
+		return err.Result()
+	}
+
+	tags := sdk.NewTags(
+		tags.Action, tags.ActionSetAutoRestake,
+		tags.Delegator, []byte(msg.DelegatorAddr.String()),
+		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+	)
+
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
 func handleMsgBeginUnbonding(ctx sdk.Context, msg types.MsgBeginUnbonding, k keeper.Keeper) sdk.Result {
 	err := k.BeginUnbonding(ctx, msg.DelegatorAddr, msg.ValidatorAddr, msg.SharesAmount)
 	if err != nil {
@@ -217,3 +265,37 @@ func handleMsgCompleteRedelegate(ctx sdk.Context, msg types.MsgCompleteRedelegat
 	)
 	return sdk.Result{Tags: tags}
 }
+
+func handleMsgTokenizeShares(ctx sdk.Context, msg types.MsgTokenizeShares, k keeper.Keeper) sdk.Result {
+	_, err := k.TokenizeShares(ctx, msg.DelegatorAddr, msg.ValidatorAddr, msg.SharesAmount)
+	if err != nil {
+		return err.Result()
+	}
+
+	tags := sdk.NewTags(
+		tags.Action, tags.ActionTokenizeShares,
+		tags.Delegator, []byte(msg.DelegatorAddr.String()),
+		tags.SrcValidator, []byte(msg.ValidatorAddr.String()),
+	)
+
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
+func handleMsgRedeemTokens(ctx sdk.Context, msg types.MsgRedeemTokens, k keeper.Keeper) sdk.Result {
+	valAddr, _, err := k.RedeemTokens(ctx, msg.DelegatorAddr, msg.Amount)
+	if err != nil {
+		return err.Result()
+	}
+
+	tags := sdk.NewTags(
+		tags.Action, tags.ActionRedeemTokens,
+		tags.Delegator, []byte(msg.DelegatorAddr.String()),
+		tags.DstValidator, []byte(valAddr.String()),
+	)
+
+	return sdk.Result{
+		Tags: tags,
+	}
+}
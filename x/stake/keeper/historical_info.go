@@ -0,0 +1,59 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// get the historical info entry at a height
+func (k Keeper) GetHistoricalInfo(ctx sdk.Context, height int64) (hi types.HistoricalInfo, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(GetHistoricalInfoKey(height))
+	if value == nil {
+		return hi, false
+	}
+	return types.MustUnmarshalHistoricalInfo(k.cdc, value), true
+}
+
+// set the historical info entry at a height
+func (k Keeper) SetHistoricalInfo(ctx sdk.Context, hi types.HistoricalInfo) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(hi)
+	store.Set(GetHistoricalInfoKey(hi.Height), bz)
+}
+
+// delete the historical info entry at a height
+func (k Keeper) DeleteHistoricalInfo(ctx sdk.Context, height int64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetHistoricalInfoKey(height))
+}
+
+// Record the bonded validator set of the current block along with the block's
+// header hash, and prune entries which are more than HistoricalEntries blocks
+// old. Must be called once per block, at the beginning of the block.
+func (k Keeper) TrackHistoricalInfo(ctx sdk.Context, headerHash []byte) {
+	entries := int64(k.GetParams(ctx).HistoricalEntries)
+	height := ctx.BlockHeight()
+
+	// prune all entries at or below height - entries, there may be more than
+	// one if the number of historical entries has been lowered
+	if pruneHeight := height - entries; pruneHeight >= 0 {
+		store := ctx.KVStore(k.storeKey)
+		iterator := store.Iterator(HistoricalInfoKey, GetHistoricalInfoKey(pruneHeight+1))
+		var pruneKeys [][]byte
+		for ; iterator.Valid(); iterator.Next() {
+			pruneKeys = append(pruneKeys, iterator.Key())
+		}
+		iterator.Close()
+		for _, key := range pruneKeys {
+			store.Delete(key)
+		}
+	}
+
+	if entries == 0 {
+		return
+	}
+
+	hi := types.NewHistoricalInfo(height, headerHash, k.GetValidatorsByPower(ctx))
+	k.SetHistoricalInfo(ctx, hi)
+}
@@ -0,0 +1,88 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestHistoricalInfo(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 0)
+
+	hi := types.NewHistoricalInfo(2, []byte("hash"), []types.Validator{})
+	keeper.SetHistoricalInfo(ctx, hi)
+
+	resHi, found := keeper.GetHistoricalInfo(ctx, 2)
+	require.True(t, found)
+	require.Equal(t, int64(2), resHi.Height)
+	require.Equal(t, []byte("hash"), []byte(resHi.HeaderHash))
+
+	keeper.DeleteHistoricalInfo(ctx, 2)
+	_, found = keeper.GetHistoricalInfo(ctx, 2)
+	require.False(t, found)
+}
+
+func TestTrackHistoricalInfo(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 0)
+	pool := keeper.GetPool(ctx)
+	pool.LooseTokens = sdk.NewDec(100)
+	keeper.SetPool(ctx, pool)
+
+	params := keeper.GetParams(ctx)
+	params.HistoricalEntries = 5
+	keeper.SetParams(ctx, params)
+
+	// create a bonded validator
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator, pool, _ = validator.AddTokensFromDel(pool, sdk.NewInt(100))
+	keeper.SetPool(ctx, pool)
+	validator = keeper.UpdateValidator(ctx, validator)
+	require.Equal(t, sdk.Bonded, validator.Status)
+
+	for height := int64(1); height <= 10; height++ {
+		ctx = ctx.WithBlockHeight(height)
+		keeper.TrackHistoricalInfo(ctx, []byte("hash"))
+	}
+
+	// only the latest HistoricalEntries entries are kept
+	for height := int64(1); height <= 5; height++ {
+		_, found := keeper.GetHistoricalInfo(ctx, height)
+		require.False(t, found, "height %d", height)
+	}
+	for height := int64(6); height <= 10; height++ {
+		hi, found := keeper.GetHistoricalInfo(ctx, height)
+		require.True(t, found, "height %d", height)
+		require.Equal(t, height, hi.Height)
+		require.Equal(t, 1, len(hi.Validators))
+		require.Equal(t, addrVals[0], hi.Validators[0].OperatorAddr)
+		require.True(t, validator.Tokens.Equal(hi.Validators[0].Tokens))
+		require.Equal(t, sdk.Bonded, hi.Validators[0].Status)
+	}
+
+	// lowering the number of entries prunes all the older entries at once
+	params.HistoricalEntries = 2
+	keeper.SetParams(ctx, params)
+	ctx = ctx.WithBlockHeight(11)
+	keeper.TrackHistoricalInfo(ctx, []byte("hash"))
+	for height := int64(6); height <= 9; height++ {
+		_, found := keeper.GetHistoricalInfo(ctx, height)
+		require.False(t, found, "height %d", height)
+	}
+	_, found := keeper.GetHistoricalInfo(ctx, 10)
+	require.True(t, found)
+	_, found = keeper.GetHistoricalInfo(ctx, 11)
+	require.True(t, found)
+
+	// with no entries to keep nothing is recorded
+	params.HistoricalEntries = 0
+	keeper.SetParams(ctx, params)
+	ctx = ctx.WithBlockHeight(12)
+	keeper.TrackHistoricalInfo(ctx, []byte("hash"))
+	for height := int64(10); height <= 12; height++ {
+		_, found := keeper.GetHistoricalInfo(ctx, height)
+		require.False(t, found, "height %d", height)
+	}
+}
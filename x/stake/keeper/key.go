@@ -2,6 +2,7 @@ package keeper
 
 import (
 	"encoding/binary"
+	"time"
 
 	"github.com/tendermint/tendermint/crypto"
 
@@ -29,9 +30,13 @@ var (
 	RedelegationKey                  = []byte{0x0C} // key for a redelegation
 	RedelegationByValSrcIndexKey     = []byte{0x0D} // prefix for each key for an redelegation, by source validator operator
 	RedelegationByValDstIndexKey     = []byte{0x0E} // prefix for each key for an redelegation, by destination validator operator
+	ConsPubKeyRotationKey            = []byte{0x0F} // prefix for each key to the latest consensus pubkey rotation of a validator
+	HistoricalInfoKey                = []byte{0x10} // prefix for each key to a historical info entry, by height
+	RetiredConsPubKeyQueueKey        = []byte{0x11} // prefix for each key to a consensus pubkey rotation, by time of rotation
 
 	// Keys for store prefixes (transient)
-	TendermintUpdatesTKey = []byte{0x00} // prefix for each key to a validator which is being updated
+	TendermintUpdatesTKey        = []byte{0x00} // prefix for each key to a validator which is being updated
+	TendermintRetiredUpdatesTKey = []byte{0x01} // prefix for each key to a retired consensus pubkey which is being removed
 )
 
 const maxDigitsForAccount = 12 // ~220,000,000 atoms created at launch
@@ -104,6 +109,44 @@ func GetTendermintUpdatesTKey(operatorAddr sdk.ValAddress) []byte {
 	return append(TendermintUpdatesTKey, operatorAddr.Bytes()...)
 }
 
+// get the key for the accumulated removals of the consensus pubkeys retired
+// by validators
+// VALUE: abci.Validator
+// note records using these keys should never persist between blocks
+func GetTendermintRetiredUpdatesTKey(operatorAddr sdk.ValAddress) []byte {
+	return append(TendermintRetiredUpdatesTKey, operatorAddr.Bytes()...)
+}
+
+// gets the key for the latest consensus pubkey rotation of a validator
+// VALUE: stake/types.ConsPubKeyRotation
+func GetConsPubKeyRotationKey(operatorAddr sdk.ValAddress) []byte {
+	return append(ConsPubKeyRotationKey, operatorAddr.Bytes()...)
+}
+
+// gets the key for the historical info entry at a height
+// VALUE: stake/types.HistoricalInfo
+func GetHistoricalInfoKey(height int64) []byte {
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, uint64(height))
+	return append(HistoricalInfoKey, heightBytes...)
+}
+
+// fixed width time format, so that the keys sort by time
+const sortableTimeFormat = "2006-01-02T15:04:05.000000000"
+
+// gets the prefix for the consensus pubkey rotations at a time
+func GetRetiredConsPubKeyQueueTimeKey(rotationTime time.Time) []byte {
+	timeBytes := []byte(rotationTime.UTC().Format(sortableTimeFormat))
+	return append(RetiredConsPubKeyQueueKey, timeBytes...)
+}
+
+// gets the key for the consensus pubkey rotation of a validator in the queue
+// of the retired pubkeys to prune
+// VALUE: none
+func GetRetiredConsPubKeyQueueKey(rotationTime time.Time, operatorAddr sdk.ValAddress) []byte {
+	return append(GetRetiredConsPubKeyQueueTimeKey(rotationTime), operatorAddr.Bytes()...)
+}
+
 //______________________________________________________________________________
 
 // gets the key for delegator bond with validator
@@ -0,0 +1,106 @@
+package keeper
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// Convert delegation shares into tokens of the validator's tokenized share
+// denom, one token for each share. The shares are moved to the validator's
+// tokenized share holder and stay delegated, so that the tokens are subject
+// to slashing through the exchange rate of the validator like any other
+// delegation shares. Shares received through a redelegation cannot be
+// tokenized until the redelegation has completed, as slashing of the
+// redelegation source validator is applied to the delegation of the
+// redelegating delegator.
+func (k Keeper) TokenizeShares(ctx sdk.Context, delAddr sdk.AccAddress,
+	valAddr sdk.ValAddress, shares sdk.Dec) (tokens sdk.Coin, err sdk.Error) {
+
+	validator, found := k.GetValidator(ctx, valAddr)
+	if !found {
+		return tokens, types.ErrNoValidatorFound(k.Codespace())
+	}
+	if bytes.Equal(delAddr, validator.OperatorAddr) {
+		return tokens, types.ErrTokenizeSelfDelegation(k.Codespace())
+	}
+	if k.HasReceivingRedelegation(ctx, delAddr, valAddr) {
+		return tokens, types.ErrTokenizeRedelegation(k.Codespace())
+	}
+
+	amount := shares.RoundInt()
+	if !shares.Equal(sdk.NewDecFromInt(amount)) {
+		return tokens, types.ErrNotIntegralShares(k.Codespace())
+	}
+
+	err = k.transferDelegationShares(ctx, delAddr, types.GetTokenizedShareHolder(valAddr), valAddr, shares)
+	if err != nil {
+		return tokens, err
+	}
+
+	tokens = sdk.NewCoin(types.GetTokenizedShareDenom(valAddr), amount)
+	_, _, err = k.bankKeeper.AddCoins(ctx, delAddr, sdk.Coins{tokens})
+	if err != nil {
+		return tokens, err
+	}
+	return tokens, nil
+}
+
+// Convert tokens of a validator's tokenized share denom back into delegation
+// shares of the validator, one share for each token.
+func (k Keeper) RedeemTokens(ctx sdk.Context, delAddr sdk.AccAddress,
+	tokens sdk.Coin) (valAddr sdk.ValAddress, shares sdk.Dec, err sdk.Error) {
+
+	valAddr, errRes := types.ParseTokenizedShareDenom(tokens.Denom)
+	if errRes != nil {
+		return nil, shares, types.ErrBadTokenizedShareDenom(k.Codespace(), tokens.Denom)
+	}
+
+	_, _, err = k.bankKeeper.SubtractCoins(ctx, delAddr, sdk.Coins{tokens})
+	if err != nil {
+		return nil, shares, err
+	}
+
+	shares = sdk.NewDecFromInt(tokens.Amount)
+	err = k.transferDelegationShares(ctx, types.GetTokenizedShareHolder(valAddr), delAddr, valAddr, shares)
+	if err != nil {
+		return nil, shares, err
+	}
+	return valAddr, shares, nil
+}
+
+// move delegation shares of a validator from one delegator to another
+// without changing the validator's tokens or delegator shares
+func (k Keeper) transferDelegationShares(ctx sdk.Context, srcDelAddr, dstDelAddr sdk.AccAddress,
+	valAddr sdk.ValAddress, shares sdk.Dec) sdk.Error {
+
+	srcDelegation, found := k.GetDelegation(ctx, srcDelAddr, valAddr)
+	if !found {
+		return types.ErrNoDelegatorForAddress(k.Codespace())
+	}
+	if srcDelegation.Shares.LT(shares) {
+		return types.ErrNotEnoughDelegationShares(k.Codespace(), srcDelegation.Shares.String())
+	}
+
+	srcDelegation.Shares = srcDelegation.Shares.Sub(shares)
+	if srcDelegation.Shares.IsZero() {
+		k.RemoveDelegation(ctx, srcDelegation)
+	} else {
+		srcDelegation.Height = ctx.BlockHeight()
+		k.SetDelegation(ctx, srcDelegation)
+	}
+
+	dstDelegation, found := k.GetDelegation(ctx, dstDelAddr, valAddr)
+	if !found {
+		dstDelegation = types.Delegation{
+			DelegatorAddr: dstDelAddr,
+			ValidatorAddr: valAddr,
+			Shares:        sdk.ZeroDec(),
+		}
+	}
+	dstDelegation.Shares = dstDelegation.Shares.Add(shares)
+	dstDelegation.Height = ctx.BlockHeight()
+	k.SetDelegation(ctx, dstDelegation)
+	return nil
+}
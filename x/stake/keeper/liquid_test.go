@@ -0,0 +1,137 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestTokenizeAndRedeemShares(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 100)
+	bondDenom := keeper.GetParams(ctx).BondDenom
+
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	keeper.SetValidator(ctx, validator)
+	keeper.SetValidatorByPubKeyIndex(ctx, validator)
+
+	_, err := keeper.Delegate(ctx, sdk.AccAddress(addrVals[0]), sdk.NewInt64Coin(bondDenom, 10), validator, true)
+	require.Nil(t, err)
+	validator, _ = keeper.GetValidator(ctx, addrVals[0])
+	_, err = keeper.Delegate(ctx, addrDels[0], sdk.NewInt64Coin(bondDenom, 20), validator, true)
+	require.Nil(t, err)
+
+	// the self-delegation cannot be tokenized
+	_, err = keeper.TokenizeShares(ctx, sdk.AccAddress(addrVals[0]), addrVals[0], sdk.NewDec(5))
+	require.NotNil(t, err)
+
+	// only integral amounts of shares can be tokenized
+	_, err = keeper.TokenizeShares(ctx, addrDels[0], addrVals[0], sdk.NewDecWithPrec(25, 1))
+	require.NotNil(t, err)
+
+	// cannot tokenize more shares than delegated
+	_, err = keeper.TokenizeShares(ctx, addrDels[0], addrVals[0], sdk.NewDec(21))
+	require.NotNil(t, err)
+
+	tokens, err := keeper.TokenizeShares(ctx, addrDels[0], addrVals[0], sdk.NewDec(20))
+	require.Nil(t, err)
+	denom := types.GetTokenizedShareDenom(addrVals[0])
+	require.Equal(t, sdk.NewInt64Coin(denom, 20), tokens)
+	require.Equal(t, int64(20), keeper.bankKeeper.GetCoins(ctx, addrDels[0]).AmountOf(denom).Int64())
+
+	// the shares are moved to the tokenized share holder and stay delegated
+	_, found := keeper.GetDelegation(ctx, addrDels[0], addrVals[0])
+	require.False(t, found)
+	holderDelegation, found := keeper.GetDelegation(ctx, types.GetTokenizedShareHolder(addrVals[0]), addrVals[0])
+	require.True(t, found)
+	require.True(t, sdk.NewDec(20).Equal(holderDelegation.Shares))
+	validator, found = keeper.GetValidator(ctx, addrVals[0])
+	require.True(t, found)
+	require.True(t, sdk.NewDec(30).Equal(validator.DelegatorShares))
+	require.True(t, sdk.NewDec(30).Equal(validator.Tokens))
+
+	// the tokens can be transferred
+	_, err = keeper.bankKeeper.SendCoins(ctx, addrDels[0], addrDels[1], sdk.Coins{tokens})
+	require.Nil(t, err)
+
+	// slashing is reflected through the exchange rate of the validator
+	keeper.Slash(ctx, PKs[0], 0, 30, sdk.NewDecWithPrec(5, 1))
+	validator, found = keeper.GetValidator(ctx, addrVals[0])
+	require.True(t, found)
+	require.True(t, sdk.NewDecWithPrec(5, 1).Equal(validator.DelegatorShareExRate()))
+
+	// tokens of an unknown denom cannot be redeemed
+	_, _, err = keeper.RedeemTokens(ctx, addrDels[1], sdk.NewInt64Coin(bondDenom, 5))
+	require.NotNil(t, err)
+
+	// cannot redeem more tokens than held
+	_, _, err = keeper.RedeemTokens(ctx, addrDels[1], sdk.NewInt64Coin(denom, 21))
+	require.NotNil(t, err)
+
+	valAddr, shares, err := keeper.RedeemTokens(ctx, addrDels[1], tokens)
+	require.Nil(t, err)
+	require.Equal(t, addrVals[0], valAddr)
+	require.True(t, sdk.NewDec(20).Equal(shares))
+	require.True(t, keeper.bankKeeper.GetCoins(ctx, addrDels[1]).AmountOf(denom).IsZero())
+
+	delegation, found := keeper.GetDelegation(ctx, addrDels[1], addrVals[0])
+	require.True(t, found)
+	require.True(t, sdk.NewDec(20).Equal(delegation.Shares))
+	require.True(t, sdk.NewDec(10).Equal(delegation.Shares.Mul(validator.DelegatorShareExRate())))
+	_, found = keeper.GetDelegation(ctx, types.GetTokenizedShareHolder(addrVals[0]), addrVals[0])
+	require.False(t, found)
+}
+
+// tests that shares received through a redelegation cannot escape slashing of
+// the redelegation source validator by being tokenized
+func TestTokenizeRedelegatedShares(t *testing.T) {
+	ctx, keeper, params := setupHelper(t, 10)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: time.Unix(100, 0)})
+
+	// set a redelegation which has not yet matured
+	rd := types.Redelegation{
+		DelegatorAddr:    addrDels[0],
+		ValidatorSrcAddr: addrVals[0],
+		ValidatorDstAddr: addrVals[1],
+		CreationHeight:   11,
+		MinTime:          time.Unix(200, 0),
+		SharesSrc:        sdk.NewDec(6),
+		SharesDst:        sdk.NewDec(6),
+		InitialBalance:   sdk.NewInt64Coin(params.BondDenom, 6),
+		Balance:          sdk.NewInt64Coin(params.BondDenom, 6),
+	}
+	keeper.SetRedelegation(ctx, rd)
+	keeper.SetDelegation(ctx, types.Delegation{
+		DelegatorAddr: addrDels[0],
+		ValidatorAddr: addrVals[1],
+		Shares:        sdk.NewDec(6),
+	})
+	pool := keeper.GetPool(ctx)
+	pool.BondedTokens = pool.BondedTokens.Add(sdk.NewDec(6))
+	keeper.SetPool(ctx, pool)
+
+	// the redelegated shares cannot be tokenized
+	_, err := keeper.TokenizeShares(ctx, addrDels[0], addrVals[1], sdk.NewDec(6))
+	require.NotNil(t, err)
+	_, found := keeper.GetDelegation(ctx, types.GetTokenizedShareHolder(addrVals[1]), addrVals[1])
+	require.False(t, found)
+
+	// slashing the source validator still reaches the redelegated shares
+	ctx = ctx.WithBlockHeight(12)
+	keeper.Slash(ctx, PKs[0], 10, 10, sdk.NewDecWithPrec(5, 1))
+	delegation, found := keeper.GetDelegation(ctx, addrDels[0], addrVals[1])
+	require.True(t, found)
+	require.True(t, sdk.NewDec(3).Equal(delegation.Shares))
+
+	// once the redelegation has completed the shares can be tokenized
+	ctx = ctx.WithBlockHeader(abci.Header{Time: time.Unix(200, 0)})
+	err = keeper.CompleteRedelegation(ctx, addrDels[0], addrVals[0], addrVals[1])
+	require.Nil(t, err)
+	tokens, err := keeper.TokenizeShares(ctx, addrDels[0], addrVals[1], sdk.NewDec(3))
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewInt64Coin(types.GetTokenizedShareDenom(addrVals[1]), 3), tokens)
+}
@@ -0,0 +1,87 @@
+package keeper
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/tags"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// turn the restaking of withdrawn rewards of a delegation on or off
+func (k Keeper) SetAutoRestake(ctx sdk.Context, delAddr sdk.AccAddress,
+	valAddr sdk.ValAddress, autoRestake bool) sdk.Error {
+
+	delegation, found := k.GetDelegation(ctx, delAddr, valAddr)
+	if !found {
+		return types.ErrNoDelegation(k.Codespace())
+	}
+
+	delegation.AutoRestake = autoRestake
+	k.SetDelegation(ctx, delegation)
+	return nil
+}
+
+// Restake the bond denom part of rewards withdrawn from a delegation, which
+// must already have been credited to the delegator's account. This is called
+// at each reward payout through the DelegationHooks; it is a no-op for delegations which do not
+// have AutoRestake set, in which case the rewards are left liquid.
+//
+// The rewards are delegated to the same validator through the regular
+// Delegate path, unless the validator is jailed and the delegation is not
+// the validator's self-delegation, mirroring the checks of MsgDelegate.
+func (k Keeper) RestakeRewards(ctx sdk.Context, delAddr sdk.AccAddress,
+	valAddr sdk.ValAddress, rewards sdk.Coins) (sdk.Tags, sdk.Error) {
+
+	delegation, found := k.GetDelegation(ctx, delAddr, valAddr)
+	if !found || !delegation.AutoRestake {
+		return nil, nil
+	}
+
+	bondDenom := k.GetParams(ctx).BondDenom
+	amount := rewards.AmountOf(bondDenom)
+	if !amount.GT(sdk.ZeroInt()) {
+		return nil, nil
+	}
+
+	validator, found := k.GetValidator(ctx, valAddr)
+	if !found {
+		return nil, types.ErrNoValidatorFound(k.Codespace())
+	}
+	if validator.Jailed && !bytes.Equal(validator.OperatorAddr, delAddr) {
+		return nil, nil
+	}
+
+	_, err := k.Delegate(ctx, delAddr, sdk.Coin{Denom: bondDenom, Amount: amount}, validator, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return sdk.NewTags(
+		tags.Action, tags.ActionRestake,
+		tags.Delegator, []byte(delAddr.String()),
+		tags.DstValidator, []byte(valAddr.String()),
+	), nil
+}
+
+//_______________________________________________________________________
+
+// Wrapper struct for sdk.DelegationHooks
+type DelegationHooks struct {
+	k Keeper
+}
+
+// Assert implementation
+var _ sdk.DelegationHooks = DelegationHooks{}
+
+// Return a sdk.DelegationHooks interface over the wrapper struct
+func (k Keeper) DelegationHooks() sdk.DelegationHooks {
+	return DelegationHooks{k}
+}
+
+// Implements sdk.DelegationHooks
+func (d DelegationHooks) OnDelegationRewardsWithdrawn(ctx sdk.Context, delAddr sdk.AccAddress,
+	valAddr sdk.ValAddress, rewards sdk.Coins) (sdk.Tags, sdk.Error) {
+
+	return d.k.RestakeRewards(ctx, delAddr, valAddr, rewards)
+}
@@ -0,0 +1,77 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestRestakeRewards(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 100)
+	hooks := keeper.DelegationHooks()
+	bondDenom := keeper.GetParams(ctx).BondDenom
+
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	keeper.SetValidator(ctx, validator)
+
+	// no delegation yet
+	err := keeper.SetAutoRestake(ctx, addrDels[0], addrVals[0], true)
+	require.NotNil(t, err)
+
+	_, err = keeper.Delegate(ctx, addrDels[0], sdk.NewInt64Coin(bondDenom, 10), validator, true)
+	require.Nil(t, err)
+
+	// rewards are credited to the delegator's account by the payout
+	payout := func(rewards sdk.Coins) {
+		_, _, err := keeper.bankKeeper.AddCoins(ctx, addrDels[0], rewards)
+		require.Nil(t, err)
+		pool := keeper.GetPool(ctx)
+		pool.LooseTokens = pool.LooseTokens.Add(sdk.NewDecFromInt(rewards.AmountOf(bondDenom)))
+		keeper.SetPool(ctx, pool)
+	}
+	rewards := sdk.Coins{sdk.NewInt64Coin("foocoin", 3), sdk.NewInt64Coin(bondDenom, 5)}
+
+	// rewards stay liquid without AutoRestake
+	payout(rewards)
+	tags, err := hooks.OnDelegationRewardsWithdrawn(ctx, addrDels[0], addrVals[0], rewards)
+	require.Nil(t, err)
+	require.Nil(t, tags)
+	delegation, found := keeper.GetDelegation(ctx, addrDels[0], addrVals[0])
+	require.True(t, found)
+	require.True(t, sdk.NewDec(10).Equal(delegation.Shares))
+	require.False(t, delegation.AutoRestake)
+
+	err = keeper.SetAutoRestake(ctx, addrDels[0], addrVals[0], true)
+	require.Nil(t, err)
+
+	// the bond denom part of the rewards is restaked
+	payout(rewards)
+	tags, err = hooks.OnDelegationRewardsWithdrawn(ctx, addrDels[0], addrVals[0], rewards)
+	require.Nil(t, err)
+	require.NotNil(t, tags)
+	delegation, found = keeper.GetDelegation(ctx, addrDels[0], addrVals[0])
+	require.True(t, found)
+	require.True(t, sdk.NewDec(15).Equal(delegation.Shares))
+	require.True(t, delegation.AutoRestake)
+
+	coins := keeper.bankKeeper.GetCoins(ctx, addrDels[0])
+	require.Equal(t, int64(95), coins.AmountOf(bondDenom).Int64())
+	require.Equal(t, int64(6), coins.AmountOf("foocoin").Int64())
+
+	// nothing is restaked into a jailed validator
+	validator, found = keeper.GetValidator(ctx, addrVals[0])
+	require.True(t, found)
+	validator.Jailed = true
+	keeper.SetValidator(ctx, validator)
+
+	payout(rewards)
+	tags, err = hooks.OnDelegationRewardsWithdrawn(ctx, addrDels[0], addrVals[0], rewards)
+	require.Nil(t, err)
+	require.Nil(t, tags)
+	delegation, found = keeper.GetDelegation(ctx, addrDels[0], addrVals[0])
+	require.True(t, found)
+	require.True(t, sdk.NewDec(15).Equal(delegation.Shares))
+}
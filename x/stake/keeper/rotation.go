@@ -0,0 +1,129 @@
+package keeper
+
+import (
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// get the latest consensus pubkey rotation of a validator
+func (k Keeper) GetConsPubKeyRotation(ctx sdk.Context, operatorAddr sdk.ValAddress) (rotation types.ConsPubKeyRotation, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetConsPubKeyRotationKey(operatorAddr))
+	if bz == nil {
+		return rotation, false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &rotation)
+	return rotation, true
+}
+
+// set the latest consensus pubkey rotation of a validator, queuing its
+// retired pubkey for pruning
+func (k Keeper) SetConsPubKeyRotation(ctx sdk.Context, rotation types.ConsPubKeyRotation) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinary(rotation)
+	store.Set(GetConsPubKeyRotationKey(rotation.OperatorAddr), bz)
+	store.Set(GetRetiredConsPubKeyQueueKey(rotation.Time, rotation.OperatorAddr), []byte{})
+}
+
+// remove the latest consensus pubkey rotation of a validator along with the
+// index of the retired pubkey
+func (k Keeper) RemoveConsPubKeyRotation(ctx sdk.Context, operatorAddr sdk.ValAddress) {
+	rotation, found := k.GetConsPubKeyRotation(ctx, operatorAddr)
+	if !found {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetValidatorByPubKeyIndexKey(rotation.OldConsPubKey))
+	store.Delete(GetConsPubKeyRotationKey(operatorAddr))
+	store.Delete(GetRetiredConsPubKeyQueueKey(rotation.Time, operatorAddr))
+}
+
+// Remove the retired pubkeys, along with their rotation records, whose
+// retention period of one unbonding period is over. Must be called once per
+// block, at the end of the block.
+func (k Keeper) PruneRetiredConsPubKeys(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	blockTime := ctx.BlockHeader().Time
+	unbondingTime := k.GetParams(ctx).UnbondingTime
+
+	// the rotations up to blockTime - unbondingTime, included
+	end := GetRetiredConsPubKeyQueueTimeKey(blockTime.Add(-unbondingTime).Add(1))
+	iterator := store.Iterator(RetiredConsPubKeyQueueKey, end)
+	var operatorAddrs []sdk.ValAddress
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		operatorAddrs = append(operatorAddrs, sdk.ValAddress(key[len(end):]))
+		store.Delete(key)
+	}
+	iterator.Close()
+
+	for _, operatorAddr := range operatorAddrs {
+		rotation, found := k.GetConsPubKeyRotation(ctx, operatorAddr)
+		if found && rotation.Expired(blockTime, unbondingTime) {
+			k.RemoveConsPubKeyRotation(ctx, operatorAddr)
+		}
+	}
+}
+
+// Replace the consensus pubkey of a validator.
+//
+// The old pubkey stays indexed to the validator until one unbonding period
+// after the rotation, when PruneRetiredConsPubKeys removes it, so that
+// evidence of infractions committed with it can still be slashed; as the
+// unbonding period is never shorter than the max evidence age this covers all
+// admissible evidence. A validator can only rotate its pubkey once per
+// unbonding period.
+//
+// If the old pubkey is in the Tendermint validator set, i.e. the validator was
+// bonded before this block, Tendermint is sent an update removing the old
+// pubkey and one adding the new pubkey with the validator's current power.
+func (k Keeper) RotateConsPubKey(ctx sdk.Context, operatorAddr sdk.ValAddress, newPubKey crypto.PubKey) sdk.Error {
+	validator, found := k.GetValidator(ctx, operatorAddr)
+	if !found {
+		return types.ErrNoValidatorFound(k.Codespace())
+	}
+
+	// the new pubkey must not be, or have recently been, in use by any validator
+	if _, found := k.GetValidatorByPubKey(ctx, newPubKey); found {
+		return types.ErrValidatorPubKeyExists(k.Codespace())
+	}
+
+	// rate limit rotations, dropping the retired pubkey of the previous
+	// rotation once its retention period is over
+	blockTime := ctx.BlockHeader().Time
+	unbondingTime := k.GetParams(ctx).UnbondingTime
+	if rotation, found := k.GetConsPubKeyRotation(ctx, operatorAddr); found {
+		if !rotation.Expired(blockTime, unbondingTime) {
+			return types.ErrConsPubKeyRotationTooSoon(k.Codespace(), rotation.Time.Add(unbondingTime))
+		}
+		k.RemoveConsPubKeyRotation(ctx, operatorAddr)
+	}
+
+	oldValidator := validator
+	validator.ConsPubKey = newPubKey
+	k.SetValidator(ctx, validator)
+	k.SetValidatorByPubKeyIndex(ctx, validator)
+	k.SetConsPubKeyRotation(ctx, types.NewConsPubKeyRotation(
+		operatorAddr, oldValidator.ConsPubKey, newPubKey, ctx.BlockHeight(), blockTime))
+
+	// swap the pubkeys in the Tendermint validator set, a validator bonded in
+	// this block is only sent with its new pubkey
+	if validator.Status == sdk.Bonded {
+		tstore := ctx.TransientStore(k.storeTKey)
+		if validator.BondHeight < ctx.BlockHeight() {
+			bz := k.cdc.MustMarshalBinary(oldValidator.ABCIValidatorZero())
+			tstore.Set(GetTendermintRetiredUpdatesTKey(operatorAddr), bz)
+		}
+		bz := k.cdc.MustMarshalBinary(validator.ABCIValidator())
+		tstore.Set(GetTendermintUpdatesTKey(operatorAddr), bz)
+	}
+
+	// call the rotation hook if present
+	if k.validatorHooks != nil {
+		k.validatorHooks.OnValidatorConsPubKeyRotated(ctx, oldValidator.ConsAddress(), validator.ConsAddress())
+	}
+
+	return nil
+}
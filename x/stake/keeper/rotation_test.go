@@ -0,0 +1,159 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestRotateConsPubKey(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 0)
+	pool := keeper.GetPool(ctx)
+	pool.LooseTokens = sdk.NewDec(100)
+	keeper.SetPool(ctx, pool)
+
+	// create a bonded validator
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator, pool, _ = validator.AddTokensFromDel(pool, sdk.NewInt(100))
+	keeper.SetPool(ctx, pool)
+	validator = keeper.UpdateValidator(ctx, validator)
+	keeper.SetValidatorByPubKeyIndex(ctx, validator)
+	require.Equal(t, sdk.Bonded, validator.Status)
+	clearTendermintUpdates(ctx, keeper)
+	ctx = ctx.WithBlockHeight(1)
+
+	// unknown validator
+	err := keeper.RotateConsPubKey(ctx, addrVals[1], PKs[1])
+	require.NotNil(t, err)
+
+	// pubkey already in use
+	err = keeper.RotateConsPubKey(ctx, addrVals[0], PKs[0])
+	require.NotNil(t, err)
+
+	// rotate
+	err = keeper.RotateConsPubKey(ctx, addrVals[0], PKs[1])
+	require.Nil(t, err)
+
+	validator, found := keeper.GetValidator(ctx, addrVals[0])
+	require.True(t, found)
+	require.Equal(t, PKs[1], validator.ConsPubKey)
+
+	// both the new and the retired pubkey map to the validator
+	resVal, found := keeper.GetValidatorByPubKey(ctx, PKs[1])
+	require.True(t, found)
+	require.Equal(t, addrVals[0], resVal.OperatorAddr)
+	resVal, found = keeper.GetValidatorByPubKey(ctx, PKs[0])
+	require.True(t, found)
+	require.Equal(t, addrVals[0], resVal.OperatorAddr)
+
+	// Tendermint swaps the old pubkey for the new one
+	updates := keeper.GetValidTendermintUpdates(ctx)
+	require.Equal(t, 2, len(updates))
+	require.Equal(t, validator.ABCIValidator(), updates[0])
+	require.Equal(t, abci.Validator{
+		PubKey:  updates[1].PubKey,
+		Address: PKs[0].Address(),
+		Power:   0,
+	}, updates[1])
+	clearTendermintUpdates(ctx, keeper)
+
+	rotation, found := keeper.GetConsPubKeyRotation(ctx, addrVals[0])
+	require.True(t, found)
+	require.Equal(t, PKs[0], rotation.OldConsPubKey)
+	require.Equal(t, PKs[1], rotation.NewConsPubKey)
+
+	// rotating again within the unbonding period is not allowed
+	err = keeper.RotateConsPubKey(ctx, addrVals[0], PKs[2])
+	require.NotNil(t, err)
+
+	// the retired pubkey is kept until the end of the unbonding period
+	header := ctx.BlockHeader()
+	header.Time = header.Time.Add(keeper.GetParams(ctx).UnbondingTime - 1)
+	ctx = ctx.WithBlockHeader(header)
+	keeper.PruneRetiredConsPubKeys(ctx)
+	_, found = keeper.GetValidatorByPubKey(ctx, PKs[0])
+	require.True(t, found)
+
+	// then it is dropped at the end of the block
+	header.Time = header.Time.Add(1)
+	ctx = ctx.WithBlockHeader(header)
+	keeper.PruneRetiredConsPubKeys(ctx)
+	_, found = keeper.GetValidatorByPubKey(ctx, PKs[0])
+	require.False(t, found)
+	_, found = keeper.GetConsPubKeyRotation(ctx, addrVals[0])
+	require.False(t, found)
+	resVal, found = keeper.GetValidatorByPubKey(ctx, PKs[1])
+	require.True(t, found)
+	require.Equal(t, addrVals[0], resVal.OperatorAddr)
+
+	err = keeper.RotateConsPubKey(ctx, addrVals[0], PKs[2])
+	require.Nil(t, err)
+
+	_, found = keeper.GetValidatorByPubKey(ctx, PKs[0])
+	require.False(t, found)
+	_, found = keeper.GetValidatorByPubKey(ctx, PKs[1])
+	require.True(t, found)
+	_, found = keeper.GetValidatorByPubKey(ctx, PKs[2])
+	require.True(t, found)
+}
+
+// a bonded validator rotating its pubkey and leaving the validator set in the
+// same block only has its retired pubkey removed from Tendermint
+func TestRotateConsPubKeyThenUnbond(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 0)
+	pool := keeper.GetPool(ctx)
+	pool.LooseTokens = sdk.NewDec(100)
+	keeper.SetPool(ctx, pool)
+
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator, pool, _ = validator.AddTokensFromDel(pool, sdk.NewInt(100))
+	keeper.SetPool(ctx, pool)
+	validator = keeper.UpdateValidator(ctx, validator)
+	keeper.SetValidatorByPubKeyIndex(ctx, validator)
+	require.Equal(t, sdk.Bonded, validator.Status)
+	clearTendermintUpdates(ctx, keeper)
+	ctx = ctx.WithBlockHeight(1)
+
+	err := keeper.RotateConsPubKey(ctx, addrVals[0], PKs[1])
+	require.Nil(t, err)
+	keeper.Jail(ctx, PKs[1])
+	validator, found := keeper.GetValidator(ctx, addrVals[0])
+	require.True(t, found)
+	require.Equal(t, sdk.Unbonding, validator.Status)
+
+	updates := keeper.GetValidTendermintUpdates(ctx)
+	require.Equal(t, 1, len(updates))
+	require.Equal(t, PKs[0].Address(), crypto.Address(updates[0].Address))
+	require.Equal(t, int64(0), updates[0].Power)
+}
+
+// a validator bonded in the block of its rotation is only sent to Tendermint
+// with its new pubkey
+func TestRotateConsPubKeyNewlyBonded(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 0)
+	pool := keeper.GetPool(ctx)
+	pool.LooseTokens = sdk.NewDec(100)
+	keeper.SetPool(ctx, pool)
+	ctx = ctx.WithBlockHeight(1)
+
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator, pool, _ = validator.AddTokensFromDel(pool, sdk.NewInt(100))
+	keeper.SetPool(ctx, pool)
+	validator = keeper.UpdateValidator(ctx, validator)
+	keeper.SetValidatorByPubKeyIndex(ctx, validator)
+	require.Equal(t, sdk.Bonded, validator.Status)
+
+	err := keeper.RotateConsPubKey(ctx, addrVals[0], PKs[1])
+	require.Nil(t, err)
+	validator, found := keeper.GetValidator(ctx, addrVals[0])
+	require.True(t, found)
+
+	updates := keeper.GetValidTendermintUpdates(ctx)
+	require.Equal(t, 1, len(updates))
+	require.Equal(t, validator.ABCIValidator(), updates[0])
+}
@@ -206,6 +206,14 @@ func (k Keeper) GetValidTendermintUpdates(ctx sdk.Context) (updates []abci.Valid
 		abciValBytes := iterator.Value()
 		k.cdc.MustUnmarshalBinary(abciValBytes, &abciVal)
 
+		// A validator which rotated its pubkey and then left the validator set
+		// in this block: its retired pubkey is removed below, and Tendermint
+		// never saw its new pubkey.
+		operatorAddr := sdk.ValAddress(iterator.Key()[len(TendermintUpdatesTKey):])
+		if abciVal.Power == 0 && tstore.Has(GetTendermintRetiredUpdatesTKey(operatorAddr)) {
+			continue
+		}
+
 		val, found := k.GetValidator(ctx, abciVal.GetAddress())
 		if found {
 			// The validator is new or already exists in the store and must adhere to
@@ -222,6 +230,17 @@ func (k Keeper) GetValidTendermintUpdates(ctx sdk.Context) (updates []abci.Valid
 			updates = append(updates, abciVal)
 		}
 	}
+
+	// Remove the consensus pubkeys retired by a rotation, these were part of
+	// the validator set as the validator was bonded at the time of rotation.
+	retiredIterator := sdk.KVStorePrefixIterator(tstore, TendermintRetiredUpdatesTKey)
+	defer retiredIterator.Close()
+
+	for ; retiredIterator.Valid(); retiredIterator.Next() {
+		var abciVal abci.Validator
+		k.cdc.MustUnmarshalBinary(retiredIterator.Value(), &abciVal)
+		updates = append(updates, abciVal)
+	}
 	return
 }
 
@@ -680,6 +699,7 @@ func (k Keeper) RemoveValidator(ctx sdk.Context, address sdk.ValAddress) {
 	store.Delete(GetValidatorKey(address))
 	store.Delete(GetValidatorByPubKeyIndexKey(validator.ConsPubKey))
 	store.Delete(GetValidatorsByPowerIndexKey(validator, pool))
+	k.RemoveConsPubKeyRotation(ctx, address)
 
 	// delete from the current and power weighted validator groups if the validator
 	// is bonded - and add validator with zero power to the validator updates
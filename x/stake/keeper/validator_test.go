@@ -15,12 +15,14 @@ import (
 func clearTendermintUpdates(ctx sdk.Context, k Keeper) {
 	store := ctx.TransientStore(k.storeTKey)
 
-	// delete subspace
-	iterator := sdk.KVStorePrefixIterator(store, TendermintUpdatesTKey)
-	for ; iterator.Valid(); iterator.Next() {
-		store.Delete(iterator.Key())
+	// delete subspaces
+	for _, prefix := range [][]byte{TendermintUpdatesTKey, TendermintRetiredUpdatesTKey} {
+		iterator := sdk.KVStorePrefixIterator(store, prefix)
+		for ; iterator.Valid(); iterator.Next() {
+			store.Delete(iterator.Key())
+		}
+		iterator.Close()
 	}
-	iterator.Close()
 }
 
 //_______________________________________________________
@@ -21,6 +21,7 @@ const (
 	QueryDelegatorValidator  = "delegatorValidator"
 	QueryPool                = "pool"
 	QueryParameters          = "parameters"
+	QueryHistoricalInfo      = "historicalInfo"
 )
 
 // creates a querier for staking REST endpoints
@@ -45,6 +46,8 @@ func NewQuerier(k keep.Keeper, cdc *codec.Codec) sdk.Querier {
 			return queryPool(ctx, cdc, k)
 		case QueryParameters:
 			return queryParameters(ctx, cdc, k)
+		case QueryHistoricalInfo:
+			return queryHistoricalInfo(ctx, cdc, req, k)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown stake query endpoint")
 		}
@@ -73,6 +76,12 @@ type QueryBondsParams struct {
 	ValidatorAddr sdk.ValAddress
 }
 
+// defines the params for the following queries:
+// - 'custom/stake/historicalInfo'
+type QueryHistoricalInfoParams struct {
+	Height int64
+}
+
 func queryValidators(ctx sdk.Context, cdc *codec.Codec, k keep.Keeper) (res []byte, err sdk.Error) {
 	stakeParams := k.GetParams(ctx)
 	validators := k.GetValidators(ctx, stakeParams.MaxValidators)
@@ -225,3 +234,23 @@ func queryParameters(ctx sdk.Context, cdc *codec.Codec, k keep.Keeper) (res []by
 	}
 	return res, nil
 }
+
+func queryHistoricalInfo(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k keep.Keeper) (res []byte, err sdk.Error) {
+	var params QueryHistoricalInfoParams
+
+	errRes := cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf("incorrectly formatted request data: %s", errRes.Error()))
+	}
+
+	hi, found := k.GetHistoricalInfo(ctx, params.Height)
+	if !found {
+		return []byte{}, types.ErrNoHistoricalInfo(types.DefaultCodespace)
+	}
+
+	res, errRes = codec.MarshalJSONIndent(cdc, hi)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", errRes.Error()))
+	}
+	return res, nil
+}
@@ -213,3 +213,35 @@ func TestQueryDelegation(t *testing.T) {
 
 	require.Equal(t, unbond, summary.UnbondingDelegations[0])
 }
+
+func TestQueryHistoricalInfo(t *testing.T) {
+	cdc := codec.New()
+	ctx, _, keeper := keep.CreateTestInput(t, false, 10000)
+
+	hi := types.NewHistoricalInfo(5, []byte("hash"), []types.Validator{})
+	keeper.SetHistoricalInfo(ctx, hi)
+
+	query := abci.RequestQuery{
+		Path: "/custom/stake/historicalInfo",
+		Data: []byte{},
+	}
+
+	bz, errRes := cdc.MarshalJSON(QueryHistoricalInfoParams{Height: 4})
+	require.Nil(t, errRes)
+	query.Data = bz
+
+	_, err := queryHistoricalInfo(ctx, cdc, query, keeper)
+	require.NotNil(t, err)
+
+	bz, errRes = cdc.MarshalJSON(QueryHistoricalInfoParams{Height: 5})
+	require.Nil(t, errRes)
+	query.Data = bz
+
+	res, err := queryHistoricalInfo(ctx, cdc, query, keeper)
+	require.Nil(t, err)
+
+	var resHi types.HistoricalInfo
+	errRes = cdc.UnmarshalJSON(res, &resHi)
+	require.Nil(t, errRes)
+	require.Equal(t, int64(5), resHi.Height)
+}
@@ -18,17 +18,25 @@ type (
 	Redelegation          = types.Redelegation
 	Params                = types.Params
 	Pool                  = types.Pool
+	ConsPubKeyRotation    = types.ConsPubKeyRotation
+	HistoricalInfo        = types.HistoricalInfo
 	MsgCreateValidator    = types.MsgCreateValidator
 	MsgEditValidator      = types.MsgEditValidator
+	MsgRotateConsPubKey   = types.MsgRotateConsPubKey
 	MsgDelegate           = types.MsgDelegate
+	MsgSetAutoRestake     = types.MsgSetAutoRestake
 	MsgBeginUnbonding     = types.MsgBeginUnbonding
 	MsgCompleteUnbonding  = types.MsgCompleteUnbonding
 	MsgBeginRedelegate    = types.MsgBeginRedelegate
 	MsgCompleteRedelegate = types.MsgCompleteRedelegate
+	MsgTokenizeShares     = types.MsgTokenizeShares
+	MsgRedeemTokens       = types.MsgRedeemTokens
 	GenesisState          = types.GenesisState
 	QueryDelegatorParams  = querier.QueryDelegatorParams
 	QueryValidatorParams  = querier.QueryValidatorParams
 	QueryBondsParams      = querier.QueryBondsParams
+
+	QueryHistoricalInfoParams = querier.QueryHistoricalInfoParams
 )
 
 var (
@@ -39,6 +47,8 @@ var (
 	GetValidatorsBondedIndexKey  = keeper.GetValidatorsBondedIndexKey
 	GetValidatorsByPowerIndexKey = keeper.GetValidatorsByPowerIndexKey
 	GetTendermintUpdatesTKey     = keeper.GetTendermintUpdatesTKey
+	GetConsPubKeyRotationKey     = keeper.GetConsPubKeyRotationKey
+	GetHistoricalInfoKey         = keeper.GetHistoricalInfoKey
 	GetDelegationKey             = keeper.GetDelegationKey
 	GetDelegationsKey            = keeper.GetDelegationsKey
 	ParamKey                     = keeper.ParamKey
@@ -75,11 +85,20 @@ var (
 	NewMsgCreateValidator           = types.NewMsgCreateValidator
 	NewMsgCreateValidatorOnBehalfOf = types.NewMsgCreateValidatorOnBehalfOf
 	NewMsgEditValidator             = types.NewMsgEditValidator
+	NewMsgRotateConsPubKey          = types.NewMsgRotateConsPubKey
 	NewMsgDelegate                  = types.NewMsgDelegate
+	NewMsgSetAutoRestake            = types.NewMsgSetAutoRestake
 	NewMsgBeginUnbonding            = types.NewMsgBeginUnbonding
 	NewMsgCompleteUnbonding         = types.NewMsgCompleteUnbonding
 	NewMsgBeginRedelegate           = types.NewMsgBeginRedelegate
 	NewMsgCompleteRedelegate        = types.NewMsgCompleteRedelegate
+	NewMsgTokenizeShares            = types.NewMsgTokenizeShares
+	NewMsgRedeemTokens              = types.NewMsgRedeemTokens
+
+	TokenizedShareDenomPrefix = types.TokenizedShareDenomPrefix
+	GetTokenizedShareDenom    = types.GetTokenizedShareDenom
+	ParseTokenizedShareDenom  = types.ParseTokenizedShareDenom
+	GetTokenizedShareHolder   = types.GetTokenizedShareHolder
 
 	NewQuerier = querier.NewQuerier
 )
@@ -106,6 +125,12 @@ var (
 	ErrCommissionNegative    = types.ErrCommissionNegative
 	ErrCommissionHuge        = types.ErrCommissionHuge
 
+	ErrNilConsPubKey             = types.ErrNilConsPubKey
+	ErrConsPubKeyRotationTooSoon = types.ErrConsPubKeyRotationTooSoon
+	ErrNotIntegralShares         = types.ErrNotIntegralShares
+	ErrTokenizeSelfDelegation    = types.ErrTokenizeSelfDelegation
+	ErrBadTokenizedShareDenom    = types.ErrBadTokenizedShareDenom
+
 	ErrNilDelegatorAddr          = types.ErrNilDelegatorAddr
 	ErrBadDenom                  = types.ErrBadDenom
 	ErrBadDelegationAmount       = types.ErrBadDelegationAmount
@@ -131,6 +156,7 @@ var (
 var (
 	ActionCreateValidator      = tags.ActionCreateValidator
 	ActionEditValidator        = tags.ActionEditValidator
+	ActionRotateConsPubKey     = tags.ActionRotateConsPubKey
 	ActionDelegate             = tags.ActionDelegate
 	ActionBeginUnbonding       = tags.ActionBeginUnbonding
 	ActionCompleteUnbonding    = tags.ActionCompleteUnbonding
@@ -8,11 +8,16 @@ import (
 var (
 	ActionCreateValidator      = []byte("create-validator")
 	ActionEditValidator        = []byte("edit-validator")
+	ActionRotateConsPubKey     = []byte("rotate-cons-pubkey")
 	ActionDelegate             = []byte("delegate")
+	ActionSetAutoRestake       = []byte("set-auto-restake")
+	ActionRestake              = []byte("restake")
 	ActionBeginUnbonding       = []byte("begin-unbonding")
 	ActionCompleteUnbonding    = []byte("complete-unbonding")
 	ActionBeginRedelegation    = []byte("begin-redelegation")
 	ActionCompleteRedelegation = []byte("complete-redelegation")
+	ActionTokenizeShares       = []byte("tokenize-shares")
+	ActionRedeemTokens         = []byte("redeem-tokens")
 
 	Action       = sdk.TagAction
 	SrcValidator = sdk.TagSrcValidator
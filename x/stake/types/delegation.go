@@ -16,12 +16,14 @@ type Delegation struct {
 	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
 	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
 	Shares        sdk.Dec        `json:"shares"`
-	Height        int64          `json:"height"` // Last height bond updated
+	Height        int64          `json:"height"`       // Last height bond updated
+	AutoRestake   bool           `json:"auto_restake"` // restake withdrawn rewards with the same validator
 }
 
 type delegationValue struct {
-	Shares sdk.Dec
-	Height int64
+	Shares      sdk.Dec
+	Height      int64
+	AutoRestake bool
 }
 
 // aggregates of all delegations, unbondings and redelegations
@@ -36,6 +38,7 @@ func MustMarshalDelegation(cdc *codec.Codec, delegation Delegation) []byte {
 	val := delegationValue{
 		delegation.Shares,
 		delegation.Height,
+		delegation.AutoRestake,
 	}
 	return cdc.MustMarshalBinary(val)
 }
@@ -72,6 +75,7 @@ func UnmarshalDelegation(cdc *codec.Codec, key, value []byte) (delegation Delega
 		ValidatorAddr: valAddr,
 		Shares:        storeValue.Shares,
 		Height:        storeValue.Height,
+		AutoRestake:   storeValue.AutoRestake,
 	}, nil
 }
 
@@ -80,7 +84,8 @@ func (d Delegation) Equal(d2 Delegation) bool {
 	return bytes.Equal(d.DelegatorAddr, d2.DelegatorAddr) &&
 		bytes.Equal(d.ValidatorAddr, d2.ValidatorAddr) &&
 		d.Height == d2.Height &&
-		d.Shares.Equal(d2.Shares)
+		d.Shares.Equal(d2.Shares) &&
+		d.AutoRestake == d2.AutoRestake
 }
 
 // ensure fulfills the sdk validator types
@@ -98,8 +103,9 @@ func (d Delegation) HumanReadableString() (string, error) {
 	resp := "Delegation \n"
 	resp += fmt.Sprintf("Delegator: %s\n", d.DelegatorAddr)
 	resp += fmt.Sprintf("Validator: %s\n", d.ValidatorAddr)
-	resp += fmt.Sprintf("Shares: %s", d.Shares.String())
-	resp += fmt.Sprintf("Height: %d", d.Height)
+	resp += fmt.Sprintf("Shares: %s\n", d.Shares.String())
+	resp += fmt.Sprintf("Height: %d\n", d.Height)
+	resp += fmt.Sprintf("Auto Restake: %v", d.AutoRestake)
 
 	return resp, nil
 }
@@ -44,6 +44,15 @@ func ErrValidatorPubKeyExists(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidValidator, "validator already exist for this pubkey, must use new validator pubkey")
 }
 
+func ErrNilConsPubKey(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, "consensus pubkey is nil")
+}
+
+func ErrConsPubKeyRotationTooSoon(codespace sdk.CodespaceType, next time.Time) sdk.Error {
+	msg := fmt.Sprintf("consensus pubkey was rotated too recently, next rotation allowed at %v", next)
+	return sdk.NewError(codespace, CodeInvalidValidator, msg)
+}
+
 func ErrValidatorJailed(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidValidator, "validator for this address is currently jailed")
 }
@@ -152,6 +161,27 @@ func ErrNeitherShareMsgsGiven(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidInput, "neither shares amount nor shares percent provided")
 }
 
+func ErrNotIntegralShares(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDelegation, "only an integral amount of shares can be tokenized")
+}
+
+func ErrTokenizeSelfDelegation(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDelegation, "the self-delegation of a validator cannot be tokenized")
+}
+
+func ErrTokenizeRedelegation(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDelegation,
+		"redelegation to this validator in progress, the redelegation must complete before its shares can be tokenized")
+}
+
+func ErrBadTokenizedShareDenom(codespace sdk.CodespaceType, denom string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDelegation, fmt.Sprintf("%s is not a tokenized share denom", denom))
+}
+
+func ErrNoHistoricalInfo(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, "no historical info found for this height")
+}
+
 func ErrMissingSignature(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidValidator, "missing signature")
 }
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"fmt"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// HistoricalInfo is a compact record of the bonded validator set, sorted by
+// power, as of the beginning of a block
+type HistoricalInfo struct {
+	Height     int64                 `json:"height"`
+	HeaderHash cmn.HexBytes          `json:"header_hash"`
+	Validators []HistoricalValidator `json:"validators"`
+}
+
+// HistoricalValidator is the part of a validator kept in a HistoricalInfo
+type HistoricalValidator struct {
+	OperatorAddr    sdk.ValAddress `json:"operator_address"`
+	Tokens          sdk.Dec        `json:"tokens"`
+	DelegatorShares sdk.Dec        `json:"delegator_shares"`
+	Status          sdk.BondStatus `json:"status"`
+}
+
+// NewHistoricalInfo - initialize a new historical info entry from a set of validators
+func NewHistoricalInfo(height int64, headerHash []byte, validators []Validator) HistoricalInfo {
+	historicalValidators := make([]HistoricalValidator, len(validators))
+	for i, validator := range validators {
+		historicalValidators[i] = HistoricalValidator{
+			OperatorAddr:    validator.OperatorAddr,
+			Tokens:          validator.Tokens,
+			DelegatorShares: validator.DelegatorShares,
+			Status:          validator.Status,
+		}
+	}
+	return HistoricalInfo{
+		Height:     height,
+		HeaderHash: headerHash,
+		Validators: historicalValidators,
+	}
+}
+
+// unmarshal a historical info entry from a store value
+func MustUnmarshalHistoricalInfo(cdc *codec.Codec, value []byte) HistoricalInfo {
+	hi, err := UnmarshalHistoricalInfo(cdc, value)
+	if err != nil {
+		panic(err)
+	}
+	return hi
+}
+
+// unmarshal a historical info entry from a store value
+func UnmarshalHistoricalInfo(cdc *codec.Codec, value []byte) (hi HistoricalInfo, err error) {
+	err = cdc.UnmarshalBinary(value, &hi)
+	return hi, err
+}
+
+// HumanReadableString returns a human readable string representation of a
+// historical info entry
+func (hi HistoricalInfo) HumanReadableString() string {
+	resp := "Historical Info \n"
+	resp += fmt.Sprintf("Height: %d\n", hi.Height)
+	resp += fmt.Sprintf("Header Hash: %s\n", hi.HeaderHash)
+	for _, v := range hi.Validators {
+		resp += fmt.Sprintf("Validator: %s, Tokens: %s, Delegator Shares: %s, Status: %s\n",
+			v.OperatorAddr, v.Tokens, v.DelegatorShares, sdk.BondStatusToString(v.Status))
+	}
+	return resp
+}
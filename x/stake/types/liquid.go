@@ -0,0 +1,37 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// TokenizedShareDenomPrefix prefixes the denom of the bank-transferable
+// tokens representing delegation shares tokenized with a validator
+const TokenizedShareDenomPrefix = "delshare"
+
+// GetTokenizedShareDenom returns the denom of the tokens representing
+// delegation shares of a validator, one token being worth one share
+func GetTokenizedShareDenom(valAddr sdk.ValAddress) string {
+	return fmt.Sprintf("%s/%s", TokenizedShareDenomPrefix, valAddr)
+}
+
+// ParseTokenizedShareDenom returns the validator whose delegation shares are
+// represented by tokens of a denom
+func ParseTokenizedShareDenom(denom string) (sdk.ValAddress, error) {
+	prefix := TokenizedShareDenomPrefix + "/"
+	if !strings.HasPrefix(denom, prefix) {
+		return nil, fmt.Errorf("%s is not a tokenized share denom", denom)
+	}
+	return sdk.ValAddressFromBech32(strings.TrimPrefix(denom, prefix))
+}
+
+// GetTokenizedShareHolder returns the address holding the delegation shares
+// tokenized with a validator. No private key exists for this address.
+func GetTokenizedShareHolder(valAddr sdk.ValAddress) sdk.AccAddress {
+	bz := append([]byte(TokenizedShareDenomPrefix), valAddr.Bytes()...)
+	return sdk.AccAddress(tmhash.Sum(bz)[:sdk.AddrLen])
+}
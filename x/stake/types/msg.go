@@ -14,6 +14,8 @@ const MsgType = "stake"
 var _, _, _ sdk.Msg = &MsgCreateValidator{}, &MsgEditValidator{}, &MsgDelegate{}
 var _, _ sdk.Msg = &MsgBeginUnbonding{}, &MsgCompleteUnbonding{}
 var _, _ sdk.Msg = &MsgBeginRedelegate{}, &MsgCompleteRedelegate{}
+var _, _ sdk.Msg = &MsgRotateConsPubKey{}, &MsgSetAutoRestake{}
+var _, _ sdk.Msg = &MsgTokenizeShares{}, &MsgRedeemTokens{}
 
 //______________________________________________________________________
 
@@ -151,6 +153,53 @@ func (msg MsgEditValidator) ValidateBasic() sdk.Error {
 
 //______________________________________________________________________
 
+// MsgRotateConsPubKey - struct for replacing the consensus pubkey of a validator
+type MsgRotateConsPubKey struct {
+	ValidatorAddr sdk.ValAddress `json:"address"`
+	NewPubKey     crypto.PubKey  `json:"new_pubkey"`
+}
+
+func NewMsgRotateConsPubKey(valAddr sdk.ValAddress, newPubKey crypto.PubKey) MsgRotateConsPubKey {
+	return MsgRotateConsPubKey{
+		ValidatorAddr: valAddr,
+		NewPubKey:     newPubKey,
+	}
+}
+
+//nolint
+func (msg MsgRotateConsPubKey) Type() string { return MsgType }
+func (msg MsgRotateConsPubKey) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddr)}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgRotateConsPubKey) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(struct {
+		ValidatorAddr sdk.ValAddress `json:"address"`
+		NewPubKey     string         `json:"new_pubkey"`
+	}{
+		ValidatorAddr: msg.ValidatorAddr,
+		NewPubKey:     sdk.MustBech32ifyConsPub(msg.NewPubKey),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgRotateConsPubKey) ValidateBasic() sdk.Error {
+	if msg.ValidatorAddr == nil {
+		return ErrNilValidatorAddr(DefaultCodespace)
+	}
+	if msg.NewPubKey == nil {
+		return ErrNilConsPubKey(DefaultCodespace)
+	}
+	return nil
+}
+
+//______________________________________________________________________
+
 // MsgDelegate - struct for bonding transactions
 type MsgDelegate struct {
 	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
@@ -197,6 +246,50 @@ func (msg MsgDelegate) ValidateBasic() sdk.Error {
 
 //______________________________________________________________________
 
+// MsgSetAutoRestake - struct for turning the restaking of withdrawn rewards
+// of a delegation on or off
+type MsgSetAutoRestake struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	AutoRestake   bool           `json:"auto_restake"`
+}
+
+func NewMsgSetAutoRestake(delAddr sdk.AccAddress, valAddr sdk.ValAddress, autoRestake bool) MsgSetAutoRestake {
+	return MsgSetAutoRestake{
+		DelegatorAddr: delAddr,
+		ValidatorAddr: valAddr,
+		AutoRestake:   autoRestake,
+	}
+}
+
+//nolint
+func (msg MsgSetAutoRestake) Type() string { return MsgType }
+func (msg MsgSetAutoRestake) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgSetAutoRestake) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgSetAutoRestake) ValidateBasic() sdk.Error {
+	if msg.DelegatorAddr == nil {
+		return ErrNilDelegatorAddr(DefaultCodespace)
+	}
+	if msg.ValidatorAddr == nil {
+		return ErrNilValidatorAddr(DefaultCodespace)
+	}
+	return nil
+}
+
+//______________________________________________________________________
+
 // MsgDelegate - struct for bonding transactions
 type MsgBeginRedelegate struct {
 	DelegatorAddr    sdk.AccAddress `json:"delegator_addr"`
@@ -392,3 +485,98 @@ func (msg MsgCompleteUnbonding) ValidateBasic() sdk.Error {
 	}
 	return nil
 }
+
+//______________________________________________________________________
+
+// MsgTokenizeShares - struct for converting delegation shares into
+// bank-transferable tokens of a denom unique to the validator
+type MsgTokenizeShares struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	SharesAmount  sdk.Dec        `json:"shares_amount"`
+}
+
+func NewMsgTokenizeShares(delAddr sdk.AccAddress, valAddr sdk.ValAddress, sharesAmount sdk.Dec) MsgTokenizeShares {
+	return MsgTokenizeShares{
+		DelegatorAddr: delAddr,
+		ValidatorAddr: valAddr,
+		SharesAmount:  sharesAmount,
+	}
+}
+
+//nolint
+func (msg MsgTokenizeShares) Type() string { return MsgType }
+func (msg MsgTokenizeShares) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgTokenizeShares) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgTokenizeShares) ValidateBasic() sdk.Error {
+	if msg.DelegatorAddr == nil {
+		return ErrNilDelegatorAddr(DefaultCodespace)
+	}
+	if msg.ValidatorAddr == nil {
+		return ErrNilValidatorAddr(DefaultCodespace)
+	}
+	if msg.SharesAmount.LTE(sdk.ZeroDec()) {
+		return ErrBadSharesAmount(DefaultCodespace)
+	}
+	if !msg.SharesAmount.Equal(sdk.NewDecFromInt(msg.SharesAmount.RoundInt())) {
+		return ErrNotIntegralShares(DefaultCodespace)
+	}
+	return nil
+}
+
+//______________________________________________________________________
+
+// MsgRedeemTokens - struct for converting tokenized delegation shares back
+// into a delegation
+type MsgRedeemTokens struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	Amount        sdk.Coin       `json:"amount"`
+}
+
+func NewMsgRedeemTokens(delAddr sdk.AccAddress, amount sdk.Coin) MsgRedeemTokens {
+	return MsgRedeemTokens{
+		DelegatorAddr: delAddr,
+		Amount:        amount,
+	}
+}
+
+//nolint
+func (msg MsgRedeemTokens) Type() string { return MsgType }
+func (msg MsgRedeemTokens) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgRedeemTokens) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgRedeemTokens) ValidateBasic() sdk.Error {
+	if msg.DelegatorAddr == nil {
+		return ErrNilDelegatorAddr(DefaultCodespace)
+	}
+	if !(msg.Amount.Amount.GT(sdk.ZeroInt())) {
+		return ErrBadSharesAmount(DefaultCodespace)
+	}
+	if _, err := ParseTokenizedShareDenom(msg.Amount.Denom); err != nil {
+		return ErrBadTokenizedShareDenom(DefaultCodespace, msg.Amount.Denom)
+	}
+	return nil
+}
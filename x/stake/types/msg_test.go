@@ -69,6 +69,29 @@ func TestMsgEditValidator(t *testing.T) {
 	}
 }
 
+// test ValidateBasic for MsgRotateConsPubKey
+func TestMsgRotateConsPubKey(t *testing.T) {
+	tests := []struct {
+		name          string
+		validatorAddr sdk.ValAddress
+		pubkey        crypto.PubKey
+		expectPass    bool
+	}{
+		{"basic good", addr1, pk1, true},
+		{"empty address", emptyAddr, pk1, false},
+		{"empty pubkey", addr1, emptyPubkey, false},
+	}
+
+	for _, tc := range tests {
+		msg := NewMsgRotateConsPubKey(tc.validatorAddr, tc.pubkey)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
+
 // test ValidateBasic and GetSigners for MsgCreateValidatorOnBehalfOf
 func TestMsgCreateValidatorOnBehalfOf(t *testing.T) {
 	tests := []struct {
@@ -136,6 +159,31 @@ func TestMsgDelegate(t *testing.T) {
 	}
 }
 
+// test ValidateBasic for MsgSetAutoRestake
+func TestMsgSetAutoRestake(t *testing.T) {
+	tests := []struct {
+		name          string
+		delegatorAddr sdk.AccAddress
+		validatorAddr sdk.ValAddress
+		autoRestake   bool
+		expectPass    bool
+	}{
+		{"enable", sdk.AccAddress(addr1), addr2, true, true},
+		{"disable", sdk.AccAddress(addr1), addr2, false, true},
+		{"empty delegator", sdk.AccAddress(emptyAddr), addr1, true, false},
+		{"empty validator", sdk.AccAddress(addr1), emptyAddr, true, false},
+	}
+
+	for _, tc := range tests {
+		msg := NewMsgSetAutoRestake(tc.delegatorAddr, tc.validatorAddr, tc.autoRestake)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
+
 // test ValidateBasic for MsgUnbond
 func TestMsgBeginRedelegate(t *testing.T) {
 	tests := []struct {
@@ -237,3 +285,55 @@ func TestMsgCompleteUnbonding(t *testing.T) {
 		}
 	}
 }
+
+// test ValidateBasic for MsgTokenizeShares
+func TestMsgTokenizeShares(t *testing.T) {
+	tests := []struct {
+		name          string
+		delegatorAddr sdk.AccAddress
+		validatorAddr sdk.ValAddress
+		sharesAmount  sdk.Dec
+		expectPass    bool
+	}{
+		{"regular", sdk.AccAddress(addr1), addr2, sdk.NewDec(10), true},
+		{"fractional shares", sdk.AccAddress(addr1), addr2, sdk.NewDecWithPrec(105, 1), false},
+		{"zero shares", sdk.AccAddress(addr1), addr2, sdk.ZeroDec(), false},
+		{"negative shares", sdk.AccAddress(addr1), addr2, sdk.NewDec(-1), false},
+		{"empty delegator", sdk.AccAddress(emptyAddr), addr1, sdk.NewDec(10), false},
+		{"empty validator", sdk.AccAddress(addr1), emptyAddr, sdk.NewDec(10), false},
+	}
+
+	for _, tc := range tests {
+		msg := NewMsgTokenizeShares(tc.delegatorAddr, tc.validatorAddr, tc.sharesAmount)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
+
+// test ValidateBasic for MsgRedeemTokens
+func TestMsgRedeemTokens(t *testing.T) {
+	denom := GetTokenizedShareDenom(addr2)
+	tests := []struct {
+		name          string
+		delegatorAddr sdk.AccAddress
+		amount        sdk.Coin
+		expectPass    bool
+	}{
+		{"regular", sdk.AccAddress(addr1), sdk.NewInt64Coin(denom, 10), true},
+		{"zero amount", sdk.AccAddress(addr1), sdk.NewInt64Coin(denom, 0), false},
+		{"not a tokenized share denom", sdk.AccAddress(addr1), coinPos, false},
+		{"empty delegator", sdk.AccAddress(emptyAddr), sdk.NewInt64Coin(denom, 10), false},
+	}
+
+	for _, tc := range tests {
+		msg := NewMsgRedeemTokens(tc.delegatorAddr, tc.amount)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
@@ -22,8 +22,9 @@ type Params struct {
 
 	UnbondingTime time.Duration `json:"unbonding_time"`
 
-	MaxValidators uint16 `json:"max_validators"` // maximum number of validators
-	BondDenom     string `json:"bond_denom"`     // bondable coin denomination
+	MaxValidators     uint16 `json:"max_validators"`     // maximum number of validators
+	BondDenom         string `json:"bond_denom"`         // bondable coin denomination
+	HistoricalEntries uint16 `json:"historical_entries"` // number of historical info entries to keep
 }
 
 // Equal returns a boolean determining if two Param types are identical.
@@ -43,6 +44,7 @@ func DefaultParams() Params {
 		UnbondingTime:       defaultUnbondingTime,
 		MaxValidators:       100,
 		BondDenom:           "steak",
+		HistoricalEntries:   100,
 	}
 }
 
@@ -58,6 +60,7 @@ func (p Params) HumanReadableString() string {
 	resp += fmt.Sprintf("Unbonding Time: %s\n", p.UnbondingTime)
 	resp += fmt.Sprintf("Max Validators: %d: \n", p.MaxValidators)
 	resp += fmt.Sprintf("Bonded Coin Denomination: %s\n", p.BondDenom)
+	resp += fmt.Sprintf("Historical Entries: %d\n", p.HistoricalEntries)
 	return resp
 }
 
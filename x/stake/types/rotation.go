@@ -0,0 +1,61 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ConsPubKeyRotation records the most recent consensus pubkey rotation of a
+// validator. The old pubkey remains indexed to the validator for one unbonding
+// period after the rotation so that evidence of infractions committed with it
+// can still be handled, and no further rotation is allowed within that period.
+type ConsPubKeyRotation struct {
+	OperatorAddr  sdk.ValAddress `json:"operator_address"`
+	OldConsPubKey crypto.PubKey  `json:"old_consensus_pubkey"`
+	NewConsPubKey crypto.PubKey  `json:"new_consensus_pubkey"`
+	Height        int64          `json:"height"` // height at which the rotation took place
+	Time          time.Time      `json:"time"`   // time at which the rotation took place
+}
+
+// NewConsPubKeyRotation - initialize a new consensus pubkey rotation record
+func NewConsPubKeyRotation(operator sdk.ValAddress, oldPubKey, newPubKey crypto.PubKey,
+	height int64, time time.Time) ConsPubKeyRotation {
+
+	return ConsPubKeyRotation{
+		OperatorAddr:  operator,
+		OldConsPubKey: oldPubKey,
+		NewConsPubKey: newPubKey,
+		Height:        height,
+		Time:          time,
+	}
+}
+
+// Expired returns whether the retention period of the old pubkey has passed
+func (r ConsPubKeyRotation) Expired(blockTime time.Time, unbondingTime time.Duration) bool {
+	return !blockTime.Before(r.Time.Add(unbondingTime))
+}
+
+// HumanReadableString returns a human readable string representation of a
+// consensus pubkey rotation
+func (r ConsPubKeyRotation) HumanReadableString() (string, error) {
+	bechOld, err := sdk.Bech32ifyConsPub(r.OldConsPubKey)
+	if err != nil {
+		return "", err
+	}
+	bechNew, err := sdk.Bech32ifyConsPub(r.NewConsPubKey)
+	if err != nil {
+		return "", err
+	}
+
+	resp := "Consensus PubKey Rotation \n"
+	resp += fmt.Sprintf("Operator: %s\n", r.OperatorAddr)
+	resp += fmt.Sprintf("Old Consensus PubKey: %s\n", bechOld)
+	resp += fmt.Sprintf("New Consensus PubKey: %s\n", bechNew)
+	resp += fmt.Sprintf("Height: %d\n", r.Height)
+	resp += fmt.Sprintf("Time: %v\n", r.Time)
+	return resp, nil
+}